@@ -0,0 +1,263 @@
+package core
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// スクリプトの仕様
+// https://en.bitcoin.it/wiki/Script
+
+// maxScriptSize はスクリプトの最大サイズ
+const maxScriptSize = 10000
+
+// OpCode はスクリプトの命令を表す型
+type OpCode byte
+
+const (
+	// Op0 は空のバイト列をスタックに積む
+	Op0 OpCode = 0x00
+	// OpPushData1 は次の1バイトの長さのデータをスタックに積む
+	OpPushData1 OpCode = 0x4c
+	// OpPushData2 は次の2バイトの長さのデータをスタックに積む
+	OpPushData2 OpCode = 0x4d
+	// OpPushData4 は次の4バイトの長さのデータをスタックに積む
+	OpPushData4 OpCode = 0x4e
+	// Op1Negate は-1をスタックに積む
+	Op1Negate OpCode = 0x4f
+	// Op1 は1をスタックに積む、Op2からOp16も同様
+	Op1 OpCode = 0x51
+	// Op16 は16をスタックに積む
+	Op16 OpCode = 0x60
+	// OpIf はスタックの先頭が真なら続く命令を実行する
+	OpIf OpCode = 0x63
+	// OpNotIf はスタックの先頭が偽なら続く命令を実行する
+	OpNotIf OpCode = 0x64
+	// OpElse はOpIfの条件を満たさない場合に実行する命令の始まり
+	OpElse OpCode = 0x67
+	// OpEndIf はOpIfの終わり
+	OpEndIf OpCode = 0x68
+	// OpReturn はスクリプトを失敗させる、データの埋め込みに使う
+	OpReturn OpCode = 0x6a
+	// OpIfDup はスタックの先頭が真なら複製する
+	OpIfDup OpCode = 0x73
+	// OpDrop はスタックの先頭を取り除く
+	OpDrop OpCode = 0x75
+	// OpDup はスタックの先頭を複製する
+	OpDup OpCode = 0x76
+	// OpEqual はスタックの先頭2つが等しいか判定する
+	OpEqual OpCode = 0x87
+	// OpEqualVerify はOpEqualの後にOpVerifyを実行する
+	OpEqualVerify OpCode = 0x88
+	// OpHash160 はスタックの先頭をripemd160(sha256(x))に置き換える
+	OpHash160 OpCode = 0xa9
+	// OpCheckSig は署名を検証する
+	OpCheckSig OpCode = 0xac
+	// OpCheckMultiSig は複数の署名を検証する
+	OpCheckMultiSig OpCode = 0xae
+	// OpCheckLockTimeVerify はトランザクションのロックタイムを検証する(BIP65)
+	OpCheckLockTimeVerify OpCode = 0xb1
+	// OpCheckSequenceVerify は入力の相対ロックタイムを検証する(BIP112)
+	OpCheckSequenceVerify OpCode = 0xb2
+)
+
+// ScriptBuilder はスクリプトを組み立てるための型
+type ScriptBuilder struct {
+	script []byte
+}
+
+// NewScriptBuilder は空のスクリプトのScriptBuilderを生成します
+func NewScriptBuilder() *ScriptBuilder {
+	return &ScriptBuilder{}
+}
+
+// AddOp はスクリプトに命令を追加します
+func (b *ScriptBuilder) AddOp(op OpCode) *ScriptBuilder {
+	b.script = append(b.script, byte(op))
+	return b
+}
+
+// AddData はデータをスタックに積む命令を追加します
+// データの長さに応じて最小のプッシュ命令を使います
+func (b *ScriptBuilder) AddData(data []byte) *ScriptBuilder {
+	n := len(data)
+	switch {
+	case n == 0:
+		return b.AddOp(Op0)
+	case n == 1 && 1 <= data[0] && data[0] <= 16:
+		return b.AddOp(Op1 + OpCode(data[0]-1))
+	case n == 1 && data[0] == 0x81:
+		return b.AddOp(Op1Negate)
+	case n < int(OpPushData1):
+		b.script = append(b.script, byte(n))
+	case n <= 0xff:
+		b.script = append(b.script, byte(OpPushData1), byte(n))
+	case n <= 0xffff:
+		b.script = append(b.script, byte(OpPushData2), 0, 0)
+		binary.LittleEndian.PutUint16(b.script[len(b.script)-2:], uint16(n))
+	default:
+		b.script = append(b.script, byte(OpPushData4), 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(b.script[len(b.script)-4:], uint32(n))
+	}
+	b.script = append(b.script, data...)
+	return b
+}
+
+// AddInt64 は数値をスタックに積む命令を追加します
+// -1から16まではその数値を表す命令を使い、それ以外はスクリプトの数値形式でプッシュします
+func (b *ScriptBuilder) AddInt64(v int64) *ScriptBuilder {
+	switch {
+	case v == 0:
+		return b.AddOp(Op0)
+	case v == -1:
+		return b.AddOp(Op1Negate)
+	case 1 <= v && v <= 16:
+		return b.AddOp(Op1 + OpCode(v-1))
+	}
+	return b.AddData(scriptNum(v))
+}
+
+// Script は組み立てたスクリプトを返します
+func (b *ScriptBuilder) Script() []byte {
+	return b.script
+}
+
+// scriptNum は数値をスクリプトの数値形式(符号付きリトルエンディアン)に変換する
+func scriptNum(v int64) []byte {
+	if v == 0 {
+		return []byte{}
+	}
+
+	negative := v < 0
+	abs := uint64(v)
+	if negative {
+		abs = uint64(-v)
+	}
+
+	result := []byte{}
+	for 0 < abs {
+		result = append(result, byte(abs&0xff))
+		abs >>= 8
+	}
+
+	// 最上位ビットは符号として使うので、既に立っている場合は1バイト追加する
+	if result[len(result)-1]&0x80 != 0 {
+		extra := byte(0x00)
+		if negative {
+			extra = 0x80
+		}
+		result = append(result, extra)
+	} else if negative {
+		result[len(result)-1] |= 0x80
+	}
+	return result
+}
+
+// NewNullDataScript はデータを埋め込むためのOP_RETURNの出力スクリプトを生成します
+// OP_RETURN <data>...
+func NewNullDataScript(data ...[]byte) []byte {
+	b := NewScriptBuilder().AddOp(OpReturn)
+	for _, d := range data {
+		b.AddData(d)
+	}
+	return b.Script()
+}
+
+// ExtractNullData はOP_RETURNの出力スクリプトに埋め込まれたデータを取り出します
+// OP_RETURNの後がデータのプッシュだけで構成されていない場合はfalseを返します
+func ExtractNullData(script []byte) ([][]byte, bool) {
+	if len(script) == 0 || OpCode(script[0]) != OpReturn {
+		return nil, false
+	}
+	data, err := parsePushes(script[1:])
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// IsUnspendable は出力スクリプトが使用できないものか判定します
+// 使用できない出力はUTXOとして扱われないのでダストの判定も不要です
+func IsUnspendable(script []byte) bool {
+	return (0 < len(script) && OpCode(script[0]) == OpReturn) || maxScriptSize < len(script)
+}
+
+// PushedData はスクリプトのプッシュ命令でスタックに積むデータを全て取り出します
+// プッシュ以外の命令は読み飛ばし、不正な命令があればその手前までを返します
+// Op1からOp16などの数値の命令はデータとして扱いません
+func PushedData(script []byte) [][]byte {
+	data := [][]byte{}
+	for 0 < len(script) {
+		op := OpCode(script[0])
+		script = script[1:]
+
+		var n int
+		switch {
+		case OpPushData4 < op:
+			continue
+		case op < OpPushData1:
+			n = int(op)
+		case op == OpPushData1 && 1 <= len(script):
+			n = int(script[0])
+			script = script[1:]
+		case op == OpPushData2 && 2 <= len(script):
+			n = int(binary.LittleEndian.Uint16(script))
+			script = script[2:]
+		case op == OpPushData4 && 4 <= len(script):
+			n = int(binary.LittleEndian.Uint32(script))
+			script = script[4:]
+		default:
+			return data
+		}
+
+		if n < 0 || len(script) < n {
+			return data
+		}
+		data = append(data, script[:n])
+		script = script[n:]
+	}
+	return data
+}
+
+// parsePushes はプッシュ命令だけで構成されたスクリプトからデータを取り出す
+func parsePushes(script []byte) ([][]byte, error) {
+	data := [][]byte{}
+	for 0 < len(script) {
+		op := OpCode(script[0])
+		script = script[1:]
+
+		var n int
+		switch {
+		case op == Op0:
+			data = append(data, []byte{})
+			continue
+		case op == Op1Negate:
+			data = append(data, []byte{0x81})
+			continue
+		case Op1 <= op && op <= Op16:
+			data = append(data, []byte{byte(op-Op1) + 1})
+			continue
+		case op < OpPushData1:
+			n = int(op)
+		case op == OpPushData1 && 1 <= len(script):
+			n = int(script[0])
+			script = script[1:]
+		case op == OpPushData2 && 2 <= len(script):
+			n = int(binary.LittleEndian.Uint16(script))
+			script = script[2:]
+		case op == OpPushData4 && 4 <= len(script):
+			n = int(binary.LittleEndian.Uint32(script))
+			script = script[4:]
+		default:
+			return nil, errors.Errorf("プッシュ命令ではありません: %#x", op)
+		}
+
+		if n < 0 || len(script) < n {
+			return nil, errors.Errorf("プッシュするデータが足りません: length=%d", n)
+		}
+		data = append(data, script[:n])
+		script = script[n:]
+	}
+	return data, nil
+}
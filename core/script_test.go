@@ -0,0 +1,70 @@
+package core
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestScriptBuilderAddInt64(t *testing.T) {
+	tests := []struct {
+		val      int64
+		expected string
+	}{
+		{0, "00"},
+		{-1, "4f"},
+		{1, "51"},
+		{16, "60"},
+		{17, "0111"},
+		{127, "017f"},
+		{128, "028000"},
+		{-128, "028080"},
+		{255, "02ff00"},
+		{500000, "0320a107"},
+		{1700000000, "0400f15365"},
+	}
+	for _, test := range tests {
+		script := NewScriptBuilder().AddInt64(test.val).Script()
+		if hex.EncodeToString(script) != test.expected {
+			t.Errorf("スクリプトが一致しません: %d, %x != %s", test.val, script, test.expected)
+		}
+	}
+}
+
+func TestScriptBuilderAddData(t *testing.T) {
+	tests := []struct {
+		len    int
+		prefix []byte
+	}{
+		{20, []byte{20}},
+		{75, []byte{75}},
+		{76, []byte{byte(OpPushData1), 76}},
+		{256, []byte{byte(OpPushData2), 0x00, 0x01}},
+		{0x10000, []byte{byte(OpPushData4), 0x00, 0x00, 0x01, 0x00}},
+	}
+	for _, test := range tests {
+		data := bytes.Repeat([]byte{0xab}, test.len)
+		script := NewScriptBuilder().AddData(data).Script()
+		if !bytes.HasPrefix(script, test.prefix) {
+			t.Errorf("プッシュ命令が一致しません: len=%d, %x", test.len, script[:len(test.prefix)])
+		}
+		if len(script) != len(test.prefix)+test.len {
+			t.Errorf("スクリプトの長さが一致しません: len=%d, %d", test.len, len(script))
+		}
+	}
+}
+
+func TestPushedData(t *testing.T) {
+	pubKeyHash := bytes.Repeat([]byte{0x12}, 20)
+	script := NewScriptBuilder().AddOp(OpDup).AddOp(OpHash160).AddData(pubKeyHash).AddOp(OpEqualVerify).AddOp(OpCheckSig).Script()
+	data := PushedData(script)
+	if len(data) != 1 || !bytes.Equal(data[0], pubKeyHash) {
+		t.Errorf("プッシュしたデータが一致しません: %x", data)
+	}
+
+	// 途中で壊れているスクリプトはその手前まで
+	broken := append(NewScriptBuilder().AddData([]byte{0x01, 0x02}).Script(), 0x05, 0x01)
+	if data := PushedData(broken); len(data) != 1 {
+		t.Errorf("壊れたスクリプトのデータの数が一致しません: %d", len(data))
+	}
+}
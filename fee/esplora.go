@@ -0,0 +1,78 @@
+package fee
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EsploraEstimator はEsplora形式のHTTP APIから手数料率を取得するEstimator
+// https://github.com/Blockstream/esplora/blob/master/API.md#get-fee-estimates
+type EsploraEstimator struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewEsploraEstimator はEsploraのAPIのベースURLを指定してEsploraEstimatorを生成します
+// clientがnilの場合はhttp.DefaultClientを使います
+func NewEsploraEstimator(baseURL string, client *http.Client) *EsploraEstimator {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &EsploraEstimator{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+	}
+}
+
+// EstimateFee はEsploraの推定値からtarget個のブロック以内に承認されるための手数料率を返します
+// Esploraは一部の目標ブロック数しか返さないので、target以下で最も大きい目標ブロック数の値を使います
+func (e *EsploraEstimator) EstimateFee(target int) (Rate, error) {
+	if err := checkTarget(target); err != nil {
+		return 0, err
+	}
+
+	estimates, err := e.fetchEstimates()
+	if err != nil {
+		return 0, err
+	}
+
+	bestTarget := 0
+	var best float64
+	for key, satPerVByte := range estimates {
+		t, err := strconv.Atoi(key)
+		if err != nil {
+			return 0, errors.Wrapf(err, "目標ブロック数が不正です: %q", key)
+		}
+		if t <= target && bestTarget < t {
+			bestTarget = t
+			best = satPerVByte
+		}
+	}
+	if bestTarget == 0 {
+		return 0, ErrInsufficientData
+	}
+	return NewRateFromSatPerVByte(best), nil
+}
+
+// fetchEstimates は目標ブロック数ごとの手数料率(sat/vB)を取得する
+func (e *EsploraEstimator) fetchEstimates() (map[string]float64, error) {
+	res, err := e.client.Get(e.baseURL + "/fee-estimates")
+	if err != nil {
+		return nil, errors.Wrap(err, "手数料の推定値の取得に失敗しました")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("手数料の推定値の取得に失敗しました: status=%d", res.StatusCode)
+	}
+
+	estimates := map[string]float64{}
+	if err := json.NewDecoder(res.Body).Decode(&estimates); err != nil {
+		return nil, errors.Wrap(err, "手数料の推定値のデコードに失敗しました")
+	}
+	return estimates, nil
+}
@@ -0,0 +1,60 @@
+package fee
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEsploraEstimator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/fee-estimates" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"1": 87.882, "2": 87.882, "3": 87.882, "6": 68.285, "144": 1.027, "1008": 1.0}`))
+	}))
+	defer server.Close()
+
+	e := NewEsploraEstimator(server.URL+"/api/", server.Client())
+	tests := []struct {
+		target   int
+		expected Rate
+	}{
+		{1, 87882},
+		{5, 87882},
+		{6, 68285},
+		{143, 68285},
+		{144, 1027},
+		{1008, 1000},
+	}
+	for _, test := range tests {
+		rate, err := e.EstimateFee(test.target)
+		if err != nil {
+			t.Errorf("推定に失敗しました: target=%d, %v", test.target, err)
+			continue
+		}
+		if rate != test.expected {
+			t.Errorf("推定値が一致しません: target=%d, %d != %d", test.target, rate, test.expected)
+		}
+	}
+}
+
+func TestFallbackEstimator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	e := NewFallbackEstimator(
+		NewEsploraEstimator(server.URL, server.Client()),
+		NewStaticEstimator(NewRateFromSatPerVByte(10)),
+	)
+	rate, err := e.EstimateFee(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate != 10000 {
+		t.Errorf("フォールバックの手数料率が使われていません: %d", rate)
+	}
+}
@@ -0,0 +1,91 @@
+package fee
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Rate は手数料率を表す型
+// 単位はBitcoin Coreと同じsatoshi/kvB(仮想キロバイト)です
+type Rate int64
+
+// NewRateFromSatPerVByte はsatoshi/vBの値から手数料率を生成します
+func NewRateFromSatPerVByte(satPerVByte float64) Rate {
+	return Rate(satPerVByte * 1000)
+}
+
+// SatPerVByte は手数料率をsatoshi/vBで返します
+func (r Rate) SatPerVByte() float64 {
+	return float64(r) / 1000
+}
+
+// FeeForVSize は仮想サイズvsizeのトランザクションに必要な手数料をsatoshiで返します
+// 手数料率を下回らないようにBitcoin CoreのCFeeRate::GetFeeと同じく切り上げます
+func (r Rate) FeeForVSize(vsize int) int64 {
+	fee := int64(r) * int64(vsize)
+	if 0 < fee {
+		return (fee + 999) / 1000
+	}
+	return fee / 1000
+}
+
+// MaxTarget は推定できる承認までのブロック数の最大値
+// Bitcoin Coreのestimatesmartfeeと同じ約1週間分
+const MaxTarget = 1008
+
+// ErrInsufficientData は推定に必要なデータが揃っていない場合のエラー
+var ErrInsufficientData = errors.New("手数料の推定に必要なデータが足りません")
+
+// Estimator は手数料率を推定するためのインターフェース
+type Estimator interface {
+	// target個のブロック以内に承認されるための手数料率を推定する
+	EstimateFee(target int) (Rate, error)
+}
+
+// StaticEstimator は常に固定の手数料率を返すEstimator
+// 他の推定方法が使えない場合のフォールバックとして使います
+type StaticEstimator struct {
+	Rate Rate
+}
+
+// NewStaticEstimator は固定の手数料率を返すEstimatorを生成します
+func NewStaticEstimator(rate Rate) *StaticEstimator {
+	return &StaticEstimator{Rate: rate}
+}
+
+// EstimateFee は固定の手数料率を返します
+func (e *StaticEstimator) EstimateFee(target int) (Rate, error) {
+	if err := checkTarget(target); err != nil {
+		return 0, err
+	}
+	return e.Rate, nil
+}
+
+// FallbackEstimator は複数のEstimatorを順番に試し、最初に成功した推定値を返すEstimator
+type FallbackEstimator struct {
+	estimators []Estimator
+}
+
+// NewFallbackEstimator は指定した順番でEstimatorを試すFallbackEstimatorを生成します
+func NewFallbackEstimator(estimators ...Estimator) *FallbackEstimator {
+	return &FallbackEstimator{estimators: estimators}
+}
+
+// EstimateFee は最初に推定に成功したEstimatorの手数料率を返します
+func (e *FallbackEstimator) EstimateFee(target int) (Rate, error) {
+	err := ErrInsufficientData
+	for _, estimator := range e.estimators {
+		var rate Rate
+		if rate, err = estimator.EstimateFee(target); err == nil {
+			return rate, nil
+		}
+	}
+	return 0, errors.Wrap(err, "全ての手数料の推定に失敗しました")
+}
+
+// checkTarget は承認までのブロック数が推定できる範囲か確認する
+func checkTarget(target int) error {
+	if target < 1 || MaxTarget < target {
+		return errors.Errorf("承認までのブロック数が範囲外です: %d", target)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package fee
+
+import "testing"
+
+func TestRateFeeForVSize(t *testing.T) {
+	tests := []struct {
+		rate  Rate
+		vsize int
+		want  int64
+	}{
+		{1000, 141, 141},
+		// 手数料率を下回らないように切り上げる
+		{1001, 141, 142},
+		{NewRateFromSatPerVByte(2.5), 141, 353},
+		{1, 1, 1},
+		{0, 141, 0},
+	}
+	for _, test := range tests {
+		if fee := test.rate.FeeForVSize(test.vsize); fee != test.want {
+			t.Errorf("手数料が一致しません: rate=%d, vsize=%d, %d != %d", test.rate, test.vsize, fee, test.want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package fee
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/keiji0/btcwallet/protocol"
+)
+
+// 推定方法はBitcoin CoreのCBlockPolicyEstimatorを簡略化したものです
+// https://github.com/bitcoin/bitcoin/blob/master/src/policy/fees.cpp
+// メモリプールで観測したトランザクションが何ブロックで承認されたかを手数料率のバケットごとに記録し、
+// 目標ブロック数以内に十分な割合で承認されている最も低い手数料率を推定値とします
+
+const (
+	// minBucketRate はバケットの最小の手数料率(1sat/vB)
+	minBucketRate = 1000
+	// maxBucketRate はバケットの最大の手数料率(10000sat/vB)
+	maxBucketRate = 10000000
+	// bucketSpacing はバケットの手数料率の間隔の倍率
+	bucketSpacing = 1.05
+	// successThreshold は目標ブロック数以内に承認された割合がこの値以上なら推定値として採用する
+	successThreshold = 0.85
+	// minBucketSamples は推定に使うバケットの範囲に最低限必要なトランザクション数
+	minBucketSamples = 10
+	// decay はブロックごとに過去の記録を減衰させる割合
+	decay = 0.998
+)
+
+// feeBucket は手数料率の範囲ごとの承認までのブロック数の記録
+type feeBucket struct {
+	// この手数料率以上のトランザクションを記録する
+	lower Rate
+	// confirmed[i]はi+1ブロックで承認されたトランザクションの数
+	confirmed []float64
+	// MaxTargetブロック以内に承認されなかったトランザクションの数
+	failed float64
+}
+
+// pendingTx はメモリプールで観測した未承認のトランザクション
+type pendingTx struct {
+	bucket int
+	height int32
+}
+
+// MempoolEstimator はp2pで受信したメモリプールのトランザクションとブロックから手数料率を推定するEstimator
+type MempoolEstimator struct {
+	mtx     sync.Mutex
+	height  int32
+	buckets []*feeBucket
+	pending map[protocol.Hash]pendingTx
+}
+
+// NewMempoolEstimator は現在のブロックの高さを起点にMempoolEstimatorを生成します
+func NewMempoolEstimator(height int32) *MempoolEstimator {
+	e := &MempoolEstimator{
+		height:  height,
+		pending: map[protocol.Hash]pendingTx{},
+	}
+	for rate := float64(minBucketRate); rate <= maxBucketRate; rate *= bucketSpacing {
+		e.buckets = append(e.buckets, &feeBucket{
+			lower:     Rate(rate),
+			confirmed: make([]float64, MaxTarget),
+		})
+	}
+	return e
+}
+
+// ObserveTx はメモリプールに入ったトランザクションとその手数料率を記録します
+func (e *MempoolEstimator) ObserveTx(txid protocol.Hash, rate Rate) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if _, ok := e.pending[txid]; ok {
+		return
+	}
+	e.pending[txid] = pendingTx{
+		bucket: e.bucketIndex(rate),
+		height: e.height,
+	}
+}
+
+// RemoveTx は承認以外の理由でメモリプールから消えたトランザクションの記録を取り除きます
+func (e *MempoolEstimator) RemoveTx(txid protocol.Hash) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	delete(e.pending, txid)
+}
+
+// ObserveBlock は新しいブロックに含まれていたトランザクションの承認を記録します
+// 現在の高さ以下のブロックは既に処理済みとして無視します
+// 高さが飛んだ場合は間のブロックの分も過去の記録を減衰させます
+func (e *MempoolEstimator) ObserveBlock(height int32, txids []protocol.Hash) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if height <= e.height {
+		return
+	}
+	factor := math.Pow(decay, float64(height-e.height))
+	e.height = height
+
+	for _, b := range e.buckets {
+		for i := range b.confirmed {
+			b.confirmed[i] *= factor
+		}
+		b.failed *= factor
+	}
+
+	for _, txid := range txids {
+		tx, ok := e.pending[txid]
+		if !ok {
+			continue
+		}
+		blocks := int(height - tx.height)
+		if blocks < 1 {
+			blocks = 1
+		}
+		if blocks <= MaxTarget {
+			e.buckets[tx.bucket].confirmed[blocks-1]++
+		} else {
+			e.buckets[tx.bucket].failed++
+		}
+		delete(e.pending, txid)
+	}
+
+	// 承認されないまま推定できる範囲を超えたものは失敗として記録する
+	for txid, tx := range e.pending {
+		if MaxTarget < int(height-tx.height) {
+			e.buckets[tx.bucket].failed++
+			delete(e.pending, txid)
+		}
+	}
+}
+
+// EstimateFee はtarget個のブロック以内に承認されるための手数料率を推定します
+func (e *MempoolEstimator) EstimateFee(target int) (Rate, error) {
+	if err := checkTarget(target); err != nil {
+		return 0, err
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	// targetブロック以上待っている未承認のトランザクションはバケットごとに失敗として数える
+	waiting := make([]float64, len(e.buckets))
+	for _, tx := range e.pending {
+		if target <= int(e.height-tx.height) {
+			waiting[tx.bucket]++
+		}
+	}
+
+	// 手数料率の高いバケットから順に、十分なサンプル数が集まるまでバケットをまとめて承認率を調べる
+	found := false
+	var best Rate
+	var success, total float64
+	for i := len(e.buckets) - 1; 0 <= i; i-- {
+		b := e.buckets[i]
+		for blocks, n := range b.confirmed {
+			if blocks < target {
+				success += n
+			}
+			total += n
+		}
+		total += b.failed + waiting[i]
+
+		if total < minBucketSamples {
+			continue
+		}
+		if success/total < successThreshold {
+			break
+		}
+		found = true
+		best = b.lower
+		success, total = 0, 0
+	}
+
+	if !found {
+		return 0, ErrInsufficientData
+	}
+	return best, nil
+}
+
+// bucketIndex は手数料率が属するバケットのインデックスを返す
+func (e *MempoolEstimator) bucketIndex(rate Rate) int {
+	i := sort.Search(len(e.buckets), func(i int) bool {
+		return rate < e.buckets[i].lower
+	})
+	if i == 0 {
+		return 0
+	}
+	return i - 1
+}
@@ -0,0 +1,89 @@
+package fee
+
+import (
+	"math"
+	"testing"
+
+	"github.com/keiji0/btcwallet/protocol"
+)
+
+func TestMempoolEstimator(t *testing.T) {
+	e := NewMempoolEstimator(100)
+
+	if _, err := e.EstimateFee(1); err != ErrInsufficientData {
+		t.Errorf("データがないのに推定できました: %v", err)
+	}
+
+	// 手数料率ごとに20個ずつトランザクションを観測する
+	txids := func(tag byte) []protocol.Hash {
+		hashes := []protocol.Hash{}
+		for i := 0; i < 20; i++ {
+			hashes = append(hashes, protocol.Hash{tag, byte(i)})
+		}
+		return hashes
+	}
+	high, middle, low := txids(1), txids(2), txids(3)
+	for i := range high {
+		e.ObserveTx(high[i], NewRateFromSatPerVByte(50))
+		e.ObserveTx(middle[i], NewRateFromSatPerVByte(5))
+		e.ObserveTx(low[i], NewRateFromSatPerVByte(2))
+	}
+
+	// 高い手数料は次のブロックで、中間の手数料は5ブロック後に承認され、低い手数料は承認されない
+	e.ObserveBlock(101, high)
+	for height := int32(102); height < 105; height++ {
+		e.ObserveBlock(height, nil)
+	}
+	e.ObserveBlock(105, middle)
+	for height := int32(106); height <= 110; height++ {
+		e.ObserveBlock(height, nil)
+	}
+
+	tests := []struct {
+		target int
+		min    float64
+		max    float64
+	}{
+		{1, 45, 50},
+		{5, 4.5, 5},
+		{10, 4.5, 5},
+	}
+	for _, test := range tests {
+		rate, err := e.EstimateFee(test.target)
+		if err != nil {
+			t.Errorf("推定に失敗しました: target=%d, %v", test.target, err)
+			continue
+		}
+		if rate.SatPerVByte() < test.min || test.max < rate.SatPerVByte() {
+			t.Errorf("推定値が範囲外です: target=%d, %v", test.target, rate.SatPerVByte())
+		}
+	}
+
+	if _, err := e.EstimateFee(0); err == nil {
+		t.Errorf("範囲外の目標ブロック数が推定できました")
+	}
+}
+
+func TestMempoolEstimatorDecay(t *testing.T) {
+	e := NewMempoolEstimator(100)
+	txid := protocol.Hash{1}
+	e.ObserveTx(txid, NewRateFromSatPerVByte(10))
+	e.ObserveBlock(101, []protocol.Hash{txid})
+	b := e.buckets[e.bucketIndex(NewRateFromSatPerVByte(10))]
+	if b.confirmed[0] != 1 {
+		t.Fatalf("承認が記録されていません: %v", b.confirmed[0])
+	}
+
+	// 処理済みの高さは減衰させない
+	e.ObserveBlock(101, nil)
+	e.ObserveBlock(90, nil)
+	if b.confirmed[0] != 1 {
+		t.Errorf("処理済みの高さで減衰しました: %v", b.confirmed[0])
+	}
+
+	// 高さが飛んだ場合は飛んだブロックの数だけ減衰させる
+	e.ObserveBlock(111, nil)
+	if want := math.Pow(decay, 10); math.Abs(b.confirmed[0]-want) > 1e-12 {
+		t.Errorf("減衰が一致しません: %v != %v", b.confirmed[0], want)
+	}
+}
@@ -0,0 +1,572 @@
+package p2p
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	mrand "math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/keiji0/btcwallet/util/hash"
+	"github.com/pkg/errors"
+)
+
+// 接続先の候補のアドレスの管理
+// まだ接続したことのないアドレスをnewテーブル、接続できたアドレスをtriedテーブルに分けて保持します
+// どちらのテーブルもバケットに分けて、秘密の鍵で位置を決めるので外部から埋め尽くされにくくなります
+// https://github.com/bitcoin/bitcoin/blob/master/src/addrman.h
+
+const (
+	// newBucketCount はnewテーブルのバケットの数
+	newBucketCount = 1024
+	// triedBucketCount はtriedテーブルのバケットの数
+	triedBucketCount = 256
+	// bucketSize は1つのバケットに入るアドレスの数
+	bucketSize = 64
+	// newBucketsPerSourceGroup は同じ送信元のグループのアドレスが入るnewテーブルのバケットの数
+	newBucketsPerSourceGroup = 64
+	// triedBucketsPerGroup は同じグループのアドレスが入るtriedテーブルのバケットの数
+	triedBucketsPerGroup = 8
+
+	// addrHorizon はこれより古いアドレスを使わない期間
+	addrHorizon = 30 * 24 * time.Hour
+	// addrRetries は一度も接続できていないアドレスを諦めるまでの試行回数
+	addrRetries = 3
+	// addrMaxFailures は接続できていた期間が古いアドレスを諦めるまでの失敗回数
+	addrMaxFailures = 10
+	// addrMinFailPeriod はaddrMaxFailuresを数え始める最後に接続できてからの期間
+	addrMinFailPeriod = 7 * 24 * time.Hour
+	// addrUpdateInterval は接続しているアドレスの時刻を更新する間隔
+	addrUpdateInterval = 20 * time.Minute
+	// getAddrMaxPercent はgetaddrに返すアドレスの全体に対する割合の上限
+	getAddrMaxPercent = 23
+
+	// AddrTimePenalty はaddrメッセージで受け取ったアドレスの時刻から差し引く時間
+	AddrTimePenalty = 2 * time.Hour
+
+	// peersFileVersion はピアのファイルの形式のバージョン
+	peersFileVersion = 1
+)
+
+// peersFileMagic はピアのファイルの先頭のバイト列
+var peersFileMagic = [4]byte{'p', 'e', 'e', 'r'}
+
+// knownAddress はAddrManagerが保持するアドレスと接続の履歴
+type knownAddress struct {
+	addr *protocol.NetAddressV2
+	// このアドレスを教えてくれたピアのアドレス
+	source *protocol.NetAddressV2
+	// 最後に接続できた時刻と試した時刻
+	lastSuccess time.Time
+	lastTry     time.Time
+	// 最後に接続できてから試した回数
+	attempts int
+	tried    bool
+	// テーブルの中でのバケットと位置
+	bucket int
+	pos    int
+	// 選ぶためのテーブルごとの一覧の中の位置
+	index int
+}
+
+// isTerrible は接続先の候補から外すべきアドレスかどうかを返す
+func (ka *knownAddress) isTerrible(now time.Time) bool {
+	// 試したばかりのアドレスは外さない
+	if now.Sub(ka.lastTry) < time.Minute {
+		return false
+	}
+	ts := time.Time(ka.addr.Timestamp)
+	if ts.After(now.Add(10 * time.Minute)) {
+		return true
+	}
+	if ts.Unix() <= 0 || addrHorizon < now.Sub(ts) {
+		return true
+	}
+	if ka.lastSuccess.IsZero() && addrRetries <= ka.attempts {
+		return true
+	}
+	if addrMinFailPeriod < now.Sub(ka.lastSuccess) && addrMaxFailures <= ka.attempts {
+		return true
+	}
+	return false
+}
+
+// chance は選ばれやすさを返す、最近試したアドレスや失敗の多いアドレスほど小さくなる
+func (ka *knownAddress) chance(now time.Time) float64 {
+	c := 1.0
+	if now.Sub(ka.lastTry) < 10*time.Minute {
+		c *= 0.01
+	}
+	attempts := ka.attempts
+	if 8 < attempts {
+		attempts = 8
+	}
+	return c * math.Pow(0.66, float64(attempts))
+}
+
+// AddrManager は接続先の候補のアドレスを管理する型
+type AddrManager struct {
+	mtx sync.Mutex
+	// 保存するファイルのパス、空の場合は保存しない
+	path string
+	// バケットの位置を決める秘密の鍵
+	key   [32]byte
+	addrs map[string]*knownAddress
+	// テーブルの各位置のアドレス
+	newTable   [newBucketCount][bucketSize]*knownAddress
+	triedTable [triedBucketCount][bucketSize]*knownAddress
+	// テーブルごとのアドレスの一覧、バケットはほとんど空なのでここからランダムに選びます
+	newList   []*knownAddress
+	triedList []*knownAddress
+	rand      *mrand.Rand
+
+	// ローカルのアドレスも受け入れる、テストで使います
+	allowLocal bool
+}
+
+// NewAddrManager はアドレスの管理を生成します
+// pathにファイルがあれば読み込みます、pathが空の場合は保存しません
+func NewAddrManager(path string) (*AddrManager, error) {
+	var seed [8]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		return nil, errors.Wrap(err, "乱数の生成に失敗しました")
+	}
+	am := &AddrManager{
+		path:  path,
+		addrs: map[string]*knownAddress{},
+		rand:  mrand.New(mrand.NewSource(int64(binary.LittleEndian.Uint64(seed[:])))),
+	}
+	if _, err := rand.Read(am.key[:]); err != nil {
+		return nil, errors.Wrap(err, "鍵の生成に失敗しました")
+	}
+	if path == "" {
+		return am, nil
+	}
+	if err := am.load(); err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return nil, err
+	}
+	return am, nil
+}
+
+// Size は保持しているアドレスの数を返します
+func (am *AddrManager) Size() int {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	return len(am.newList) + len(am.triedList)
+}
+
+// TriedSize は接続できたことのあるアドレスの数を返します
+func (am *AddrManager) TriedSize() int {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	return len(am.triedList)
+}
+
+// Add はsourceから教えてもらったアドレスをnewテーブルに追加し、追加した数を返します
+// 既にあるアドレスは時刻とサービスだけを更新します、時刻からはpenaltyを差し引きます
+func (am *AddrManager) Add(addrs []*protocol.NetAddressV2, source *protocol.NetAddressV2, penalty time.Duration) int {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	n := 0
+	for _, addr := range addrs {
+		if am.add(addr, source, penalty, time.Now()) {
+			n++
+		}
+	}
+	return n
+}
+
+func (am *AddrManager) add(addr, source *protocol.NetAddressV2, penalty time.Duration, now time.Time) bool {
+	if addr.Validate() != nil || !am.routable(addr) {
+		return false
+	}
+	// 自分自身のアドレスを教えてもらった場合は差し引かない
+	if addr.String() == source.String() {
+		penalty = 0
+	}
+	ts := time.Time(addr.Timestamp).Add(-penalty)
+	if ts.After(now.Add(10 * time.Minute)) {
+		ts = now.Add(-5 * 24 * time.Hour)
+	}
+
+	if ka, ok := am.addrs[addr.String()]; ok {
+		ka.addr.Services |= addr.Services
+		if time.Time(ka.addr.Timestamp).Before(ts) {
+			ka.addr.Timestamp = protocol.Uint32Time(ts)
+		}
+		return false
+	}
+
+	a := *addr
+	a.Addr = append([]byte{}, addr.Addr...)
+	a.Timestamp = protocol.Uint32Time(ts)
+	s := *source
+	s.Addr = append([]byte{}, source.Addr...)
+	ka := &knownAddress{addr: &a, source: &s}
+	return am.placeNew(ka, now, false)
+}
+
+// placeNew はアドレスをnewテーブルに置く
+// 位置が埋まっている場合はforceか置かれているアドレスが使えない場合だけ置き換えます
+func (am *AddrManager) placeNew(ka *knownAddress, now time.Time, force bool) bool {
+	bucket := am.newBucket(ka.addr, ka.source)
+	pos := am.bucketPosition(false, bucket, ka.addr)
+	if old := am.newTable[bucket][pos]; old != nil {
+		if !force && !old.isTerrible(now) {
+			return false
+		}
+		am.newTable[bucket][pos] = nil
+		am.newList = removeFromList(am.newList, old)
+		delete(am.addrs, old.addr.String())
+	}
+	ka.tried = false
+	ka.bucket, ka.pos = bucket, pos
+	am.newTable[bucket][pos] = ka
+	am.newList = appendToList(am.newList, ka)
+	am.addrs[ka.addr.String()] = ka
+	return true
+}
+
+// placeTried はアドレスをtriedテーブルの空いている位置に置く
+func (am *AddrManager) placeTried(ka *knownAddress, bucket, pos int) {
+	ka.tried = true
+	ka.bucket, ka.pos = bucket, pos
+	am.triedTable[bucket][pos] = ka
+	am.triedList = appendToList(am.triedList, ka)
+	am.addrs[ka.addr.String()] = ka
+}
+
+// appendToList はテーブルごとの一覧にアドレスを追加する
+func appendToList(list []*knownAddress, ka *knownAddress) []*knownAddress {
+	ka.index = len(list)
+	return append(list, ka)
+}
+
+// removeFromList はテーブルごとの一覧からアドレスを取り除く、最後の要素を空いた位置に移します
+func removeFromList(list []*knownAddress, ka *knownAddress) []*knownAddress {
+	last := list[len(list)-1]
+	list[ka.index] = last
+	last.index = ka.index
+	list[len(list)-1] = nil
+	return list[:len(list)-1]
+}
+
+// Good は接続してハンドシェイクができたアドレスをtriedテーブルに移します
+// 位置が埋まっている場合は置かれているアドレスをnewテーブルに戻します
+func (am *AddrManager) Good(addr *protocol.NetAddressV2) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	ka, ok := am.addrs[addr.String()]
+	if !ok {
+		return
+	}
+	now := time.Now()
+	ka.lastSuccess = now
+	ka.lastTry = now
+	ka.attempts = 0
+	if ka.tried {
+		return
+	}
+
+	am.newTable[ka.bucket][ka.pos] = nil
+	am.newList = removeFromList(am.newList, ka)
+
+	bucket := am.triedBucket(ka.addr)
+	pos := am.bucketPosition(true, bucket, ka.addr)
+	if old := am.triedTable[bucket][pos]; old != nil {
+		am.triedTable[bucket][pos] = nil
+		am.triedList = removeFromList(am.triedList, old)
+		am.placeNew(old, now, true)
+	}
+	am.placeTried(ka, bucket, pos)
+}
+
+// Attempt はアドレスに接続を試したことを記録します
+func (am *AddrManager) Attempt(addr *protocol.NetAddressV2) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	if ka, ok := am.addrs[addr.String()]; ok {
+		ka.lastTry = time.Now()
+		ka.attempts++
+	}
+}
+
+// Connected は接続しているアドレスの時刻を更新します
+// 他のピアに教える時刻が新しくなりすぎないように一定の間隔でしか更新しません
+func (am *AddrManager) Connected(addr *protocol.NetAddressV2) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	if ka, ok := am.addrs[addr.String()]; ok {
+		now := time.Now()
+		if addrUpdateInterval < now.Sub(time.Time(ka.addr.Timestamp)) {
+			ka.addr.Timestamp = protocol.Uint32Time(now)
+		}
+	}
+}
+
+// Select は接続先の候補を1つ選びます、候補が無い場合はnilを返します
+// newOnlyがfalseの場合はtriedテーブルとnewテーブルから半々の確率で選び
+// 最近試したアドレスや失敗の多いアドレスは選ばれにくくなります
+func (am *AddrManager) Select(newOnly bool) *protocol.NetAddressV2 {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	list := am.newList
+	if !newOnly && len(am.triedList) != 0 && (len(am.newList) == 0 || am.rand.Intn(2) == 0) {
+		list = am.triedList
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	now := time.Now()
+	factor := 1.0
+	for {
+		ka := list[am.rand.Intn(len(list))]
+		if am.rand.Float64() < factor*ka.chance(now) {
+			a := *ka.addr
+			return &a
+		}
+		factor *= 1.2
+	}
+}
+
+// Addresses はgetaddrに返すアドレスを最大max個ランダムに選びます
+// 使えないアドレスは除き、全体のgetAddrMaxPercentまでにします
+func (am *AddrManager) Addresses(max int) []*protocol.NetAddressV2 {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	if n := len(am.addrs) * getAddrMaxPercent / 100; n < max {
+		max = n
+	}
+	now := time.Now()
+	addrs := make([]*protocol.NetAddressV2, 0, len(am.addrs))
+	for _, ka := range am.addrs {
+		if !ka.isTerrible(now) {
+			a := *ka.addr
+			addrs = append(addrs, &a)
+		}
+	}
+	am.rand.Shuffle(len(addrs), func(i, j int) { addrs[i], addrs[j] = addrs[j], addrs[i] })
+	if max < len(addrs) {
+		addrs = addrs[:max]
+	}
+	return addrs
+}
+
+// handleMessage はピアから受け取ったaddrとaddrv2のアドレスを追加し、getaddrにアドレスを返す
+func (am *AddrManager) handleMessage(p *Peer, msg protocol.Message) {
+	source := netAddressV2(p.Addr())
+	switch m := msg.(type) {
+	case *protocol.MsgAddr:
+		addrs := make([]*protocol.NetAddressV2, 0, len(m.AddrList))
+		for _, a := range m.AddrList {
+			addr := protocol.NewNetAddressV2FromIP(a.IP, a.Port, a.Services)
+			addr.Timestamp = a.Timestamp
+			addrs = append(addrs, addr)
+		}
+		am.Add(addrs, source, AddrTimePenalty)
+	case *protocol.MsgAddrV2:
+		am.Add(m.AddrList, source, AddrTimePenalty)
+	case *protocol.MsgGetAddr:
+		// 外向きのピアに返すと自分の接続先を知られるので、内向きのピアにだけ返す
+		if p.Inbound() {
+			p.QueueMessage(am.addrMessage(p), PriorityLow, nil)
+		}
+	}
+}
+
+// addrMessage はピアが対応している形式でアドレスを返すメッセージを生成する
+func (am *AddrManager) addrMessage(p *Peer) protocol.Message {
+	addrs := am.Addresses(protocol.MaxAddrPerMsg)
+	if p.WantsAddrV2() {
+		msg := protocol.NewMsgAddrV2()
+		for _, addr := range addrs {
+			msg.AddAddress(addr)
+		}
+		return msg
+	}
+	msg := protocol.NewMsgAddr()
+	for _, addr := range addrs {
+		ip := addr.IP()
+		if ip == nil || addr.NetworkID == protocol.NetworkCJDNS {
+			continue
+		}
+		msg.AddAddress(&protocol.TimestampedNetAddress{
+			Timestamp:  addr.Timestamp,
+			NetAddress: protocol.NetAddress{Services: addr.Services, IP: ip, Port: addr.Port},
+		})
+	}
+	return msg
+}
+
+// routable は公開されたネットワークで接続できるアドレスかどうかを返す
+func (am *AddrManager) routable(addr *protocol.NetAddressV2) bool {
+	ip := addr.IP()
+	if ip == nil || addr.NetworkID == protocol.NetworkCJDNS {
+		return true
+	}
+	if ip.IsMulticast() || ip.IsUnspecified() || isInternal(ip) {
+		return false
+	}
+	return am.allowLocal || NetGroup(ip) != "local"
+}
+
+// addrGroup はアドレスのグループを返す、IP以外のネットワークはアドレスの先頭4ビットでまとめる
+func addrGroup(addr *protocol.NetAddressV2) string {
+	if ip := addr.IP(); ip != nil && addr.NetworkID != protocol.NetworkCJDNS {
+		return NetGroup(ip)
+	}
+	if len(addr.Addr) == 0 {
+		return fmt.Sprintf("%d", addr.NetworkID)
+	}
+	return fmt.Sprintf("%d:%x", addr.NetworkID, addr.Addr[0]>>4)
+}
+
+// cheapHash は鍵とデータの連結のダブルSHA256の先頭8バイトを返す
+func (am *AddrManager) cheapHash(data ...[]byte) uint64 {
+	b := append([]byte{}, am.key[:]...)
+	for _, d := range data {
+		b = append(b, d...)
+	}
+	return binary.LittleEndian.Uint64(hash.Sha256x2(b)[:8])
+}
+
+func uint64Bytes(v uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return b[:]
+}
+
+// newBucket はnewテーブルのバケットを返す
+// 同じ送信元のグループから教えてもらったアドレスはnewBucketsPerSourceGroup個のバケットにしか入りません
+func (am *AddrManager) newBucket(addr, source *protocol.NetAddressV2) int {
+	srcGroup := []byte(addrGroup(source))
+	h := am.cheapHash([]byte(addrGroup(addr)), srcGroup) % newBucketsPerSourceGroup
+	return int(am.cheapHash(srcGroup, uint64Bytes(h)) % newBucketCount)
+}
+
+// triedBucket はtriedテーブルのバケットを返す
+// 同じグループのアドレスはtriedBucketsPerGroup個のバケットにしか入りません
+func (am *AddrManager) triedBucket(addr *protocol.NetAddressV2) int {
+	h := am.cheapHash([]byte(addr.String())) % triedBucketsPerGroup
+	return int(am.cheapHash([]byte(addrGroup(addr)), uint64Bytes(h)) % triedBucketCount)
+}
+
+// bucketPosition はバケットの中の位置を返す
+func (am *AddrManager) bucketPosition(tried bool, bucket int, addr *protocol.NetAddressV2) int {
+	table := []byte{'N'}
+	if tried {
+		table[0] = 'K'
+	}
+	return int(am.cheapHash(table, uint64Bytes(uint64(bucket)), []byte(addr.String())) % bucketSize)
+}
+
+// peersFile はピアのファイルの形式
+type peersFile struct {
+	Magic   [4]byte
+	Version uint8
+	Key     [32]byte
+	Addrs   []*peersFileEntry `btc:"max=81920"`
+}
+
+// peersFileEntry はピアのファイルに保存するアドレスと接続の履歴
+type peersFileEntry struct {
+	Addr   protocol.NetAddressV2
+	Source protocol.NetAddressV2
+	// Unix時間、0は無いことを表す
+	LastSuccess int64
+	LastTry     int64
+	Attempts    uint32
+	Tried       bool
+}
+
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func fromUnixTime(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(t, 0)
+}
+
+// Save はアドレスをファイルに保存します
+func (am *AddrManager) Save() error {
+	if am.path == "" {
+		return nil
+	}
+	am.mtx.Lock()
+	f := peersFile{Magic: peersFileMagic, Version: peersFileVersion, Key: am.key}
+	for _, ka := range am.addrs {
+		f.Addrs = append(f.Addrs, &peersFileEntry{
+			Addr:        *ka.addr,
+			Source:      *ka.source,
+			LastSuccess: unixTime(ka.lastSuccess),
+			LastTry:     unixTime(ka.lastTry),
+			Attempts:    uint32(ka.attempts),
+			Tried:       ka.tried,
+		})
+	}
+	am.mtx.Unlock()
+
+	var buf bytes.Buffer
+	if err := protocol.SerializeStruct(&buf, protocol.CurrentVersion, &f); err != nil {
+		return err
+	}
+	return writeChecksumFile(am.path, buf.Bytes())
+}
+
+// load はファイルからアドレスを読み込む
+// バケットの位置は読み込んだ鍵で計算し直します
+func (am *AddrManager) load() error {
+	body, err := readChecksumFile(am.path)
+	if err != nil {
+		return err
+	}
+	var f peersFile
+	if err := protocol.DeserializeStruct(bytes.NewReader(body), protocol.CurrentVersion, &f); err != nil {
+		return errors.Wrap(err, "ピアのファイルのデコードに失敗しました")
+	}
+	if f.Magic != peersFileMagic || f.Version != peersFileVersion {
+		return errors.Errorf("ピアのファイルの形式が不正です: version=%d", f.Version)
+	}
+
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.key = f.Key
+	now := time.Now()
+	for _, e := range f.Addrs {
+		addr, source := e.Addr, e.Source
+		if addr.Validate() != nil {
+			continue
+		}
+		ka := &knownAddress{
+			addr:        &addr,
+			source:      &source,
+			lastSuccess: fromUnixTime(e.LastSuccess),
+			lastTry:     fromUnixTime(e.LastTry),
+			attempts:    int(e.Attempts),
+		}
+		if _, ok := am.addrs[addr.String()]; ok {
+			continue
+		}
+		if e.Tried {
+			bucket := am.triedBucket(ka.addr)
+			pos := am.bucketPosition(true, bucket, ka.addr)
+			if am.triedTable[bucket][pos] == nil {
+				am.placeTried(ka, bucket, pos)
+				continue
+			}
+		}
+		am.placeNew(ka, now, false)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package p2p
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/protocol"
+)
+
+// testAddr は時刻を現在にしたIPv4のアドレスを返す
+func testAddr(a, b, c, d byte) *protocol.NetAddressV2 {
+	addr := protocol.NewNetAddressV2FromIP(net.IPv4(a, b, c, d), 8333, protocol.NodeNetwork)
+	addr.Timestamp = protocol.Uint32Time(time.Now())
+	return addr
+}
+
+func TestAddrManager(t *testing.T) {
+	am, err := NewAddrManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if am.Select(false) != nil {
+		t.Error("空なのに選ばれました")
+	}
+
+	source := testAddr(1, 1, 1, 1)
+	addrs := []*protocol.NetAddressV2{testAddr(2, 2, 2, 2), testAddr(3, 3, 3, 3), testAddr(127, 0, 0, 1)}
+	if n := am.Add(addrs, source, AddrTimePenalty); n != 2 {
+		t.Errorf("ローカルのアドレスは追加しません: %d", n)
+	}
+	if n := am.Add(addrs, source, AddrTimePenalty); n != 0 {
+		t.Errorf("同じアドレスを追加しました: %d", n)
+	}
+	if am.Size() != 2 || am.TriedSize() != 0 {
+		t.Errorf("数が一致しません: %d %d", am.Size(), am.TriedSize())
+	}
+
+	am.Attempt(addrs[0])
+	am.Good(addrs[0])
+	if am.Size() != 2 || am.TriedSize() != 1 {
+		t.Errorf("数が一致しません: %d %d", am.Size(), am.TriedSize())
+	}
+	// newテーブルに残っているのは1つだけ
+	for i := 0; i < 10; i++ {
+		if a := am.Select(true); a == nil || a.String() != addrs[1].String() {
+			t.Fatalf("newテーブルから選ばれていません: %v", a)
+		}
+	}
+	am.Good(addrs[1])
+	if a := am.Select(true); a != nil {
+		t.Errorf("newテーブルが空なのに選ばれました: %v", a)
+	}
+	if a := am.Select(false); a == nil {
+		t.Error("triedテーブルから選ばれません")
+	}
+}
+
+func TestAddrManagerBuckets(t *testing.T) {
+	am, err := NewAddrManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 同じ送信元のグループから教えてもらったアドレスは限られたバケットにしか入らない
+	source := testAddr(1, 1, 1, 1)
+	buckets := map[int]bool{}
+	for i := 0; i < 4096; i++ {
+		addr := testAddr(byte(i>>8)+10, byte(i), 1, 1)
+		buckets[am.newBucket(addr, source)] = true
+	}
+	if newBucketsPerSourceGroup < len(buckets) {
+		t.Errorf("バケットの数が多すぎます: %d", len(buckets))
+	}
+
+	// 同じグループのアドレスは限られたtriedテーブルのバケットにしか入らない
+	buckets = map[int]bool{}
+	for i := 0; i < 256; i++ {
+		buckets[am.triedBucket(testAddr(5, 5, byte(i), 1))] = true
+	}
+	if triedBucketsPerGroup < len(buckets) {
+		t.Errorf("バケットの数が多すぎます: %d", len(buckets))
+	}
+}
+
+func TestAddrManagerTerrible(t *testing.T) {
+	am, err := NewAddrManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh := testAddr(3, 3, 3, 3)
+	addrs := []*protocol.NetAddressV2{fresh}
+	for i := 0; i < 9; i++ {
+		old := testAddr(2, 2, 2, byte(i))
+		old.Timestamp = protocol.Uint32Time(time.Now().Add(-2 * addrHorizon))
+		addrs = append(addrs, old)
+	}
+	am.Add(addrs, testAddr(1, 1, 1, 1), 0)
+
+	// getaddrには古いアドレスを返さない
+	if got := am.Addresses(protocol.MaxAddrPerMsg); len(got) != 1 || got[0].String() != fresh.String() {
+		t.Errorf("返したアドレスが一致しません: %v", got)
+	}
+
+	now := time.Now()
+	ka := am.addrs[fresh.String()]
+	ka.attempts = addrRetries
+	ka.lastTry = now.Add(-time.Hour)
+	if !ka.isTerrible(now) {
+		t.Error("接続できないアドレスが使えることになっています")
+	}
+	ka.lastTry = now
+	if ka.isTerrible(now) {
+		t.Error("試したばかりのアドレスが使えないことになっています")
+	}
+}
+
+func TestAddrManagerSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addrmanager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "peers.dat")
+
+	am, err := NewAddrManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var addrs []*protocol.NetAddressV2
+	for i := 0; i < 100; i++ {
+		addrs = append(addrs, testAddr(byte(i)+10, byte(i), 1, 1))
+	}
+	torAddr, err := protocol.NewNetAddressV2FromString("pg6mmjiyjmcrsslvykfwnntlaru7p5svn6y2ymmju6nubxndf4pscryd.onion:8333", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	torAddr.Timestamp = protocol.Uint32Time(time.Now())
+	addrs = append(addrs, torAddr)
+	am.Add(addrs, testAddr(1, 1, 1, 1), 0)
+	for _, addr := range addrs[:10] {
+		am.Good(addr)
+	}
+	if err := am.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := NewAddrManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.key != am.key {
+		t.Error("鍵が一致しません")
+	}
+	if loaded.Size() != am.Size() || loaded.TriedSize() != am.TriedSize() {
+		t.Errorf("数が一致しません: %d/%d != %d/%d", loaded.Size(), loaded.TriedSize(), am.Size(), am.TriedSize())
+	}
+	for key, ka := range am.addrs {
+		l, ok := loaded.addrs[key]
+		if !ok {
+			t.Fatalf("アドレスがありません: %s", key)
+		}
+		if l.tried != ka.tried || l.bucket != ka.bucket || l.pos != ka.pos || l.attempts != ka.attempts {
+			t.Errorf("アドレスの状態が一致しません: %s", key)
+		}
+	}
+
+	// 壊れたファイルは読み込まない
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b[len(b)/2] ^= 1
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewAddrManager(path); err == nil {
+		t.Error("壊れたファイルを読み込みました")
+	}
+}
+
+func TestManagerAddrManager(t *testing.T) {
+	// getaddrに別のピアのアドレスを返すピア
+	other, _, closeOther := servePeers(t, true)
+	defer closeOther()
+	addr, closeListener := serve(t, func(conn net.Conn) {
+		c, err := NewInboundConnection(context.Background(), conn.(*net.TCPConn), core.TestNetwork)
+		if err != nil {
+			conn.Close()
+			return
+		}
+		p := NewInboundPeer(c, PeerConfig{
+			Nonces: NewNonceSet(),
+			Handlers: map[string]MessageHandler{
+				"getaddr": func(p *Peer, msg protocol.Message) {
+					a := protocol.NewMsgAddrV2()
+					a.AddAddress(testTCPAddr(other))
+					p.QueueMessage(a, PriorityNormal, nil)
+				},
+			},
+		})
+		if err := p.Handshake(context.Background()); err != nil {
+			return
+		}
+		p.Start(context.Background())
+	})
+	defer closeListener()
+
+	am, err := NewAddrManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	am.allowLocal = true
+	am.Add([]*protocol.NetAddressV2{testTCPAddr(addr)}, testAddr(1, 1, 1, 1), 0)
+
+	m := NewManager(ManagerConfig{
+		NetType:               core.TestNetwork,
+		Peer:                  PeerConfig{Nonces: NewNonceSet()},
+		MaxOutboundFullRelay:  1,
+		MaxOutboundBlockRelay: 1,
+		AddrManager:           am,
+		NetGroup:              portGroup,
+		ConnectInterval:       10 * time.Millisecond,
+		FeelerInterval:        20 * time.Millisecond,
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	// 接続できたアドレスはtriedテーブルに移り、addrv2で教えてもらったアドレスにも接続する
+	waitFor(t, "接続したアドレスの数", func() bool { return am.Size() == 2 && am.TriedSize() == 2 })
+}
+
+// testTCPAddr はTCPのアドレスを時刻を現在にしたNetAddressV2にする
+func testTCPAddr(addr *net.TCPAddr) *protocol.NetAddressV2 {
+	a := netAddressV2(addr)
+	a.Timestamp = protocol.Uint32Time(time.Now())
+	return a
+}
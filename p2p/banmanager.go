@@ -0,0 +1,284 @@
+package p2p
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// 不正なピアのBANと接続の抑制
+// BANはIPアドレスかサブネットを期限まで拒否し、ファイルに保存します
+// 抑制は不正な振る舞いをしたピアのIPアドレスを期限まで後回しにするもので、保存しません
+// https://github.com/bitcoin/bitcoin/blob/master/src/banman.h
+
+const (
+	// DefaultBanDuration はBANの期限を指定しない場合の期間
+	DefaultBanDuration = 24 * time.Hour
+	// DefaultDiscourageDuration は不正な振る舞いをしたピアの接続を抑制する期間
+	DefaultDiscourageDuration = 24 * time.Hour
+
+	// banFileVersion はBANのファイルの形式のバージョン
+	banFileVersion = 1
+)
+
+// banFileMagic はBANのファイルの先頭のバイト列
+var banFileMagic = [4]byte{'b', 'a', 'n', 's'}
+
+// ErrBanned はBANしているアドレスからの接続の場合のエラー
+var ErrBanned = errors.New("BANしているアドレスです")
+
+// BanEntry はBANしているサブネットと期限
+type BanEntry struct {
+	Subnet *net.IPNet
+	// BANした時刻
+	Created time.Time
+	// BANが解除される時刻
+	Until time.Time
+}
+
+// BanManager はBANと接続の抑制を管理する型
+type BanManager struct {
+	// 保存するファイルのパス、空の場合は保存しません
+	path string
+
+	mtx sync.Mutex
+	// サブネットの文字列をキーにします
+	bans map[string]*BanEntry
+	// 抑制しているIPアドレスと期限
+	discouraged map[string]time.Time
+}
+
+// NewBanManager はBANの管理を生成します
+// pathにファイルがあれば読み込みます、pathが空の場合は保存しません
+func NewBanManager(path string) (*BanManager, error) {
+	bm := &BanManager{
+		path:        path,
+		bans:        map[string]*BanEntry{},
+		discouraged: map[string]time.Time{},
+	}
+	if path == "" {
+		return bm, nil
+	}
+	if err := bm.load(); err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return nil, err
+	}
+	return bm, nil
+}
+
+// ParseSubnet は「1.2.3.4」のようなIPアドレスか「1.2.3.0/24」のようなCIDRの文字列をサブネットにします
+// IPアドレスの場合はそのアドレスだけのサブネットになります
+func ParseSubnet(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, subnet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "サブネットの形式が不正です: %s", s)
+		}
+		return subnet, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, errors.Errorf("IPアドレスの形式が不正です: %s", s)
+	}
+	return hostSubnet(ip), nil
+}
+
+// hostSubnet はIPアドレスだけのサブネットを返す
+func hostSubnet(ip net.IP) *net.IPNet {
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}
+	}
+	return &net.IPNet{IP: ip.To16(), Mask: net.CIDRMask(128, 128)}
+}
+
+// Ban はサブネットをdurationの間BANしてファイルに保存します
+// durationが0の場合はDefaultBanDurationです、既にBANしている場合は期限を更新します
+func (bm *BanManager) Ban(subnet *net.IPNet, duration time.Duration) error {
+	if duration == 0 {
+		duration = DefaultBanDuration
+	}
+	subnet = normalizeSubnet(subnet)
+	now := time.Now()
+	bm.mtx.Lock()
+	bm.bans[subnet.String()] = &BanEntry{Subnet: subnet, Created: now, Until: now.Add(duration)}
+	bm.mtx.Unlock()
+	return bm.Save()
+}
+
+// Unban はサブネットのBANを解除してファイルに保存します
+func (bm *BanManager) Unban(subnet *net.IPNet) error {
+	key := normalizeSubnet(subnet).String()
+	bm.mtx.Lock()
+	if _, ok := bm.bans[key]; !ok {
+		bm.mtx.Unlock()
+		return errors.Errorf("BANしていないサブネットです: %s", key)
+	}
+	delete(bm.bans, key)
+	bm.mtx.Unlock()
+	return bm.Save()
+}
+
+// List は期限が切れていないBANの一覧をサブネットの順に返します
+func (bm *BanManager) List() []BanEntry {
+	bm.mtx.Lock()
+	defer bm.mtx.Unlock()
+	bm.sweep(time.Now())
+	entries := make([]BanEntry, 0, len(bm.bans))
+	for _, e := range bm.bans {
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].Subnet.IP.To16(), entries[j].Subnet.IP.To16()) < 0
+	})
+	return entries
+}
+
+// Clear は全てのBANを解除してファイルに保存します
+func (bm *BanManager) Clear() error {
+	bm.mtx.Lock()
+	bm.bans = map[string]*BanEntry{}
+	bm.mtx.Unlock()
+	return bm.Save()
+}
+
+// IsBanned はIPアドレスがBANしているサブネットに含まれるかどうかを返します
+func (bm *BanManager) IsBanned(ip net.IP) bool {
+	bm.mtx.Lock()
+	defer bm.mtx.Unlock()
+	now := time.Now()
+	for _, e := range bm.bans {
+		if now.Before(e.Until) && e.Subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Discourage はIPアドレスからの接続をDefaultDiscourageDurationの間抑制します
+func (bm *BanManager) Discourage(ip net.IP) {
+	bm.mtx.Lock()
+	defer bm.mtx.Unlock()
+	bm.discouraged[ip.String()] = time.Now().Add(DefaultDiscourageDuration)
+}
+
+// IsDiscouraged はIPアドレスからの接続を抑制しているかどうかを返します
+func (bm *BanManager) IsDiscouraged(ip net.IP) bool {
+	bm.mtx.Lock()
+	defer bm.mtx.Unlock()
+	until, ok := bm.discouraged[ip.String()]
+	if ok && !time.Now().Before(until) {
+		delete(bm.discouraged, ip.String())
+		return false
+	}
+	return ok
+}
+
+// sweep は期限が切れたBANと抑制を取り除く、ロックを取った状態で呼び出す
+func (bm *BanManager) sweep(now time.Time) {
+	for key, e := range bm.bans {
+		if !now.Before(e.Until) {
+			delete(bm.bans, key)
+		}
+	}
+	for key, until := range bm.discouraged {
+		if !now.Before(until) {
+			delete(bm.discouraged, key)
+		}
+	}
+}
+
+// normalizeSubnet はIPv4のサブネットを4バイトの形式にそろえて、ホスト部を0にする
+func normalizeSubnet(subnet *net.IPNet) *net.IPNet {
+	ones, bits := subnet.Mask.Size()
+	ip := subnet.IP
+	if ip4 := ip.To4(); ip4 != nil {
+		if bits == 8*net.IPv4len {
+			ip = ip4
+		} else if 8*(net.IPv6len-net.IPv4len) <= ones {
+			ip, ones, bits = ip4, ones-8*(net.IPv6len-net.IPv4len), 8*net.IPv4len
+		}
+	}
+	mask := net.CIDRMask(ones, bits)
+	return &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+}
+
+// banFile はBANのファイルの形式
+type banFile struct {
+	Magic   [4]byte
+	Version uint8
+	Bans    []*banFileEntry `btc:"max=65536"`
+}
+
+// banFileEntry はBANのファイルに保存するサブネットと期限
+type banFileEntry struct {
+	// IPv4はIPv4射影アドレスにします
+	IP [16]byte
+	// IPの16バイトに対するプレフィックスの長さ
+	PrefixLen uint8
+	// Unix時間
+	Created int64
+	Until   int64
+}
+
+// Save は期限が切れていないBANをファイルに保存します、抑制しているアドレスは保存しません
+func (bm *BanManager) Save() error {
+	if bm.path == "" {
+		return nil
+	}
+	bm.mtx.Lock()
+	bm.sweep(time.Now())
+	f := banFile{Magic: banFileMagic, Version: banFileVersion}
+	for _, e := range bm.bans {
+		entry := &banFileEntry{Created: e.Created.Unix(), Until: e.Until.Unix()}
+		copy(entry.IP[:], e.Subnet.IP.To16())
+		ones, bits := e.Subnet.Mask.Size()
+		entry.PrefixLen = uint8(ones + 8*net.IPv6len - bits)
+		f.Bans = append(f.Bans, entry)
+	}
+	bm.mtx.Unlock()
+
+	var buf bytes.Buffer
+	if err := protocol.SerializeStruct(&buf, protocol.CurrentVersion, &f); err != nil {
+		return err
+	}
+	return writeChecksumFile(bm.path, buf.Bytes())
+}
+
+// load はファイルからBANを読み込む、期限が切れたものは捨てます
+func (bm *BanManager) load() error {
+	body, err := readChecksumFile(bm.path)
+	if err != nil {
+		return err
+	}
+	var f banFile
+	if err := protocol.DeserializeStruct(bytes.NewReader(body), protocol.CurrentVersion, &f); err != nil {
+		return errors.Wrap(err, "BANのファイルのデコードに失敗しました")
+	}
+	if f.Magic != banFileMagic || f.Version != banFileVersion {
+		return errors.Errorf("BANのファイルの形式が不正です: version=%d", f.Version)
+	}
+
+	bm.mtx.Lock()
+	defer bm.mtx.Unlock()
+	now := time.Now()
+	for _, e := range f.Bans {
+		if 8*net.IPv6len < int(e.PrefixLen) {
+			continue
+		}
+		until := time.Unix(e.Until, 0)
+		if !now.Before(until) {
+			continue
+		}
+		ip := make(net.IP, net.IPv6len)
+		copy(ip, e.IP[:])
+		subnet := normalizeSubnet(&net.IPNet{IP: ip, Mask: net.CIDRMask(int(e.PrefixLen), 8*net.IPv6len)})
+		bm.bans[subnet.String()] = &BanEntry{Subnet: subnet, Created: time.Unix(e.Created, 0), Until: until}
+	}
+	return nil
+}
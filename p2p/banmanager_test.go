@@ -0,0 +1,156 @@
+package p2p
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func mustParseSubnet(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+	subnet, err := ParseSubnet(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return subnet
+}
+
+func TestParseSubnet(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"1.2.3.4", "1.2.3.4/32"},
+		{"1.2.3.4/24", "1.2.3.0/24"},
+		{"2001:db8::1", "2001:db8::1/128"},
+		{"2001:db8::/32", "2001:db8::/32"},
+	}
+	for _, tt := range tests {
+		if subnet := mustParseSubnet(t, tt.in); subnet.String() != tt.out {
+			t.Errorf("サブネットが一致しません: %s != %s", subnet, tt.out)
+		}
+	}
+	for _, s := range []string{"", "1.2.3", "1.2.3.4/33", "example.com"} {
+		if _, err := ParseSubnet(s); err == nil {
+			t.Errorf("不正な形式を受け入れました: %q", s)
+		}
+	}
+}
+
+func TestBanManager(t *testing.T) {
+	bm, err := NewBanManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bm.Ban(mustParseSubnet(t, "10.1.0.0/16"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := bm.Ban(mustParseSubnet(t, "2001:db8::1"), 0); err != nil {
+		t.Fatal(err)
+	}
+	for ip, banned := range map[string]bool{
+		"10.1.2.3":    true,
+		"10.2.0.1":    false,
+		"2001:db8::1": true,
+		"2001:db8::2": false,
+	} {
+		if bm.IsBanned(net.ParseIP(ip)) != banned {
+			t.Errorf("BANの判定が一致しません: %s", ip)
+		}
+	}
+
+	list := bm.List()
+	if len(list) != 2 || list[0].Subnet.String() != "10.1.0.0/16" || list[1].Subnet.String() != "2001:db8::1/128" {
+		t.Fatalf("BANの一覧が一致しません: %v", list)
+	}
+	if d := list[1].Until.Sub(list[1].Created); d != DefaultBanDuration {
+		t.Errorf("BANの期間が一致しません: %v", d)
+	}
+
+	// 期限が切れたBANは無くなる
+	if err := bm.Ban(mustParseSubnet(t, "192.0.2.1"), -time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if bm.IsBanned(net.ParseIP("192.0.2.1")) || len(bm.List()) != 2 {
+		t.Error("期限が切れたBANが残っています")
+	}
+
+	if err := bm.Unban(mustParseSubnet(t, "10.1.2.3/16")); err != nil {
+		t.Fatal(err)
+	}
+	if bm.IsBanned(net.ParseIP("10.1.2.3")) {
+		t.Error("BANが解除されていません")
+	}
+	if err := bm.Unban(mustParseSubnet(t, "10.1.0.0/16")); err == nil {
+		t.Error("BANしていないサブネットを解除できました")
+	}
+	if err := bm.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if len(bm.List()) != 0 || bm.IsBanned(net.ParseIP("2001:db8::1")) {
+		t.Error("BANが全て解除されていません")
+	}
+
+	// 抑制はBANとは別に管理する
+	ip := net.ParseIP("10.1.2.3")
+	bm.Discourage(ip)
+	if !bm.IsDiscouraged(ip) || bm.IsDiscouraged(net.ParseIP("10.1.2.4")) || bm.IsBanned(ip) {
+		t.Error("抑制の判定が一致しません")
+	}
+}
+
+func TestBanManagerSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "banmanager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "banlist.dat")
+
+	bm, err := NewBanManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"10.1.0.0/16", "192.0.2.1", "2001:db8::/32"} {
+		if err := bm.Ban(mustParseSubnet(t, s), time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bm.Discourage(net.ParseIP("198.51.100.1"))
+
+	loaded, err := NewBanManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, got := bm.List(), loaded.List()
+	if len(got) != len(want) {
+		t.Fatalf("BANの数が一致しません: %d != %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Subnet.String() != want[i].Subnet.String() || got[i].Until.Unix() != want[i].Until.Unix() {
+			t.Errorf("BANが一致しません: %v != %v", got[i], want[i])
+		}
+	}
+	if !loaded.IsBanned(net.ParseIP("10.1.255.255")) {
+		t.Error("読み込んだBANが効いていません")
+	}
+	if loaded.IsDiscouraged(net.ParseIP("198.51.100.1")) {
+		t.Error("抑制が保存されています")
+	}
+
+	// 壊れたファイルは読み込まない
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b[len(b)-1] ^= 1
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewBanManager(path); err == nil {
+		t.Error("壊れたファイルを読み込みました")
+	}
+}
@@ -0,0 +1,142 @@
+package bip324
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// パケットの暗号化
+// https://github.com/bitcoin/bips/blob/master/bip-0324.mediawiki#overall-packet-encryption-and-decryption-pseudocode
+
+const (
+	// LengthFieldSize は暗号化したパケットの長さのバイトサイズ
+	LengthFieldSize = 3
+	// HeaderSize はパケットのヘッダーのバイトサイズ
+	HeaderSize = 1
+	// GarbageTerminatorSize はガベージの終わりを示すバイト列のサイズ
+	GarbageTerminatorSize = 16
+	// SessionIDSize はセッションIDのバイトサイズ
+	SessionIDSize = 32
+	// MaxContentsSize はパケットの内容の最大サイズ
+	MaxContentsSize = 1<<(LengthFieldSize*8) - 1
+
+	// ignoreBit はデコイパケットを示すヘッダーのビット
+	ignoreBit byte = 1 << 7
+	// expansionSize はAEADの認証タグのサイズ
+	expansionSize = chacha20poly1305.Overhead
+)
+
+// Cipher はBIP324のセッションの送受信の暗号を保持する型
+type Cipher struct {
+	sendL *FSChaCha20
+	sendP *FSChaCha20Poly1305
+	recvL *FSChaCha20
+	recvP *FSChaCha20Poly1305
+
+	// SessionID は両方のピアで一致するセッションの識別子
+	SessionID [SessionIDSize]byte
+	// SendGarbageTerminator は自身が送るガベージの終わりを示すバイト列
+	SendGarbageTerminator [GarbageTerminatorSize]byte
+	// RecvGarbageTerminator は相手が送るガベージの終わりを示すバイト列
+	RecvGarbageTerminator [GarbageTerminatorSize]byte
+}
+
+// NewCipher はECDHの共有秘密から送受信の鍵を導出してCipherを生成します
+// magicはネットワークのマジックで、異なるネットワークのピアとは鍵が一致しなくなります
+func NewCipher(ecdhSecret []byte, magic [4]byte, initiating bool) (*Cipher, error) {
+	salt := append([]byte("bitcoin_v2_shared_secret"), magic[:]...)
+	prk := hkdf.Extract(sha256.New, ecdhSecret, salt)
+	expand := func(info string, size int) ([]byte, error) {
+		out := make([]byte, size)
+		if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, []byte(info)), out); err != nil {
+			return nil, errors.Wrapf(err, "鍵の導出に失敗しました: %s", info)
+		}
+		return out, nil
+	}
+
+	keys := map[string][]byte{}
+	for _, info := range []string{"initiator_L", "initiator_P", "responder_L", "responder_P", "garbage_terminators", "session_id"} {
+		key, err := expand(info, 32)
+		if err != nil {
+			return nil, err
+		}
+		keys[info] = key
+	}
+
+	sendRole, recvRole := "initiator", "responder"
+	initiatorTerm, responderTerm := keys["garbage_terminators"][:16], keys["garbage_terminators"][16:]
+	sendTerm, recvTerm := initiatorTerm, responderTerm
+	if !initiating {
+		sendRole, recvRole = recvRole, sendRole
+		sendTerm, recvTerm = recvTerm, sendTerm
+	}
+
+	c := &Cipher{
+		sendP: NewFSChaCha20Poly1305(keys[sendRole+"_P"]),
+		recvP: NewFSChaCha20Poly1305(keys[recvRole+"_P"]),
+	}
+	var err error
+	if c.sendL, err = NewFSChaCha20(keys[sendRole+"_L"]); err != nil {
+		return nil, err
+	}
+	if c.recvL, err = NewFSChaCha20(keys[recvRole+"_L"]); err != nil {
+		return nil, err
+	}
+	copy(c.SessionID[:], keys["session_id"])
+	copy(c.SendGarbageTerminator[:], sendTerm)
+	copy(c.RecvGarbageTerminator[:], recvTerm)
+	return c, nil
+}
+
+// Encrypt はパケットを暗号化します
+// ignoreを指定すると受信側で読み捨てられるデコイパケットになります
+func (c *Cipher) Encrypt(contents, aad []byte, ignore bool) ([]byte, error) {
+	if MaxContentsSize < len(contents) {
+		return nil, errors.Errorf("パケットの内容が大きすぎます: size=%d", len(contents))
+	}
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(contents)))
+	encLength, err := c.sendL.Crypt(length[:LengthFieldSize])
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, HeaderSize+len(contents))
+	if ignore {
+		plaintext[0] = ignoreBit
+	}
+	copy(plaintext[HeaderSize:], contents)
+	ciphertext, err := c.sendP.Encrypt(aad, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return append(encLength, ciphertext...), nil
+}
+
+// DecryptLength は暗号化されたパケットの長さを復号し、続けて読むべきバイト数を返します
+func (c *Cipher) DecryptLength(encLength []byte) (int, error) {
+	if len(encLength) != LengthFieldSize {
+		return 0, errors.Errorf("長さのフィールドのサイズが不正です: %d", len(encLength))
+	}
+	b, err := c.recvL.Crypt(encLength)
+	if err != nil {
+		return 0, err
+	}
+	length := int(b[0]) | int(b[1])<<8 | int(b[2])<<16
+	return HeaderSize + length + expansionSize, nil
+}
+
+// Decrypt はDecryptLengthで求めたサイズのパケットを復号します
+// デコイパケットの場合はignoreがtrueになります
+func (c *Cipher) Decrypt(ciphertext, aad []byte) (contents []byte, ignore bool, err error) {
+	plaintext, err := c.recvP.Decrypt(aad, ciphertext)
+	if err != nil {
+		return nil, false, err
+	}
+	return plaintext[HeaderSize:], plaintext[0]&ignoreBit != 0, nil
+}
@@ -0,0 +1,78 @@
+package bip324
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPacketEncodingVectors(t *testing.T) {
+	for _, row := range readTestVectors(t, "packet_encoding_test_vectors.csv") {
+		initiating := row["in_initiating"] == "1"
+		k, err := NewPrivateKey(mustHex(t, row["in_priv_ours"]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ours, theirs EllSwiftPubKey
+		copy(ours[:], mustHex(t, row["in_ellswift_ours"]))
+		copy(theirs[:], mustHex(t, row["in_ellswift_theirs"]))
+
+		checkHex := func(name string, got []byte) {
+			if hex.EncodeToString(got) != row[name] {
+				t.Errorf("%sが一致しません: idx=%s, %x != %s", name, row["in_idx"], got, row[name])
+			}
+		}
+		checkHex("mid_x_ours", feBytes(k.publicX()))
+		checkHex("mid_x_ours", feBytes(ours.X()))
+		checkHex("mid_x_theirs", feBytes(theirs.X()))
+		shared, err := k.ECDHXOnly(theirs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkHex("mid_x_shared", shared)
+		secret, err := k.V2ECDH(theirs, ours, initiating)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkHex("mid_shared_secret", secret)
+
+		c, err := NewCipher(secret, testMagic, initiating)
+		if err != nil {
+			t.Fatal(err)
+		}
+		initiatorL, initiatorP, responderL, responderP := c.sendL.key, c.sendP.key, c.recvL.key, c.recvP.key
+		if !initiating {
+			initiatorL, initiatorP, responderL, responderP = responderL, responderP, initiatorL, initiatorP
+		}
+		checkHex("mid_initiator_l", initiatorL)
+		checkHex("mid_initiator_p", initiatorP)
+		checkHex("mid_responder_l", responderL)
+		checkHex("mid_responder_p", responderP)
+		checkHex("mid_send_garbage_terminator", c.SendGarbageTerminator[:])
+		checkHex("mid_recv_garbage_terminator", c.RecvGarbageTerminator[:])
+		checkHex("out_session_id", c.SessionID[:])
+
+		// in_idx個の空のパケットを送った後のパケットを比べる、鍵の更新後の値も含まれます
+		idx, _ := strconv.Atoi(row["in_idx"])
+		for i := 0; i < idx; i++ {
+			if _, err := c.Encrypt(nil, nil, false); err != nil {
+				t.Fatal(err)
+			}
+		}
+		multiply, _ := strconv.Atoi(row["in_multiply"])
+		contents := bytes.Repeat(mustHex(t, row["in_contents"]), multiply)
+		ciphertext, err := c.Encrypt(contents, mustHex(t, row["in_aad"]), row["in_ignore"] == "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := hex.EncodeToString(ciphertext)
+		if want := row["out_ciphertext"]; want != "" && got != want {
+			t.Errorf("暗号文が一致しません: idx=%s", row["in_idx"])
+		}
+		if want := row["out_ciphertext_endswith"]; want != "" && !strings.HasSuffix(got, want) {
+			t.Errorf("暗号文の末尾が一致しません: idx=%s", row["in_idx"])
+		}
+	}
+}
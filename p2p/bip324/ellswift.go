@@ -0,0 +1,207 @@
+package bip324
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/pkg/errors"
+)
+
+// ElligatorSwiftによる公開鍵のエンコード
+// 公開鍵を一様な乱数と区別できない64バイトにエンコードします
+// https://github.com/bitcoin/bips/blob/master/bip-0324.mediawiki#ellswift-encoding
+
+// EllSwiftPubKeySize はElligatorSwiftでエンコードした公開鍵のバイトサイズ
+const EllSwiftPubKeySize = 64
+
+// EllSwiftPubKey はElligatorSwiftでエンコードした公開鍵
+type EllSwiftPubKey [EllSwiftPubKeySize]byte
+
+// sqrtMinus3 は-3の平方根
+var sqrtMinus3 = feSqrt(feNeg(big.NewInt(3)))
+
+// XSwiftEC は体の要素u, tから曲線上の点のX座標を求めます
+// 任意の入力に対して必ずX座標を返します
+func XSwiftEC(u, t *big.Int) *big.Int {
+	u = new(big.Int).Mod(u, fieldP)
+	t = new(big.Int).Mod(t, fieldP)
+	if u.Sign() == 0 {
+		u = big.NewInt(1)
+	}
+	if t.Sign() == 0 {
+		t = big.NewInt(1)
+	}
+	u3b := curveY2(u)
+	if feAdd(u3b, feMul(t, t)).Sign() == 0 {
+		t = feMul(big.NewInt(2), t)
+	}
+
+	x := feDiv(feSub(u3b, feMul(t, t)), feMul(big.NewInt(2), t))
+	y := feDiv(feAdd(x, t), feMul(sqrtMinus3, u))
+	half := feInv(big.NewInt(2))
+	candidates := []*big.Int{
+		feAdd(u, feMul(big.NewInt(4), feMul(y, y))),
+		feMul(feSub(feNeg(feDiv(x, y)), u), half),
+		feMul(feSub(feDiv(x, y), u), half),
+	}
+	for _, c := range candidates {
+		if isValidX(c) {
+			return c
+		}
+	}
+	// g(x1)g(x2)g(x3)は平方数なので必ずどれかが曲線上にある
+	panic("XSwiftEC: 曲線上のX座標が見つかりません")
+}
+
+// XSwiftECInv はXSwiftEC(u, t) = xとなるtを求めます
+// caseNo(0から7)で最大8つの解のどれを返すか選びます、解がない場合はnilを返します
+func XSwiftECInv(x, u *big.Int, caseNo int) *big.Int {
+	u3b := curveY2(u)
+	var s, v *big.Int
+	if caseNo&2 == 0 {
+		if isValidX(feSub(feNeg(x), u)) {
+			return nil
+		}
+		v = x
+		s = feNeg(feDiv(u3b, feAdd(feAdd(feMul(u, u), feMul(u, v)), feMul(v, v))))
+	} else {
+		s = feSub(x, u)
+		if s.Sign() == 0 {
+			return nil
+		}
+		r := feSqrt(feNeg(feMul(s, feAdd(feMul(big.NewInt(4), u3b), feMul(feMul(big.NewInt(3), s), feMul(u, u))))))
+		if r == nil {
+			return nil
+		}
+		if caseNo&1 != 0 && r.Sign() == 0 {
+			return nil
+		}
+		v = feMul(feAdd(feNeg(u), feDiv(r, s)), feInv(big.NewInt(2)))
+	}
+	w := feSqrt(s)
+	if w == nil {
+		return nil
+	}
+
+	half := feInv(big.NewInt(2))
+	// u*(1-c)/2 + v と u*(1+c)/2 + v
+	minus := feAdd(feMul(feMul(u, feSub(big.NewInt(1), sqrtMinus3)), half), v)
+	plus := feAdd(feMul(feMul(u, feAdd(big.NewInt(1), sqrtMinus3)), half), v)
+	switch caseNo & 5 {
+	case 0:
+		return feNeg(feMul(w, minus))
+	case 1:
+		return feMul(w, plus)
+	case 4:
+		return feMul(w, minus)
+	default:
+		return feNeg(feMul(w, plus))
+	}
+}
+
+// PrivateKey はBIP324の鍵交換に使う使い捨ての秘密鍵
+type PrivateKey struct {
+	d secp256k1.ModNScalar
+}
+
+// GeneratePrivateKey はランダムな秘密鍵を生成します
+func GeneratePrivateKey(random io.Reader) (*PrivateKey, error) {
+	buf := make([]byte, 32)
+	for {
+		if _, err := io.ReadFull(random, buf); err != nil {
+			return nil, errors.Wrap(err, "乱数の読み込みに失敗しました")
+		}
+		if k, err := NewPrivateKey(buf); err == nil {
+			return k, nil
+		}
+	}
+}
+
+// NewPrivateKey は32バイトの秘密鍵から生成します
+func NewPrivateKey(b []byte) (*PrivateKey, error) {
+	k := &PrivateKey{}
+	if len(b) != 32 || k.d.SetByteSlice(b) || k.d.IsZero() {
+		return nil, errors.New("秘密鍵が不正です")
+	}
+	return k, nil
+}
+
+// publicX は公開鍵のX座標を返す
+func (k *PrivateKey) publicX() *big.Int {
+	return basePoint().mul(&k.d).affineX()
+}
+
+// EllSwiftCreate は秘密鍵に対応する公開鍵をElligatorSwiftでエンコードします
+// randomはエンコードのランダムなuとcaseを選ぶために使います
+func (k *PrivateKey) EllSwiftCreate(random io.Reader) (EllSwiftPubKey, error) {
+	var pub EllSwiftPubKey
+	x := k.publicX()
+	buf := make([]byte, 33)
+	for {
+		if _, err := io.ReadFull(random, buf); err != nil {
+			return pub, errors.Wrap(err, "乱数の読み込みに失敗しました")
+		}
+		u := new(big.Int).Mod(new(big.Int).SetBytes(buf[:32]), fieldP)
+		if u.Sign() == 0 {
+			continue
+		}
+		t := XSwiftECInv(x, u, int(buf[32]&7))
+		if t == nil {
+			continue
+		}
+		copy(pub[:32], feBytes(u))
+		copy(pub[32:], feBytes(t))
+		return pub, nil
+	}
+}
+
+// X はエンコードされた公開鍵のX座標を返します
+func (p EllSwiftPubKey) X() *big.Int {
+	u := new(big.Int).SetBytes(p[:32])
+	t := new(big.Int).SetBytes(p[32:])
+	return XSwiftEC(u, t)
+}
+
+// ECDHXOnly は相手の公開鍵と自身の秘密鍵から共有点のX座標を計算します
+func (k *PrivateKey) ECDHXOnly(theirs EllSwiftPubKey) ([]byte, error) {
+	p, ok := liftX(theirs.X())
+	if !ok {
+		return nil, errors.New("公開鍵が曲線上にありません")
+	}
+	x := p.mul(&k.d).affineX()
+	if x == nil {
+		return nil, errors.New("共有点が無限遠点です")
+	}
+	return feBytes(x), nil
+}
+
+// taggedHash はBIP340のタグ付きハッシュを計算する
+func taggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, m := range msgs {
+		h.Write(m)
+	}
+	return h.Sum(nil)
+}
+
+// V2ECDH はBIP324の共有秘密を計算します
+// 公開鍵は接続を開始した側のものを先に並べます
+func (k *PrivateKey) V2ECDH(theirs, ours EllSwiftPubKey, initiating bool) ([]byte, error) {
+	x, err := k.ECDHXOnly(theirs)
+	if err != nil {
+		return nil, err
+	}
+	if initiating {
+		return taggedHash("bip324_ellswift_xonly_ecdh", ours[:], theirs[:], x), nil
+	}
+	return taggedHash("bip324_ellswift_xonly_ecdh", theirs[:], ours[:], x), nil
+}
+
+// defaultRandom は鍵やガベージの生成に使う乱数
+var defaultRandom io.Reader = rand.Reader
@@ -0,0 +1,128 @@
+package bip324
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// readTestVectors はtestdataにあるBIP324のテストベクタのCSVを読み込む
+// https://github.com/bitcoin/bips/tree/master/bip-0324
+func readTestVectors(t *testing.T, name string) []map[string]string {
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []map[string]string
+	for _, record := range records[1:] {
+		row := map[string]string{}
+		for i, column := range records[0] {
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// mustHex はテストベクタの16進数の文字列をバイト列にする
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestEllSwiftDecodeVectors(t *testing.T) {
+	for _, row := range readTestVectors(t, "ellswift_decode_test_vectors.csv") {
+		var pub EllSwiftPubKey
+		copy(pub[:], mustHex(t, row["ellswift"]))
+		if x := hex.EncodeToString(feBytes(pub.X())); x != row["x"] {
+			t.Errorf("X座標が一致しません: %s != %s (%s)", x, row["x"], row["comment"])
+		}
+	}
+}
+
+func TestXSwiftECInvVectors(t *testing.T) {
+	for _, row := range readTestVectors(t, "xswiftec_inv_test_vectors.csv") {
+		u := new(big.Int).SetBytes(mustHex(t, row["u"]))
+		x := new(big.Int).SetBytes(mustHex(t, row["x"]))
+		for caseNo := 0; caseNo < 8; caseNo++ {
+			want := row[fmt.Sprintf("case%d_t", caseNo)]
+			tt := XSwiftECInv(x, u, caseNo)
+			if tt == nil {
+				if want != "" {
+					t.Errorf("解が見つかりません: u=%s, case=%d", row["u"], caseNo)
+				}
+				continue
+			}
+			if got := hex.EncodeToString(feBytes(tt)); got != want {
+				t.Errorf("解が一致しません: u=%s, case=%d, %s != %s", row["u"], caseNo, got, want)
+			}
+		}
+	}
+}
+
+func TestXSwiftECInv(t *testing.T) {
+	found := make([]bool, 8)
+	for i := 0; i < 64; i++ {
+		k, err := GeneratePrivateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x := k.publicX()
+		b := make([]byte, 32)
+		rand.Read(b)
+		u := new(big.Int).Mod(new(big.Int).SetBytes(b), fieldP)
+		for caseNo := 0; caseNo < 8; caseNo++ {
+			tt := XSwiftECInv(x, u, caseNo)
+			if tt == nil {
+				continue
+			}
+			found[caseNo] = true
+			if XSwiftEC(u, tt).Cmp(x) != 0 {
+				t.Errorf("逆変換したtからX座標が戻りません: case=%d", caseNo)
+			}
+		}
+	}
+	for caseNo, ok := range found {
+		if !ok {
+			t.Errorf("解が1つも見つかりませんでした: case=%d", caseNo)
+		}
+	}
+}
+
+func TestV2ECDH(t *testing.T) {
+	initiator, _ := GeneratePrivateKey(rand.Reader)
+	responder, _ := GeneratePrivateKey(rand.Reader)
+	initiatorPub, err := initiator.EllSwiftCreate(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	responderPub, err := responder.EllSwiftCreate(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s1, err := initiator.V2ECDH(responderPub, initiatorPub, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := responder.V2ECDH(initiatorPub, responderPub, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(s1) != hex.EncodeToString(s2) {
+		t.Errorf("共有秘密が一致しません: %x != %x", s1, s2)
+	}
+}
@@ -0,0 +1,113 @@
+package bip324
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// 前方秘匿性のために一定回数ごとに鍵を更新するChaCha20
+// https://github.com/bitcoin/bips/blob/master/bip-0324.mediawiki#rekeying-wrappers-fschacha20poly1305-and-fschacha20
+
+// RekeyInterval は鍵を更新するまでに暗号化するパケットの数
+const RekeyInterval = 224
+
+// ErrDecrypt はパケットの認証に失敗した場合のエラー
+var ErrDecrypt = errors.New("パケットの復号に失敗しました")
+
+// newNonce はFSChaCha20で使う96bitのNonceを生成する
+// 先頭4バイトはパケットの番号、残り8バイトは鍵の更新回数
+func newNonce(low uint32, epoch uint64) []byte {
+	nonce := make([]byte, chacha20.NonceSize)
+	binary.LittleEndian.PutUint32(nonce[:4], low)
+	binary.LittleEndian.PutUint64(nonce[4:], epoch)
+	return nonce
+}
+
+// FSChaCha20 はパケットの長さの暗号化に使うストリーム暗号
+// 鍵の更新までの間は1つのキーストリームを続けて使います
+type FSChaCha20 struct {
+	key          []byte
+	chunkCounter uint64
+	stream       *chacha20.Cipher
+}
+
+// NewFSChaCha20 はFSChaCha20を生成します
+func NewFSChaCha20(key []byte) (*FSChaCha20, error) {
+	c := &FSChaCha20{key: append([]byte{}, key...)}
+	if err := c.resetStream(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *FSChaCha20) resetStream() (err error) {
+	c.stream, err = chacha20.NewUnauthenticatedCipher(c.key, newNonce(0, c.chunkCounter/RekeyInterval))
+	return errors.Wrap(err, "ChaCha20の初期化に失敗しました")
+}
+
+// Crypt はchunkを暗号化または復号します
+func (c *FSChaCha20) Crypt(chunk []byte) ([]byte, error) {
+	out := make([]byte, len(chunk))
+	c.stream.XORKeyStream(out, chunk)
+	if (c.chunkCounter+1)%RekeyInterval == 0 {
+		// キーストリームの続きを次の鍵にする
+		key := make([]byte, chacha20.KeySize)
+		c.stream.XORKeyStream(key, key)
+		c.key = key
+		c.chunkCounter++
+		return out, c.resetStream()
+	}
+	c.chunkCounter++
+	return out, nil
+}
+
+// FSChaCha20Poly1305 はパケットの内容の暗号化に使うAEAD
+type FSChaCha20Poly1305 struct {
+	key           []byte
+	packetCounter uint64
+}
+
+// NewFSChaCha20Poly1305 はFSChaCha20Poly1305を生成します
+func NewFSChaCha20Poly1305(key []byte) *FSChaCha20Poly1305 {
+	return &FSChaCha20Poly1305{key: append([]byte{}, key...)}
+}
+
+// Encrypt はplaintextを暗号化して認証タグを付けます
+func (c *FSChaCha20Poly1305) Encrypt(aad, plaintext []byte) ([]byte, error) {
+	return c.crypt(aad, plaintext, false)
+}
+
+// Decrypt はciphertextの認証タグを検証して復号します
+func (c *FSChaCha20Poly1305) Decrypt(aad, ciphertext []byte) ([]byte, error) {
+	return c.crypt(aad, ciphertext, true)
+}
+
+func (c *FSChaCha20Poly1305) crypt(aad, text []byte, decrypt bool) ([]byte, error) {
+	aead, err := chacha20poly1305.New(c.key)
+	if err != nil {
+		return nil, errors.Wrap(err, "ChaCha20Poly1305の初期化に失敗しました")
+	}
+	epoch := c.packetCounter / RekeyInterval
+	nonce := newNonce(uint32(c.packetCounter%RekeyInterval), epoch)
+
+	var out []byte
+	if decrypt {
+		out, err = aead.Open(nil, nonce, text, aad)
+		if err != nil {
+			err = ErrDecrypt
+		}
+	} else {
+		out = aead.Seal(nil, nonce, text, aad)
+	}
+
+	if (c.packetCounter+1)%RekeyInterval == 0 {
+		// 0xffffffffのNonceで32バイトの0を暗号化したものを次の鍵にする
+		rekeyNonce := newNonce(0xffffffff, epoch)
+		c.key = aead.Seal(nil, rekeyNonce, make([]byte, chacha20poly1305.KeySize), nil)[:chacha20poly1305.KeySize]
+	}
+	c.packetCounter++
+	return out, err
+}
@@ -0,0 +1,81 @@
+package bip324
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/crypto/chacha20"
+)
+
+func TestFSChaCha20(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 32)
+	enc, err := NewFSChaCha20(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := NewFSChaCha20(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 鍵の更新までは1つのキーストリームを続けて使う
+	stream, _ := chacha20.NewUnauthenticatedCipher(key, newNonce(0, 0))
+	for i := 0; i < RekeyInterval*3; i++ {
+		chunk := []byte{byte(i), byte(i >> 8), 3}
+		c, err := enc.Crypt(chunk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i < RekeyInterval {
+			want := make([]byte, len(chunk))
+			stream.XORKeyStream(want, chunk)
+			if !bytes.Equal(c, want) {
+				t.Fatalf("キーストリームが一致しません: i=%d", i)
+			}
+		}
+		p, err := dec.Crypt(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(p, chunk) {
+			t.Fatalf("復号したデータが一致しません: i=%d", i)
+		}
+	}
+}
+
+func TestFSChaCha20Poly1305(t *testing.T) {
+	key := bytes.Repeat([]byte{0x24}, 32)
+	enc := NewFSChaCha20Poly1305(key)
+	dec := NewFSChaCha20Poly1305(key)
+
+	var first []byte
+	for i := 0; i < RekeyInterval*2+1; i++ {
+		plaintext := []byte("bip324")
+		aad := []byte{byte(i)}
+		c, err := enc.Encrypt(aad, plaintext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i%RekeyInterval == 0 {
+			// 鍵が更新されているので同じ平文でも暗号文は変わる
+			if first != nil && bytes.Equal(c, first) {
+				t.Errorf("鍵が更新されていません: i=%d", i)
+			}
+			first = c
+		}
+		p, err := dec.Decrypt(aad, c)
+		if err != nil {
+			t.Fatalf("復号に失敗しました: i=%d, %v", i, err)
+		}
+		if !bytes.Equal(p, plaintext) {
+			t.Fatalf("復号したデータが一致しません: i=%d", i)
+		}
+	}
+
+	// 改ざんされたパケットは認証に失敗する
+	c, _ := enc.Encrypt(nil, []byte("bip324"))
+	c[0] ^= 1
+	if _, err := dec.Decrypt(nil, c); err != ErrDecrypt {
+		t.Errorf("改ざんが検出されませんでした: %v", err)
+	}
+}
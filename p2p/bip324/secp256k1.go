@@ -0,0 +1,195 @@
+package bip324
+
+import (
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// ElligatorSwiftとECDHで使うsecp256k1の演算
+// 公開されている値の演算は鍵交換の度に数回使うだけなのでmath/bigで素直に実装します
+
+// secp256k1の曲線パラメータ
+// https://www.secg.org/sec2-v2.pdf
+var (
+	fieldP  = mustHexInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	curveGx = mustHexInt("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	curveGy = mustHexInt("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")
+	curveB  = big.NewInt(7)
+
+	// sqrtExp は平方根を求めるための指数(p+1)/4
+	sqrtExp = new(big.Int).Rsh(new(big.Int).Add(fieldP, big.NewInt(1)), 2)
+)
+
+func mustHexInt(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex: " + s)
+	}
+	return v
+}
+
+// feAdd などは法pでの演算を行う
+func feAdd(a, b *big.Int) *big.Int { return new(big.Int).Mod(new(big.Int).Add(a, b), fieldP) }
+func feSub(a, b *big.Int) *big.Int { return new(big.Int).Mod(new(big.Int).Sub(a, b), fieldP) }
+func feMul(a, b *big.Int) *big.Int { return new(big.Int).Mod(new(big.Int).Mul(a, b), fieldP) }
+func feNeg(a *big.Int) *big.Int    { return new(big.Int).Mod(new(big.Int).Neg(a), fieldP) }
+func feInv(a *big.Int) *big.Int    { return new(big.Int).ModInverse(a, fieldP) }
+func feDiv(a, b *big.Int) *big.Int { return feMul(a, feInv(b)) }
+
+// feSqrt は平方根を返す、平方剰余でない場合はnilを返す
+func feSqrt(a *big.Int) *big.Int {
+	r := new(big.Int).Exp(a, sqrtExp, fieldP)
+	if feMul(r, r).Cmp(new(big.Int).Mod(a, fieldP)) != 0 {
+		return nil
+	}
+	return r
+}
+
+// curveY2 はx^3+7を計算する
+func curveY2(x *big.Int) *big.Int {
+	return feAdd(feMul(feMul(x, x), x), curveB)
+}
+
+// isValidX はxが曲線上の点のX座標になれるか判定する
+func isValidX(x *big.Int) bool {
+	return feSqrt(curveY2(x)) != nil
+}
+
+// feBytes は体の要素を32バイトのビッグエンディアンに変換する
+func feBytes(a *big.Int) []byte {
+	b := make([]byte, 32)
+	a.FillBytes(b)
+	return b
+}
+
+// 秘密鍵を使うスカラー倍は実行時間から秘密鍵が漏れないように定数時間で計算します
+// secp256k1パッケージのスカラー倍は定数時間ではないため、定数時間の体の演算だけを使い、
+// 例外のない完全な加算公式で全てのビットに同じ演算をします
+// https://eprint.iacr.org/2015/1060.pdf
+
+// curveB3 は加算公式で使う3b
+var curveB3 = new(secp256k1.FieldVal).SetInt(21)
+
+// projectivePoint は射影座標(X:Y:Z)の曲線上の点、Zが0の場合は無限遠点
+// 各座標は常に正規化した状態で保持します
+type projectivePoint struct {
+	x, y, z secp256k1.FieldVal
+}
+
+// newProjectivePoint はアフィン座標から点を生成する
+func newProjectivePoint(x, y *big.Int) *projectivePoint {
+	p := &projectivePoint{}
+	p.x.SetByteSlice(feBytes(x))
+	p.y.SetByteSlice(feBytes(y))
+	p.z.SetInt(1)
+	return p
+}
+
+// basePoint は生成元Gを返す
+func basePoint() *projectivePoint {
+	return newProjectivePoint(curveGx, curveGy)
+}
+
+// liftX はX座標から曲線上の点を求める
+func liftX(x *big.Int) (*projectivePoint, bool) {
+	y := feSqrt(curveY2(x))
+	if y == nil {
+		return nil, false
+	}
+	return newProjectivePoint(x, y), true
+}
+
+// fieldAdd などは正規化した値を受け取り、正規化した結果を返す
+func fieldAdd(a, b *secp256k1.FieldVal) (r secp256k1.FieldVal) {
+	r.Add2(a, b).Normalize()
+	return
+}
+
+func fieldSub(a, b *secp256k1.FieldVal) (r secp256k1.FieldVal) {
+	r.NegateVal(b, 1).Add(a).Normalize()
+	return
+}
+
+func fieldMul(a, b *secp256k1.FieldVal) (r secp256k1.FieldVal) {
+	r.Mul2(a, b).Normalize()
+	return
+}
+
+// fieldSelect はbitが1ならa、0ならbを分岐せずに返す
+func fieldSelect(bit uint8, a, b *secp256k1.FieldVal) (r secp256k1.FieldVal) {
+	var t secp256k1.FieldVal
+	r.Set(a).MulInt(bit)
+	t.Set(b).MulInt(1 - bit)
+	r.Add(&t).Normalize()
+	return
+}
+
+// add は2つの点を加算する
+// 同じ点や無限遠点でも同じ手順で計算できる完全な加算公式です(Algorithm 7)
+func (p *projectivePoint) add(q *projectivePoint) *projectivePoint {
+	t0 := fieldMul(&p.x, &q.x)
+	t1 := fieldMul(&p.y, &q.y)
+	t2 := fieldMul(&p.z, &q.z)
+	t3 := fieldAdd(&p.x, &p.y)
+	t4 := fieldAdd(&q.x, &q.y)
+	t3 = fieldMul(&t3, &t4)
+	t4 = fieldAdd(&t0, &t1)
+	t3 = fieldSub(&t3, &t4)
+	t4 = fieldAdd(&p.y, &p.z)
+	x3 := fieldAdd(&q.y, &q.z)
+	t4 = fieldMul(&t4, &x3)
+	x3 = fieldAdd(&t1, &t2)
+	t4 = fieldSub(&t4, &x3)
+	x3 = fieldAdd(&p.x, &p.z)
+	y3 := fieldAdd(&q.x, &q.z)
+	x3 = fieldMul(&x3, &y3)
+	y3 = fieldAdd(&t0, &t2)
+	y3 = fieldSub(&x3, &y3)
+	x3 = fieldAdd(&t0, &t0)
+	t0 = fieldAdd(&x3, &t0)
+	t2 = fieldMul(curveB3, &t2)
+	z3 := fieldAdd(&t1, &t2)
+	t1 = fieldSub(&t1, &t2)
+	y3 = fieldMul(curveB3, &y3)
+	x3 = fieldMul(&t4, &y3)
+	t2 = fieldMul(&t3, &t1)
+	x3 = fieldSub(&t2, &x3)
+	y3 = fieldMul(&y3, &t0)
+	t1 = fieldMul(&t1, &z3)
+	y3 = fieldAdd(&t1, &y3)
+	t0 = fieldMul(&t0, &t3)
+	z3 = fieldMul(&z3, &t4)
+	z3 = fieldAdd(&z3, &t0)
+	return &projectivePoint{x: x3, y: y3, z: z3}
+}
+
+// mul はスカラー倍を定数時間で計算する
+// ビットに関わらず毎回加算して、結果を分岐せずに選びます
+func (p *projectivePoint) mul(k *secp256k1.ModNScalar) *projectivePoint {
+	b := k.Bytes()
+	r := &projectivePoint{}
+	r.y.SetInt(1)
+	for i := 8*len(b) - 1; 0 <= i; i-- {
+		r = r.add(r)
+		t := r.add(p)
+		bit := (b[len(b)-1-i/8] >> uint(i%8)) & 1
+		r = &projectivePoint{
+			x: fieldSelect(bit, &t.x, &r.x),
+			y: fieldSelect(bit, &t.y, &r.y),
+			z: fieldSelect(bit, &t.z, &r.z),
+		}
+	}
+	return r
+}
+
+// affineX はアフィン座標のX座標を返す、無限遠点の場合はnilを返す
+func (p *projectivePoint) affineX() *big.Int {
+	if p.z.IsZero() {
+		return nil
+	}
+	var x secp256k1.FieldVal
+	x.Set(&p.z).Inverse()
+	x.Mul(&p.x).Normalize()
+	return new(big.Int).SetBytes(x.Bytes()[:])
+}
@@ -0,0 +1,70 @@
+package bip324
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+)
+
+// v2のメッセージの形式
+// 1バイト目が0の場合は続く12バイトがコマンド名、それ以外は短縮IDになります
+// https://github.com/bitcoin/bips/blob/master/bip-0324.mediawiki#v2-bitcoin-p2p-message-structure
+
+// commandSize は短縮IDを使わない場合のコマンド名のバイトサイズ
+const commandSize = 12
+
+// shortIDs は短縮IDとコマンド名の対応、インデックスが短縮IDになります
+var shortIDs = []string{
+	"",
+	"addr", "block", "blocktxn", "cmpctblock", "feefilter", "filteradd", "filterclear",
+	"filterload", "getblocks", "getblocktxn", "getdata", "getheaders", "headers", "inv",
+	"mempool", "merkleblock", "notfound", "ping", "pong", "sendcmpct", "tx", "getcfilters",
+	"cfilter", "getcfheaders", "cfheaders", "getcfcheckpt", "cfcheckpt", "addrv2",
+}
+
+// commandShortIDs はコマンド名から短縮IDを引くためのマップ
+var commandShortIDs = map[string]byte{}
+
+func init() {
+	for id, command := range shortIDs[1:] {
+		commandShortIDs[command] = byte(id + 1)
+	}
+}
+
+// EncodeContents はコマンド名とPayloadからパケットの内容を生成します
+// 短縮IDがあるコマンドは1バイトにします
+func EncodeContents(command string, payload []byte) ([]byte, error) {
+	if id, ok := commandShortIDs[command]; ok {
+		return append([]byte{id}, payload...), nil
+	}
+	if len(command) == 0 || commandSize < len(command) {
+		return nil, errors.Errorf("コマンド名の長さが不正です: %q", command)
+	}
+	contents := make([]byte, 1+commandSize+len(payload))
+	copy(contents[1:], command)
+	copy(contents[1+commandSize:], payload)
+	return contents, nil
+}
+
+// DecodeContents はパケットの内容からコマンド名とPayloadを取り出します
+// 未定義の短縮IDは将来追加されたメッセージなのでエラーにせず、コマンド名を空にして返します
+func DecodeContents(contents []byte) (command string, payload []byte, err error) {
+	if len(contents) == 0 {
+		return "", nil, errors.New("パケットの内容が空です")
+	}
+	if id := contents[0]; id != 0 {
+		if int(id) < len(shortIDs) {
+			return shortIDs[id], contents[1:], nil
+		}
+		return "", contents[1:], nil
+	}
+	if len(contents) < 1+commandSize {
+		return "", nil, errors.New("コマンド名が足りません")
+	}
+	name := contents[1 : 1+commandSize]
+	trimmed := bytes.TrimRight(name, "\x00")
+	if bytes.IndexByte(trimmed, 0) != -1 {
+		return "", nil, errors.Errorf("コマンド名が不正です: %q", name)
+	}
+	return string(trimmed), contents[1+commandSize:], nil
+}
@@ -0,0 +1,55 @@
+package bip324
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestContents(t *testing.T) {
+	tests := []struct {
+		command string
+		size    int
+	}{
+		{"addr", 1},
+		{"addrv2", 1},
+		{"version", 1 + commandSize},
+		{"verack", 1 + commandSize},
+	}
+	payload := []byte{1, 2, 3}
+	for _, test := range tests {
+		contents, err := EncodeContents(test.command, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(contents) != test.size+len(payload) {
+			t.Errorf("パケットの内容のサイズが不正です: %s, %d", test.command, len(contents))
+		}
+		command, p, err := DecodeContents(contents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if command != test.command || !bytes.Equal(p, payload) {
+			t.Errorf("デコードした内容が一致しません: %s != %s", command, test.command)
+		}
+	}
+
+	// 未定義の短縮IDはコマンド名を空にして返す
+	command, p, err := DecodeContents([]byte{byte(len(shortIDs)), 1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if command != "" || !bytes.Equal(p, payload) {
+		t.Errorf("未定義の短縮IDのデコードが不正です: %q, %x", command, p)
+	}
+
+	invalids := [][]byte{
+		{},
+		{0, 'p', 'i', 'n', 'g'},
+		append([]byte{0, 'p', 0, 'n', 'g'}, make([]byte, 8)...),
+	}
+	for _, contents := range invalids {
+		if _, _, err := DecodeContents(contents); err == nil {
+			t.Errorf("不正な内容がデコードできました: %x", contents)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package bip324
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// v2の暗号化通信のハンドシェイクとパケットの送受信
+// https://github.com/bitcoin/bips/blob/master/bip-0324.mediawiki#overall-handshake-pseudocode
+
+// MaxGarbageSize は公開鍵の後に送るガベージの最大サイズ
+const MaxGarbageSize = 4095
+
+var (
+	// ErrV1Peer は接続してきたピアがv1のversionメッセージを送ってきた場合のエラー
+	// 読み込んだバイト列はReceivedPrefixで取り出してv1の受信に使います
+	ErrV1Peer = errors.New("ピアはv1のプロトコルで接続しています")
+	// ErrNoV2Support は公開鍵を受け取る前に切断された場合のエラー
+	// 相手がv2に対応していない可能性が高いのでv1で接続し直します
+	ErrNoV2Support = errors.New("ピアがv2のプロトコルに対応していません")
+)
+
+// v1MessageCommand はv1のプロトコルで最初に送られるメッセージのコマンド
+var v1MessageCommand = []byte("version\x00\x00\x00\x00\x00")
+
+// Transport はBIP324の暗号化されたストリームでパケットを送受信する型
+type Transport struct {
+	rw         io.ReadWriter
+	magic      [4]byte
+	initiating bool
+	random     io.Reader
+
+	cipher   *Cipher
+	prefix   []byte
+	received int
+}
+
+// NewTransport はrwの上でv2のプロトコルを使うTransportを生成します
+// 接続を開始した側はinitiatingをtrueにします
+func NewTransport(rw io.ReadWriter, magic [4]byte, initiating bool) *Transport {
+	return &Transport{
+		rw:         rw,
+		magic:      magic,
+		initiating: initiating,
+		random:     defaultRandom,
+	}
+}
+
+// SessionID はハンドシェイクで合意したセッションIDを返します
+// 両方のピアで値が一致すれば中間者がいないことを確認できます
+func (t *Transport) SessionID() [SessionIDSize]byte {
+	if t.cipher == nil {
+		return [SessionIDSize]byte{}
+	}
+	return t.cipher.SessionID
+}
+
+// ReceivedPrefix はErrV1Peerの判定のために読み込んだバイト列を返します
+func (t *Transport) ReceivedPrefix() []byte {
+	return t.prefix
+}
+
+// Handshake は鍵交換をしてバージョンパケットを交換します
+func (t *Transport) Handshake() error {
+	key, err := GeneratePrivateKey(t.random)
+	if err != nil {
+		return err
+	}
+	ours, err := key.EllSwiftCreate(t.random)
+	if err != nil {
+		return err
+	}
+
+	if !t.initiating {
+		if err := t.readPrefix(); err != nil {
+			return err
+		}
+	}
+
+	garbage, err := t.newGarbage()
+	if err != nil {
+		return err
+	}
+	if err := t.write(append(ours[:], garbage...)); err != nil {
+		return err
+	}
+
+	var theirs EllSwiftPubKey
+	copy(theirs[:], t.prefix)
+	if err := t.read(theirs[len(t.prefix):]); err != nil {
+		if t.initiating && t.received < EllSwiftPubKeySize {
+			return errors.Wrap(ErrNoV2Support, err.Error())
+		}
+		return err
+	}
+
+	secret, err := key.V2ECDH(theirs, ours, t.initiating)
+	if err != nil {
+		return err
+	}
+	if t.cipher, err = NewCipher(secret, t.magic, t.initiating); err != nil {
+		return err
+	}
+
+	// ガベージの終わりとバージョンパケットを送る、バージョンパケットでガベージも認証する
+	version, err := t.cipher.Encrypt(nil, garbage, false)
+	if err != nil {
+		return err
+	}
+	if err := t.write(append(t.cipher.SendGarbageTerminator[:], version...)); err != nil {
+		return err
+	}
+
+	receivedGarbage, err := t.readGarbage()
+	if err != nil {
+		return err
+	}
+	// バージョンパケットの内容は今のところ使わない
+	if _, err := t.readPacket(receivedGarbage); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readPrefix は接続してきたピアの最初の16バイトがv1のversionメッセージか確認する
+// 一致しないバイトを読んだ時点で公開鍵の一部として扱います
+func (t *Transport) readPrefix() error {
+	v1Prefix := append(append([]byte{}, t.magic[:]...), v1MessageCommand...)
+	b := make([]byte, 1)
+	for len(t.prefix) < len(v1Prefix) {
+		if err := t.read(b); err != nil {
+			return err
+		}
+		t.prefix = append(t.prefix, b[0])
+		if b[0] != v1Prefix[len(t.prefix)-1] {
+			return nil
+		}
+	}
+	return ErrV1Peer
+}
+
+// newGarbage はランダムな長さのガベージを生成する
+func (t *Transport) newGarbage() ([]byte, error) {
+	var n [2]byte
+	if _, err := io.ReadFull(t.random, n[:]); err != nil {
+		return nil, errors.Wrap(err, "乱数の読み込みに失敗しました")
+	}
+	garbage := make([]byte, int(binary.LittleEndian.Uint16(n[:]))%(MaxGarbageSize+1))
+	if _, err := io.ReadFull(t.random, garbage); err != nil {
+		return nil, errors.Wrap(err, "乱数の読み込みに失敗しました")
+	}
+	return garbage, nil
+}
+
+// readGarbage はガベージの終わりを示すバイト列まで読み込み、ガベージを返す
+func (t *Transport) readGarbage() ([]byte, error) {
+	buf := make([]byte, GarbageTerminatorSize, GarbageTerminatorSize+MaxGarbageSize)
+	if err := t.read(buf); err != nil {
+		return nil, err
+	}
+	b := make([]byte, 1)
+	for !bytes.Equal(buf[len(buf)-GarbageTerminatorSize:], t.cipher.RecvGarbageTerminator[:]) {
+		if len(buf) == GarbageTerminatorSize+MaxGarbageSize {
+			return nil, errors.New("ガベージの終わりが見つかりません")
+		}
+		if err := t.read(b); err != nil {
+			return nil, err
+		}
+		buf = append(buf, b[0])
+	}
+	return buf[:len(buf)-GarbageTerminatorSize], nil
+}
+
+// WritePacket はパケットを暗号化して送信します
+func (t *Transport) WritePacket(contents []byte) error {
+	if t.cipher == nil {
+		return errors.New("ハンドシェイクが完了していません")
+	}
+	packet, err := t.cipher.Encrypt(contents, nil, false)
+	if err != nil {
+		return err
+	}
+	return t.write(packet)
+}
+
+// ReadPacket はパケットを受信して復号します
+// デコイパケットは読み捨てます
+func (t *Transport) ReadPacket() ([]byte, error) {
+	if t.cipher == nil {
+		return nil, errors.New("ハンドシェイクが完了していません")
+	}
+	return t.readPacket(nil)
+}
+
+// readPacket はデコイでないパケットを受信する、aadは最初のパケットの認証にだけ使う
+func (t *Transport) readPacket(aad []byte) ([]byte, error) {
+	for {
+		encLength := make([]byte, LengthFieldSize)
+		if err := t.read(encLength); err != nil {
+			return nil, err
+		}
+		size, err := t.cipher.DecryptLength(encLength)
+		if err != nil {
+			return nil, err
+		}
+		ciphertext := make([]byte, size)
+		if err := t.read(ciphertext); err != nil {
+			return nil, err
+		}
+		contents, ignore, err := t.cipher.Decrypt(ciphertext, aad)
+		if err != nil {
+			return nil, err
+		}
+		aad = nil
+		if !ignore {
+			return contents, nil
+		}
+	}
+}
+
+func (t *Transport) write(b []byte) error {
+	if _, err := t.rw.Write(b); err != nil {
+		return errors.Wrap(err, "送信に失敗しました")
+	}
+	return nil
+}
+
+func (t *Transport) read(b []byte) error {
+	n, err := io.ReadFull(t.rw, b)
+	t.received += n
+	if err != nil {
+		return errors.Wrap(err, "受信に失敗しました")
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package bip324
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+var testMagic = [4]byte{0xf9, 0xbe, 0xb4, 0xd9}
+
+// loopback はTCPのループバックで接続したコネクションの組を返す
+func loopback(t *testing.T) (net.Conn, net.Conn) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	accepted := make(chan net.Conn)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			t.Error(err)
+		}
+		accepted <- conn
+	}()
+	initiator, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return initiator, <-accepted
+}
+
+func TestTransport(t *testing.T) {
+	a, b := loopback(t)
+	defer a.Close()
+	defer b.Close()
+
+	initiator := NewTransport(a, testMagic, true)
+	responder := NewTransport(b, testMagic, false)
+	errs := make(chan error)
+	go func() { errs <- responder.Handshake() }()
+	if err := initiator.Handshake(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if initiator.SessionID() != responder.SessionID() {
+		t.Errorf("セッションIDが一致しません")
+	}
+
+	// 鍵の更新を跨ぐまでパケットを送る
+	for i := 0; i < RekeyInterval+10; i++ {
+		contents := bytes.Repeat([]byte{byte(i)}, i)
+		go func() { errs <- initiator.WritePacket(contents) }()
+		got, err := responder.ReadPacket()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, contents) {
+			t.Fatalf("受信したパケットが一致しません: i=%d", i)
+		}
+	}
+
+	// デコイパケットは読み捨てられる
+	decoy, _ := responder.cipher.Encrypt([]byte("decoy"), nil, true)
+	packet, _ := responder.cipher.Encrypt([]byte("pong"), nil, false)
+	go func() { _, err := b.Write(append(decoy, packet...)); errs <- err }()
+	got, err := initiator.ReadPacket()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "pong" {
+		t.Errorf("デコイパケットが読み捨てられていません: %q", got)
+	}
+}
+
+func TestTransportV1Peer(t *testing.T) {
+	a, b := loopback(t)
+	defer a.Close()
+	defer b.Close()
+
+	header := append(append([]byte{}, testMagic[:]...), "version\x00\x00\x00\x00\x00"...)
+	go a.Write(header)
+
+	responder := NewTransport(b, testMagic, false)
+	if err := responder.Handshake(); err != ErrV1Peer {
+		t.Fatalf("v1のピアが検出されませんでした: %v", err)
+	}
+	if !bytes.Equal(responder.ReceivedPrefix(), header) {
+		t.Errorf("読み込んだバイト列が一致しません: %x", responder.ReceivedPrefix())
+	}
+}
+
+func TestTransportNoV2Support(t *testing.T) {
+	a, b := loopback(t)
+	defer a.Close()
+
+	// v1だけに対応したピアはマジックが一致しないので切断する
+	go func() {
+		buf := make([]byte, 24)
+		b.Read(buf)
+		b.Close()
+	}()
+	initiator := NewTransport(a, testMagic, true)
+	if err := initiator.Handshake(); errors.Cause(err) != ErrNoV2Support {
+		t.Errorf("v2に対応していないピアが検出されませんでした: %v", err)
+	}
+}
@@ -1,14 +1,23 @@
 package p2p
 
 import (
+	"context"
+	"io"
 	"net"
+	"time"
 
 	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/p2p/bip324"
 	"github.com/keiji0/btcwallet/protocol"
 	"github.com/pkg/errors"
 )
 
-type connectionState int
+// v2HandshakeTimeout はBIP324のハンドシェイクを待つ時間
+// 応答しないピアはv2に対応していないものとしてv1で接続し直します
+const v2HandshakeTimeout = 10 * time.Second
+
+// DefaultConnectTimeout はピアへの接続を待つ時間、Bitcoin Coreの-timeoutのデフォルトと同じです
+const DefaultConnectTimeout = 5 * time.Second
 
 // Connection はNodeに接続するためのデータになります
 // TCPソケットを保持し、送信と受信処理を受け持ちます
@@ -16,25 +25,164 @@ type Connection struct {
 	conn    *net.TCPConn
 	addr    net.TCPAddr
 	netType core.NetworkType
+	// ピアと合意したプロトコルのバージョン
+	pver protocol.Version
+	// BIP324の暗号化通信を試すかどうか
+	v2        bool
+	transport Transport
+	// 接続を待つ時間
+	connectTimeout time.Duration
 }
 
 // NewConnection はNodeに接続するためのコネクションを生成します
 func NewConnection(addr *net.TCPAddr, netType core.NetworkType) *Connection {
 	c := &Connection{
-		addr:    *addr,
-		netType: netType,
+		addr:           *addr,
+		netType:        netType,
+		pver:           protocol.CurrentVersion,
+		connectTimeout: DefaultConnectTimeout,
 	}
 	return c
 }
 
+// NewInboundConnection は接続してきたピアのコネクションを生成します
+// ピアがBIP324のv2で接続してきた場合は暗号化通信のハンドシェイクをします
+func NewInboundConnection(ctx context.Context, conn *net.TCPConn, netType core.NetworkType) (*Connection, error) {
+	c := &Connection{
+		conn:    conn,
+		netType: netType,
+		pver:    protocol.CurrentVersion,
+	}
+	if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+		c.addr = *addr
+	}
+	stop := closeOnCancel(ctx, conn)
+	conn.SetDeadline(time.Now().Add(v2HandshakeTimeout))
+	transport, err := AcceptTransport(conn, netType)
+	conn.SetDeadline(time.Time{})
+	if stop() {
+		return nil, errors.Wrap(ctx.Err(), "ハンドシェイクを中断しました")
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.transport = transport
+	return c, nil
+}
+
+// EnableV2 はBIP324の暗号化通信を使うかどうかを設定します
+// Connectの前に呼び出します
+func (c *Connection) EnableV2(enabled bool) {
+	c.v2 = enabled
+}
+
+// SetConnectTimeout は接続を待つ時間を設定します
+// Connectの前に呼び出します
+func (c *Connection) SetConnectTimeout(timeout time.Duration) {
+	c.connectTimeout = timeout
+}
+
+// IsV2 はBIP324の暗号化通信で接続しているかどうかを返します
+func (c *Connection) IsV2() bool {
+	_, ok := c.transport.(*v2Transport)
+	return ok
+}
+
 // Connect はノードに接続します
-func (c *Connection) Connect() (err error) {
-	if c.conn, err = net.DialTCP("tcp", nil, &c.addr); err != nil {
+// v2が有効な場合はBIP324の暗号化通信を試し、ピアが対応していなければv1で接続し直します
+// ctxがキャンセルされると接続やハンドシェイクを中断します
+func (c *Connection) Connect(ctx context.Context) (err error) {
+	if c.conn, err = c.dial(ctx); err != nil {
+		return err
+	}
+	if !c.v2 {
+		c.transport = NewV1Transport(c.conn, c.netType)
+		return nil
+	}
+
+	stop := closeOnCancel(ctx, c.conn)
+	c.conn.SetDeadline(time.Now().Add(v2HandshakeTimeout))
+	c.transport, err = NewV2Transport(c.conn, c.netType, true)
+	c.conn.SetDeadline(time.Time{})
+	if stop() {
+		return errors.Wrap(ctx.Err(), "ハンドシェイクを中断しました")
+	}
+	if errors.Cause(err) == bip324.ErrNoV2Support {
+		c.conn.Close()
+		if c.conn, err = c.dial(ctx); err != nil {
+			return err
+		}
+		c.transport = NewV1Transport(c.conn, c.netType)
+		return nil
+	} else if err != nil {
+		c.conn.Close()
 		return err
 	}
 	return nil
 }
 
+// dial はタイムアウトを付けてピアに接続する
+// 応答しないアドレスで待ち続けないようにします
+func (c *Connection) dial(ctx context.Context) (*net.TCPConn, error) {
+	dialer := net.Dialer{Timeout: c.connectTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "接続に失敗しました: %s", c.addr.String())
+	}
+	return conn.(*net.TCPConn), nil
+}
+
+// closeOnCancel はctxがキャンセルされたらconnを閉じて、読み書きで止まっている処理を起こす
+// 返した関数で監視をやめ、キャンセルで閉じた場合はtrueを返します
+func closeOnCancel(ctx context.Context, conn io.Closer) (stop func() bool) {
+	done := make(chan struct{})
+	closed := make(chan bool, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			closed <- true
+		case <-done:
+			closed <- false
+		}
+	}()
+	return func() bool {
+		close(done)
+		return <-closed
+	}
+}
+
+// Addr はピアのアドレスを返します
+func (c *Connection) Addr() *net.TCPAddr {
+	addr := c.addr
+	return &addr
+}
+
+// SetDeadline は送受信のタイムアウトの時刻を設定します
+// ゼロ値を指定するとタイムアウトしなくなります
+func (c *Connection) SetDeadline(t time.Time) error {
+	if err := c.conn.SetDeadline(t); err != nil {
+		return errors.Wrap(err, "タイムアウトの設定に失敗しました")
+	}
+	return nil
+}
+
+// SetWriteDeadline は送信のタイムアウトの時刻を設定します
+func (c *Connection) SetWriteDeadline(t time.Time) error {
+	if err := c.conn.SetWriteDeadline(t); err != nil {
+		return errors.Wrap(err, "タイムアウトの設定に失敗しました")
+	}
+	return nil
+}
+
+// CloseWrite は送信側だけを閉じます、ピアには送り終えたことがEOFで伝わります
+func (c *Connection) CloseWrite() error {
+	if err := c.conn.CloseWrite(); err != nil {
+		return errors.Wrap(err, "コネクションを閉じるのに失敗しました")
+	}
+	return nil
+}
+
 // Close はノードに閉じます
 func (c *Connection) Close() (err error) {
 	if err := c.conn.Close(); err != nil {
@@ -43,9 +191,19 @@ func (c *Connection) Close() (err error) {
 	return nil
 }
 
+// ProtocolVersion はピアと合意したプロトコルのバージョンを返します
+func (c *Connection) ProtocolVersion() protocol.Version {
+	return c.pver
+}
+
+// NegotiateVersion はピアから受け取ったversionのバージョンと合意し、以降の送受信に使います
+func (c *Connection) NegotiateVersion(remote protocol.Version) {
+	c.pver = protocol.NegotiateVersion(c.pver, remote)
+}
+
 // Send はコネクションに対してメッセージを送ります
 func (c *Connection) Send(msg protocol.Message) error {
-	if err := protocol.Send(c.conn, c.netType, msg); err != nil {
+	if err := c.transport.WriteMessage(msg, c.pver); err != nil {
 		return err
 	}
 	return nil
@@ -53,7 +211,7 @@ func (c *Connection) Send(msg protocol.Message) error {
 
 // Receive はコネクションからメッセージを受け取ります
 func (c *Connection) Receive() (protocol.Message, error) {
-	msg, err := protocol.Receive(c.conn)
+	msg, err := c.transport.ReadMessage(c.pver)
 	if err != nil {
 		return nil, err
 	}
@@ -1,13 +1,16 @@
 package p2p
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/keiji0/btcwallet/core"
 	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
 )
 
 func TestConnection(t *testing.T) {
@@ -17,7 +20,7 @@ func TestConnection(t *testing.T) {
 		log.Fatalln(err)
 	}
 	conn := NewConnection(addr, core.MainNetwork)
-	if err := conn.Connect(); err != nil {
+	if err := conn.Connect(context.Background()); err != nil {
 		log.Fatalln(err)
 	}
 	defer conn.Close()
@@ -38,11 +41,39 @@ func TestConnection(t *testing.T) {
 		}
 	}
 
-	{
+	// versionとverackを受け取るまで待つ
+	for gotVersion, gotVerAck := false, false; !gotVersion || !gotVerAck; {
 		msg, err := conn.Receive()
 		if err != nil {
 			log.Fatalln(err)
 		}
 		fmt.Printf("%v\n", msg)
+
+		switch m := msg.(type) {
+		case *protocol.MsgVersion:
+			gotVersion = true
+			conn.NegotiateVersion(m.ProtocolVersion)
+			if err := conn.Send(&protocol.MsgVerAck{}); err != nil {
+				log.Fatalln(err)
+			}
+		case *protocol.MsgVerAck:
+			gotVerAck = true
+		case *protocol.MsgPing:
+			if err := conn.Send(protocol.NewMsgPong(m.Nonce)); err != nil {
+				log.Fatalln(err)
+			}
+		}
+	}
+}
+
+func TestConnectionConnectTimeout(t *testing.T) {
+	addr, closeListener := serve(t, func(conn net.Conn) { conn.Close() })
+	defer closeListener()
+
+	conn := NewConnection(addr, core.MainNetwork)
+	conn.SetConnectTimeout(time.Nanosecond)
+	err := conn.Connect(context.Background())
+	if netErr, ok := errors.Cause(err).(net.Error); !ok || !netErr.Timeout() {
+		t.Errorf("タイムアウトしていません: %v", err)
 	}
 }
@@ -0,0 +1,54 @@
+package p2p
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/keiji0/btcwallet/util/hash"
+	"github.com/pkg/errors"
+)
+
+// ピアやBANの一覧を保存するファイル
+// 内容の後ろにsha256dの先頭4バイトのチェックサムを付けます
+
+// checksumSize はファイルの末尾のチェックサムのバイト数
+const checksumSize = 4
+
+// writeChecksumFile はチェックサムを付けたdataをpathに書き込む
+// 書き込みの途中で終了しても壊れないように一時ファイルに書いてから置き換えます
+func writeChecksumFile(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrapf(err, "ファイルの作成に失敗しました: %s", path)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, hash.Sha256x2(data)[:checksumSize]...)); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "ファイルの書き込みに失敗しました: %s", path)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "ファイルの書き込みに失敗しました: %s", path)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrapf(err, "ファイルの置き換えに失敗しました: %s", path)
+	}
+	return nil
+}
+
+// readChecksumFile はwriteChecksumFileで書き込んだファイルを読み込んでチェックサムを確かめる
+func readChecksumFile(path string) ([]byte, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "ファイルの読み込みに失敗しました: %s", path)
+	}
+	if len(b) < checksumSize {
+		return nil, errors.Errorf("ファイルが壊れています: %s", path)
+	}
+	body, checksum := b[:len(b)-checksumSize], b[len(b)-checksumSize:]
+	if !bytes.Equal(hash.Sha256x2(body)[:checksumSize], checksum) {
+		return nil, errors.Errorf("ファイルのチェックサムが一致しません: %s", path)
+	}
+	return body, nil
+}
@@ -0,0 +1,110 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	mrand "math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// DNSシードからの接続先の取得
+// DNSシードはAレコードとAAAAレコードで接続できるノードのアドレスを返します
+// https://github.com/bitcoin/bitcoin/blob/master/doc/dnsseed-policy.md
+
+// DefaultSeedTimeout はDNSシードの問い合わせを待つ時間
+const DefaultSeedTimeout = 30 * time.Second
+
+// ErrNoSeedAddress はDNSシードからも固定のノードからもアドレスが見つからない場合のエラー
+var ErrNoSeedAddress = errors.New("接続先のアドレスが見つかりません")
+
+// Resolver はホスト名からIPアドレスを引くインターフェース
+// net.Resolverがそのまま使えます
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// SeedHost はサービスで絞り込むサブドメインを付けたDNSシードのホスト名を返します
+// 絞り込めないシードやservicesが0の場合はそのままのホスト名を返します
+func SeedHost(seed protocol.DNSSeed, services protocol.ServiceFlags) string {
+	if !seed.HasFiltering || services == 0 {
+		return seed.Host
+	}
+	return fmt.Sprintf("x%x.%s", uint64(services), seed.Host)
+}
+
+// SeedAddrManager はDNSシードに問い合わせてアドレスをAddrManagerに追加し、追加した数を返します
+// DNSシードからアドレスが見つからない場合はparams.SeedNodesを追加します
+func SeedAddrManager(ctx context.Context, am *AddrManager, resolver Resolver, params *protocol.NetParams, services protocol.ServiceFlags) (int, error) {
+	var mtx sync.Mutex
+	var wg sync.WaitGroup
+	n := 0
+	for _, seed := range params.DNSSeeds {
+		wg.Add(1)
+		go func(seed protocol.DNSSeed) {
+			defer wg.Done()
+			addrs, err := lookupSeed(ctx, resolver, SeedHost(seed, services), params.DefaultPort, services)
+			if err != nil || len(addrs) == 0 {
+				return
+			}
+			// Bitcoin Coreと同じようにシードのホスト名から作る内部用のアドレスを送信元にして、
+			// 問い合わせるたびに同じシードのアドレスが同じバケットにまとまるようにする
+			added := am.Add(addrs, seedSource(seed), 0)
+			mtx.Lock()
+			n += added
+			mtx.Unlock()
+		}(seed)
+	}
+	wg.Wait()
+	if n != 0 {
+		return n, nil
+	}
+
+	addrs := seedNodeAddresses(params)
+	for _, addr := range addrs {
+		n += am.Add([]*protocol.NetAddressV2{addr}, addr, 0)
+	}
+	if n == 0 {
+		return 0, ErrNoSeedAddress
+	}
+	return n, nil
+}
+
+// seedSource はDNSシードから得たアドレスの送信元にするアドレスを返す
+func seedSource(seed protocol.DNSSeed) *protocol.NetAddressV2 {
+	return protocol.NewNetAddressV2FromIP(internalAddress(seed.Host), 0, 0)
+}
+
+// lookupSeed はDNSシードに問い合わせて、返ってきたアドレスを数日前に接続できたアドレスとして返す
+func lookupSeed(ctx context.Context, resolver Resolver, host string, port protocol.NetPort, services protocol.ServiceFlags) ([]*protocol.NetAddressV2, error) {
+	ips, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "DNSシードの問い合わせに失敗しました: %s", host)
+	}
+	addrs := make([]*protocol.NetAddressV2, 0, len(ips))
+	for _, ip := range ips {
+		addr := protocol.NewNetAddressV2FromIP(ip.IP, port, services)
+		// シードが返すアドレスは最近接続できたとは限らないので3日から7日前にする
+		addr.Timestamp = protocol.Uint32Time(time.Now().Add(-3*24*time.Hour - time.Duration(mrand.Int63n(int64(4*24*time.Hour)))))
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// seedNodeAddresses は固定のノードのアドレスを1週間から2週間前に接続できたアドレスとして返す
+func seedNodeAddresses(params *protocol.NetParams) []*protocol.NetAddressV2 {
+	addrs := make([]*protocol.NetAddressV2, 0, len(params.SeedNodes))
+	for _, node := range params.SeedNodes {
+		addr, err := protocol.NewNetAddressV2FromString(node, protocol.NodeNetwork)
+		if err != nil {
+			continue
+		}
+		addr.Timestamp = protocol.Uint32Time(time.Now().Add(-7*24*time.Hour - time.Duration(mrand.Int63n(int64(7*24*time.Hour)))))
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
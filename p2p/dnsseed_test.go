@@ -0,0 +1,324 @@
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// fakeDNS はAとAAAAの問い合わせにだけ答えるDNSサーバー
+type fakeDNS struct {
+	conn    net.PacketConn
+	records map[string][]net.IP
+
+	mtx     sync.Mutex
+	queries map[string]bool
+}
+
+const (
+	dnsTypeA    = 1
+	dnsTypeAAAA = 28
+)
+
+// startFakeDNS はループバックのUDPでDNSサーバーを起動する
+func startFakeDNS(t *testing.T, records map[string][]net.IP) *fakeDNS {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeDNS{conn: conn, records: records, queries: map[string]bool{}}
+	go s.serve()
+	return s
+}
+
+func (s *fakeDNS) Close() {
+	s.conn.Close()
+}
+
+// resolver はこのサーバーに問い合わせるnet.Resolverを返す
+func (s *fakeDNS) resolver() *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", s.conn.LocalAddr().String())
+		},
+	}
+}
+
+// setRecords は名前に対するアドレスを設定する
+func (s *fakeDNS) setRecords(name string, ips []net.IP) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.records[name] = ips
+}
+
+func (s *fakeDNS) queried(name string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.queries[name]
+}
+
+func (s *fakeDNS) serve() {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if resp := s.answer(buf[:n]); resp != nil {
+			s.conn.WriteTo(resp, addr)
+		}
+	}
+}
+
+// answer は問い合わせのメッセージに対する応答を作る
+func (s *fakeDNS) answer(query []byte) []byte {
+	if len(query) < 12 {
+		return nil
+	}
+	// 質問の名前を読む
+	var labels []string
+	off := 12
+	for off < len(query) && query[off] != 0 {
+		size := int(query[off])
+		if len(query) < off+1+size {
+			return nil
+		}
+		labels = append(labels, string(query[off+1:off+1+size]))
+		off += 1 + size
+	}
+	off++
+	if len(query) < off+4 {
+		return nil
+	}
+	qtype := binary.BigEndian.Uint16(query[off:])
+	off += 4
+	name := strings.ToLower(strings.Join(labels, "."))
+
+	s.mtx.Lock()
+	s.queries[name] = true
+	s.mtx.Unlock()
+
+	s.mtx.Lock()
+	ips, ok := s.records[name]
+	s.mtx.Unlock()
+	var answers [][]byte
+	for _, ip := range ips {
+		if ip4 := ip.To4(); ip4 != nil && qtype == dnsTypeA {
+			answers = append(answers, ip4)
+		} else if ip4 == nil && qtype == dnsTypeAAAA {
+			answers = append(answers, ip.To16())
+		}
+	}
+
+	resp := make([]byte, 12, 512)
+	copy(resp, query[:2])
+	flags := uint16(0x8180)
+	if !ok {
+		// NXDOMAIN
+		flags |= 3
+	}
+	binary.BigEndian.PutUint16(resp[2:], flags)
+	binary.BigEndian.PutUint16(resp[4:], 1)
+	binary.BigEndian.PutUint16(resp[6:], uint16(len(answers)))
+	resp = append(resp, query[12:off]...)
+	for _, rdata := range answers {
+		rr := []byte{0xc0, 0x0c, 0, 0, 0, 1, 0, 0, 0, 60, 0, 0}
+		typ := uint16(dnsTypeA)
+		if len(rdata) == net.IPv6len {
+			typ = dnsTypeAAAA
+		}
+		binary.BigEndian.PutUint16(rr[2:], typ)
+		binary.BigEndian.PutUint16(rr[10:], uint16(len(rdata)))
+		resp = append(append(resp, rr...), rdata...)
+	}
+	return resp
+}
+
+func TestSeedHost(t *testing.T) {
+	seed := protocol.DNSSeed{Host: "seed.example.com", HasFiltering: true}
+	if host := SeedHost(seed, protocol.NodeNetwork|protocol.NodeWitness); host != "x9.seed.example.com" {
+		t.Errorf("ホスト名が一致しません: %s", host)
+	}
+	if host := SeedHost(seed, 0); host != "seed.example.com" {
+		t.Errorf("ホスト名が一致しません: %s", host)
+	}
+	seed.HasFiltering = false
+	if host := SeedHost(seed, protocol.NodeNetwork); host != "seed.example.com" {
+		t.Errorf("ホスト名が一致しません: %s", host)
+	}
+}
+
+func TestSeedAddrManager(t *testing.T) {
+	dns := startFakeDNS(t, map[string][]net.IP{
+		"x9.seed.example.com": {net.ParseIP("1.2.3.4"), net.ParseIP("5.6.7.8"), net.ParseIP("2001:db8::1")},
+		"seed2.example.com":   {net.ParseIP("9.9.9.9")},
+	})
+	defer dns.Close()
+
+	params := &protocol.NetParams{
+		DefaultPort: 18333,
+		DNSSeeds: []protocol.DNSSeed{
+			{Host: "seed.example.com", HasFiltering: true},
+			{Host: "seed2.example.com", HasFiltering: false},
+			{Host: "missing.example.com", HasFiltering: false},
+		},
+		SeedNodes: []string{"10.0.0.1:18333"},
+	}
+	am, err := NewAddrManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	n, err := SeedAddrManager(ctx, am, dns.resolver(), params, protocol.NodeNetwork|protocol.NodeWitness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 4 || am.Size() != 4 {
+		t.Errorf("追加した数が一致しません: %d %d", n, am.Size())
+	}
+	if !dns.queried("x9.seed.example.com") || dns.queried("seed.example.com") {
+		t.Error("サービスで絞り込むサブドメインに問い合わせていません")
+	}
+	for key, ka := range am.addrs {
+		if ka.addr.Port != params.DefaultPort || !ka.addr.Services.Has(protocol.NodeWitness) {
+			t.Errorf("アドレスが一致しません: %s %v", key, ka.addr.Services)
+		}
+	}
+}
+
+func TestSeedAddrManagerFallback(t *testing.T) {
+	dns := startFakeDNS(t, map[string][]net.IP{})
+	defer dns.Close()
+
+	params := &protocol.NetParams{
+		DefaultPort: 8333,
+		DNSSeeds:    []protocol.DNSSeed{{Host: "seed.example.com", HasFiltering: true}},
+		SeedNodes:   []string{"1.2.3.4:8333", "[2001:db8::1]:8333", "invalid"},
+	}
+	am, err := NewAddrManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if n, err := SeedAddrManager(ctx, am, dns.resolver(), params, protocol.NodeNetwork); err != nil || n != 2 {
+		t.Errorf("固定のノードが追加されません: %d %v", n, err)
+	}
+
+	params.SeedNodes = nil
+	am, err = NewAddrManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SeedAddrManager(ctx, am, dns.resolver(), params, protocol.NodeNetwork); errors.Cause(err) != ErrNoSeedAddress {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+}
+
+func TestManagerDNSSeed(t *testing.T) {
+	params, err := protocol.NetworkParams(core.TestNetwork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := map[string][]net.IP{}
+	for i, seed := range params.DNSSeeds {
+		records[SeedHost(seed, DefaultSeedServices)] = []net.IP{net.IPv4(1, byte(i), 1, 1)}
+	}
+	dns := startFakeDNS(t, records)
+	defer dns.Close()
+
+	am, err := NewAddrManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(ManagerConfig{
+		NetType:     core.TestNetwork,
+		Peer:        PeerConfig{Nonces: NewNonceSet()},
+		AddrManager: am,
+		Resolver:    dns.resolver(),
+		// 見つかったアドレスには接続しない
+		GetAddress: func() *net.TCPAddr { return nil },
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+	waitFor(t, "DNSシードのアドレスの数", func() bool { return am.Size() == len(params.DNSSeeds) })
+}
+
+func TestSeedSource(t *testing.T) {
+	seed := protocol.DNSSeed{Host: "seed.example.com", HasFiltering: true}
+	source := seedSource(seed)
+	if source.String() != seedSource(seed).String() {
+		t.Error("同じシードの送信元が一致しません")
+	}
+	other := seedSource(protocol.DNSSeed{Host: "seed2.example.com"})
+	if addrGroup(source) == addrGroup(other) {
+		t.Errorf("別のシードの送信元が同じグループです: %s", addrGroup(source))
+	}
+
+	// 送信元のアドレスはピアから教えてもらっても追加しない
+	am, err := NewAddrManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if am.Add([]*protocol.NetAddressV2{source}, other, 0) != 0 {
+		t.Error("内部用のアドレスが追加されました")
+	}
+
+	// 問い合わせ直しても同じシードのアドレスは同じバケットに入る
+	addr := protocol.NewNetAddressV2FromIP(net.ParseIP("1.2.3.4"), 8333, protocol.NodeNetwork)
+	if am.newBucket(addr, source) != am.newBucket(addr, seedSource(seed)) {
+		t.Error("同じシードのアドレスのバケットが一致しません")
+	}
+}
+
+func TestManagerDNSSeedRetry(t *testing.T) {
+	// 最初は何も返さないDNSシード
+	dns := startFakeDNS(t, map[string][]net.IP{})
+	defer dns.Close()
+
+	am, err := NewAddrManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(ManagerConfig{
+		NetType:           core.TestNetwork,
+		Peer:              PeerConfig{Nonces: NewNonceSet()},
+		AddrManager:       am,
+		Resolver:          dns.resolver(),
+		DisableDNSSeed:    true,
+		SeedRetryInterval: 10 * time.Millisecond,
+		GetAddress:        func() *net.TCPAddr { return nil },
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	// 固定のシードノードがないネットワーク
+	params := &protocol.NetParams{
+		DefaultPort: 18333,
+		DNSSeeds:    []protocol.DNSSeed{{Host: "seed.example.com"}},
+	}
+	m.wg.Add(1)
+	go m.seed(params)
+
+	waitFor(t, "DNSシードへの問い合わせ", func() bool { return dns.queried("seed.example.com") })
+	if am.Size() != 0 {
+		t.Fatalf("アドレスの数が一致しません: %d", am.Size())
+	}
+	dns.setRecords("seed.example.com", []net.IP{net.ParseIP("1.2.3.4")})
+	waitFor(t, "問い合わせ直したアドレスの追加", func() bool { return am.Size() == 1 })
+}
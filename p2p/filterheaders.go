@@ -0,0 +1,191 @@
+package p2p
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// 複数のピアから受け取ったフィルタヘッダーを突き合わせて嘘をついているピアを見つけます
+// https://github.com/bitcoin/bips/blob/master/bip-0157.mediawiki#client-operation
+//
+// 1. 複数のピアからcfcheckptを受け取り、食い違うチェックポイントを探す
+// 2. 食い違ったチェックポイントの区間のcfheadersを各ピアから受け取り、最初に食い違う高さを探す
+// 3. その高さのブロックと各ピアのcfilterを受け取り、ブロックと一致しないフィルタを送ったピアを嘘つきとする
+
+// FilterHeaderVerifier はピアごとのフィルタヘッダーを保持して比較する型
+type FilterHeaderVerifier struct {
+	mtx sync.Mutex
+	// ピアごとの高さとフィルタヘッダーの対応
+	headers map[string]map[uint32]protocol.Hash
+}
+
+// NewFilterHeaderVerifier はFilterHeaderVerifierを生成します
+func NewFilterHeaderVerifier() *FilterHeaderVerifier {
+	return &FilterHeaderVerifier{
+		headers: map[string]map[uint32]protocol.Hash{},
+	}
+}
+
+// AddCFCheckpt はピアから受け取ったcfcheckptのフィルタヘッダーを記録します
+func (v *FilterHeaderVerifier) AddCFCheckpt(peer string, msg *protocol.MsgCFCheckpt) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	for i, header := range msg.FilterHeaders {
+		v.set(peer, uint32(i+1)*protocol.CFCheckptInterval, header)
+	}
+}
+
+// AddCFHeaders はピアから受け取ったcfheadersからフィルタヘッダーを計算して記録します
+// startHeightはgetcfheadersで要求した開始の高さです
+// 既に記録しているフィルタヘッダーと繋がらない場合は、ピア自身が矛盾しているのでエラーになります
+func (v *FilterHeaderVerifier) AddCFHeaders(peer string, startHeight uint32, msg *protocol.MsgCFHeaders) error {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	if startHeight == 0 && msg.PrevFilterHeader != (protocol.Hash{}) {
+		return errors.Errorf("ジェネシスブロックの前のフィルタヘッダーが0ではありません: peer=%s", peer)
+	}
+	headers := msg.FilterHeaders()
+	if 0 < startHeight {
+		if err := v.check(peer, startHeight-1, msg.PrevFilterHeader); err != nil {
+			return err
+		}
+	}
+	for i, header := range headers {
+		if err := v.check(peer, startHeight+uint32(i), header); err != nil {
+			return err
+		}
+	}
+
+	if 0 < startHeight {
+		v.set(peer, startHeight-1, msg.PrevFilterHeader)
+	}
+	for i, header := range headers {
+		v.set(peer, startHeight+uint32(i), header)
+	}
+	return nil
+}
+
+// FindConflict は複数のピアでフィルタヘッダーが食い違う最も低い高さを返します
+// 食い違いがない場合はfalseを返します
+func (v *FilterHeaderVerifier) FindConflict() (uint32, bool) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	byHeight := map[uint32]map[protocol.Hash]bool{}
+	for _, headers := range v.headers {
+		for height, header := range headers {
+			if byHeight[height] == nil {
+				byHeight[height] = map[protocol.Hash]bool{}
+			}
+			byHeight[height][header] = true
+		}
+	}
+	var conflicts []uint32
+	for height, set := range byHeight {
+		if 1 < len(set) {
+			conflicts = append(conflicts, height)
+		}
+	}
+	if len(conflicts) == 0 {
+		return 0, false
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i] < conflicts[j] })
+	return conflicts[0], true
+}
+
+// ResolveConflict はheightのブロックと各ピアから受け取ったcfilterから嘘をついているピアを返します
+// フィルタがピアのフィルタヘッダーと繋がらないか、ブロックの出力スクリプトを含まない場合に嘘と判定します
+// 各ピアのheightとその1つ前のフィルタヘッダーが記録されている必要があります
+func (v *FilterHeaderVerifier) ResolveConflict(height uint32, block *protocol.Block, filters map[string]*protocol.MsgCFilter) ([]string, error) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	scripts := filterOutputScripts(block)
+	key := protocol.BasicFilterKey(block.BlockHash())
+
+	var liars []string
+	for peer, msg := range filters {
+		header, ok := v.headers[peer][height]
+		if !ok {
+			return nil, errors.Errorf("フィルタヘッダーが記録されていません: peer=%s, height=%d", peer, height)
+		}
+		var prev protocol.Hash
+		if 0 < height {
+			if prev, ok = v.headers[peer][height-1]; !ok {
+				return nil, errors.Errorf("前のフィルタヘッダーが記録されていません、cfheadersを要求してください: peer=%s, height=%d", peer, height-1)
+			}
+		}
+		if !verifyFilter(msg, block.BlockHash(), prev, header, key, scripts) {
+			liars = append(liars, peer)
+		}
+	}
+	sort.Strings(liars)
+
+	// 嘘をついたピアのフィルタヘッダーは以降の比較に使わない
+	for _, peer := range liars {
+		delete(v.headers, peer)
+	}
+	return liars, nil
+}
+
+// RemovePeer はピアのフィルタヘッダーを破棄します
+func (v *FilterHeaderVerifier) RemovePeer(peer string) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	delete(v.headers, peer)
+}
+
+func (v *FilterHeaderVerifier) set(peer string, height uint32, header protocol.Hash) {
+	if v.headers[peer] == nil {
+		v.headers[peer] = map[uint32]protocol.Hash{}
+	}
+	v.headers[peer][height] = header
+}
+
+// check はピアが以前に送ったフィルタヘッダーと矛盾していないか確認する
+func (v *FilterHeaderVerifier) check(peer string, height uint32, header protocol.Hash) error {
+	if known, ok := v.headers[peer][height]; ok && known != header {
+		return errors.Errorf("以前に受け取ったフィルタヘッダーと一致しません: peer=%s, height=%d", peer, height)
+	}
+	return nil
+}
+
+// verifyFilter はピアのフィルタがフィルタヘッダーと繋がり、ブロックの出力スクリプトを全て含むか検証する
+func verifyFilter(msg *protocol.MsgCFilter, blockHash, prev, header protocol.Hash, key [16]byte, scripts [][]byte) bool {
+	if msg.FilterType != protocol.FilterTypeBasic || msg.BlockHash != blockHash {
+		return false
+	}
+	filter, err := msg.GCSFilter()
+	if err != nil {
+		return false
+	}
+	if protocol.FilterHeader(filter.Hash(), prev) != header {
+		return false
+	}
+	for _, script := range scripts {
+		if ok, err := filter.Match(key, script); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// filterOutputScripts はブロックのうち基本フィルタに含まれるはずの出力スクリプトを返す
+// 使用した出力のスクリプトはブロックだけでは分からないので検証には使いません
+func filterOutputScripts(block *protocol.Block) [][]byte {
+	var scripts [][]byte
+	for _, tx := range block.Transactions {
+		for _, out := range tx.TxOut {
+			if len(out.PkScript) == 0 || core.OpCode(out.PkScript[0]) == core.OpReturn {
+				continue
+			}
+			scripts = append(scripts, out.PkScript)
+		}
+	}
+	return scripts
+}
@@ -0,0 +1,99 @@
+package p2p
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keiji0/btcwallet/protocol"
+)
+
+// testFilterChain はブロックと基本フィルタの一覧を生成する
+func testFilterChain(t *testing.T, count int) ([]*protocol.Block, []*protocol.GCSFilter) {
+	var blocks []*protocol.Block
+	var filters []*protocol.GCSFilter
+	var prev protocol.Hash
+	for i := 0; i < count; i++ {
+		tx := protocol.NewTx()
+		tx.AddTxIn(&protocol.TxIn{PreviousOutPoint: protocol.OutPoint{Index: 0xffffffff}, SignatureScript: []byte{byte(i)}})
+		tx.AddTxOut(&protocol.TxOut{Value: 5000000000, PkScript: []byte{0x51, byte(i)}})
+		block := &protocol.Block{
+			Header:       protocol.BlockHeader{Version: 4, PrevBlock: prev, MerkleRoot: tx.TxHash()},
+			Transactions: []*protocol.Tx{tx},
+		}
+		filter, err := protocol.NewBasicFilter(block, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, block)
+		filters = append(filters, filter)
+		prev = block.BlockHash()
+	}
+	return blocks, filters
+}
+
+func TestFilterHeaderVerifier(t *testing.T) {
+	blocks, filters := testFilterChain(t, 4)
+	honest := make([]protocol.Hash, len(filters))
+	for i, f := range filters {
+		honest[i] = f.Hash()
+	}
+
+	// 嘘つきのピアは高さ2のフィルタから出力スクリプトを取り除く
+	key := protocol.BasicFilterKey(blocks[2].BlockHash())
+	fake, err := protocol.NewGCSFilter(protocol.BasicFilterP, protocol.BasicFilterM, key, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lying := append([]protocol.Hash{}, honest...)
+	lying[2] = fake.Hash()
+
+	v := NewFilterHeaderVerifier()
+	for peer, hashes := range map[string][]protocol.Hash{"a": honest, "b": honest, "c": lying} {
+		msg := protocol.NewMsgCFHeaders(protocol.FilterTypeBasic, blocks[3].BlockHash(), protocol.Hash{}, hashes)
+		if err := v.AddCFHeaders(peer, 0, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	height, ok := v.FindConflict()
+	if !ok || height != 2 {
+		t.Fatalf("食い違う高さが一致しません: %d, %v", height, ok)
+	}
+
+	hash := blocks[2].BlockHash()
+	cfilters := map[string]*protocol.MsgCFilter{
+		"a": protocol.NewMsgCFilter(protocol.FilterTypeBasic, hash, filters[2].Bytes()),
+		"b": protocol.NewMsgCFilter(protocol.FilterTypeBasic, hash, filters[2].Bytes()),
+		"c": protocol.NewMsgCFilter(protocol.FilterTypeBasic, hash, fake.Bytes()),
+	}
+	liars, err := v.ResolveConflict(height, blocks[2], cfilters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(liars, []string{"c"}) {
+		t.Errorf("嘘をついているピアが一致しません: %v", liars)
+	}
+	if _, ok := v.FindConflict(); ok {
+		t.Errorf("嘘をついたピアを除いても食い違いが残っています")
+	}
+
+	// 以前に送ったものと矛盾するcfheadersはエラーになる
+	msg := protocol.NewMsgCFHeaders(protocol.FilterTypeBasic, blocks[3].BlockHash(), honest[0], lying[2:])
+	if err := v.AddCFHeaders("a", 2, msg); err == nil {
+		t.Errorf("矛盾するフィルタヘッダーが記録できました")
+	}
+}
+
+func TestFilterHeaderVerifierCheckpoint(t *testing.T) {
+	v := NewFilterHeaderVerifier()
+	v.AddCFCheckpt("a", protocol.NewMsgCFCheckpt(protocol.FilterTypeBasic, protocol.Hash{}, []protocol.Hash{{0x01}, {0x02}}))
+	v.AddCFCheckpt("b", protocol.NewMsgCFCheckpt(protocol.FilterTypeBasic, protocol.Hash{}, []protocol.Hash{{0x01}, {0x03}}))
+	if height, ok := v.FindConflict(); !ok || height != 2*protocol.CFCheckptInterval {
+		t.Errorf("食い違うチェックポイントが一致しません: %d, %v", height, ok)
+	}
+
+	// 前のフィルタヘッダーがないと判定できない
+	if _, err := v.ResolveConflict(2*protocol.CFCheckptInterval, &protocol.Block{}, map[string]*protocol.MsgCFilter{"a": {}}); err == nil {
+		t.Errorf("前のフィルタヘッダーがなくても判定できました")
+	}
+}
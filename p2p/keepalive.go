@@ -0,0 +1,268 @@
+package p2p
+
+import (
+	"context"
+	"time"
+
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// 接続の維持と応答しないピアの検出
+// pingを定期的に送ってpongまでの時間を計測し、要求したデータが届かないピアを切断します
+
+const (
+	// DefaultPingInterval はpingを送る間隔
+	DefaultPingInterval = 2 * time.Minute
+	// DefaultPingTimeout はpongが返ってくるまで待つ時間
+	DefaultPingTimeout = 20 * time.Minute
+	// DefaultStallTimeout はgetdataやgetheadersの応答が届くまで待つ時間
+	DefaultStallTimeout = 2 * time.Minute
+)
+
+var (
+	// ErrPingTimeout は時間内にpongが返ってこなかった場合のエラー
+	ErrPingTimeout = errors.New("pongが返ってきません")
+	// ErrStalled は要求したデータが時間内に届かなかった場合のエラー
+	ErrStalled = errors.New("要求したデータが届きません")
+)
+
+// PingStats はピアとの通信の遅延の統計
+type PingStats struct {
+	// 最後にpongが返ってくるまでにかかった時間
+	PingTime time.Duration
+	// pongが返ってくるまでにかかった最小の時間
+	MinPing time.Duration
+	// 返ってきていないpingを送ってからの経過時間
+	PingWait time.Duration
+}
+
+// pingState は送ったpingとpongまでの時間
+type pingState struct {
+	// 返ってきていないpingのNonce、0の場合は待っていない
+	nonce uint64
+	// nonceのpingを送った時刻
+	sent time.Time
+	// 最後にpingを送った時刻
+	last     time.Time
+	pingTime time.Duration
+	minPing  time.Duration
+}
+
+// requestTracker は応答を待っているgetdataとgetheadersの期限を保持する
+type requestTracker struct {
+	// getdataで要求したデータのハッシュと期限
+	data map[protocol.Hash]time.Time
+	// getheadersの期限、送った順に並ぶ
+	headers []time.Time
+}
+
+func newRequestTracker() *requestTracker {
+	return &requestTracker{data: map[protocol.Hash]time.Time{}}
+}
+
+// sent は送ったメッセージの応答の期限を記録する
+func (t *requestTracker) sent(msg protocol.Message, deadline time.Time) {
+	switch m := msg.(type) {
+	case *protocol.MsgGetData:
+		for _, iv := range m.InvList {
+			if _, ok := t.data[iv.Hash]; !ok {
+				t.data[iv.Hash] = deadline
+			}
+		}
+	case *protocol.MsgGetHeaders:
+		t.headers = append(t.headers, deadline)
+	}
+}
+
+// received は受け取ったメッセージに対応する要求を取り除く
+func (t *requestTracker) received(msg protocol.Message) {
+	switch m := msg.(type) {
+	case *protocol.MsgBlock:
+		delete(t.data, m.Block.BlockHash())
+	case *protocol.MsgMerkleBlock:
+		delete(t.data, m.Header.BlockHash())
+	case *protocol.MsgCmpctBlock:
+		delete(t.data, m.Block.Header.BlockHash())
+	case *protocol.MsgTx:
+		delete(t.data, m.Tx.TxHash())
+		delete(t.data, m.Tx.WitnessHash())
+	case *protocol.MsgNotFound:
+		for _, iv := range m.InvList {
+			delete(t.data, iv.Hash)
+		}
+	case *protocol.MsgHeaders:
+		if len(t.headers) != 0 {
+			t.headers = t.headers[1:]
+		}
+	}
+}
+
+// requested はブロックかマークルブロックがgetdataで要求したものかどうかを返す
+func (t *requestTracker) requested(msg protocol.Message) bool {
+	var hash protocol.Hash
+	switch m := msg.(type) {
+	case *protocol.MsgBlock:
+		hash = m.Block.BlockHash()
+	case *protocol.MsgMerkleBlock:
+		hash = m.Header.BlockHash()
+	default:
+		return true
+	}
+	_, ok := t.data[hash]
+	return ok
+}
+
+// stalled は期限を過ぎた要求があればその内容を返す
+func (t *requestTracker) stalled(now time.Time) (string, bool) {
+	if len(t.headers) != 0 && now.After(t.headers[0]) {
+		return "headers", true
+	}
+	for hash, deadline := range t.data {
+		if now.After(deadline) {
+			return hash.String(), true
+		}
+	}
+	return "", false
+}
+
+// len は応答を待っている要求の数を返す
+func (t *requestTracker) len() int {
+	return len(t.data) + len(t.headers)
+}
+
+// PingStats はピアとの通信の遅延の統計を返します
+func (p *Peer) PingStats() PingStats {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	stats := PingStats{
+		PingTime: p.ping.pingTime,
+		MinPing:  p.ping.minPing,
+	}
+	if p.ping.nonce != 0 && !p.ping.sent.IsZero() {
+		stats.PingWait = time.Since(p.ping.sent)
+	}
+	return stats
+}
+
+// PendingRequests は応答を待っているgetdataのデータとgetheadersの数を返します
+func (p *Peer) PendingRequests() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.requests.len()
+}
+
+// keepaliveLoop は定期的にpingを送り、pongや要求したデータが届かない場合は切断する
+func (p *Peer) keepaliveLoop(ctx context.Context) {
+	interval := p.cfg.PingInterval
+	if p.cfg.PingTimeout < interval {
+		interval = p.cfg.PingTimeout
+	}
+	if p.cfg.StallTimeout < interval {
+		interval = p.cfg.StallTimeout
+	}
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+
+	// 接続してすぐに遅延を計測する
+	if err := p.sendPing(); err != nil {
+		p.disconnect(err)
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := p.checkKeepalive(now); err != nil {
+				p.disconnect(err)
+				return
+			}
+		}
+	}
+}
+
+// checkKeepalive は期限を過ぎたpingや要求を確認して、必要ならpingを送る
+func (p *Peer) checkKeepalive(now time.Time) error {
+	p.mtx.Lock()
+	waiting := p.ping.nonce != 0
+	timeout := waiting && !p.ping.sent.IsZero() && p.cfg.PingTimeout < now.Sub(p.ping.sent)
+	due := !waiting && p.cfg.PingInterval <= now.Sub(p.ping.last)
+	what, stalled := p.requests.stalled(now)
+	p.mtx.Unlock()
+
+	if timeout {
+		return ErrPingTimeout
+	}
+	if stalled {
+		return errors.Wrapf(ErrStalled, "%sが届きません", what)
+	}
+	if due {
+		return p.sendPing()
+	}
+	return nil
+}
+
+// sendPing は新しいNonceでpingを送信待ちに追加する
+func (p *Peer) sendPing() error {
+	nonce, err := randomNonce()
+	if err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	p.ping.nonce = nonce
+	p.ping.sent = time.Time{}
+	p.ping.last = time.Now()
+	p.mtx.Unlock()
+
+	err = p.QueueMessage(protocol.NewMsgPing(nonce), PriorityHigh, nil)
+	if err == ErrQueueFull || err == ErrPeerDisconnected {
+		// 送信待ちが詰まっている場合は次の機会に送る、Shutdownしている場合は送らない
+		p.mtx.Lock()
+		p.ping.nonce = 0
+		p.mtx.Unlock()
+		return nil
+	}
+	return err
+}
+
+// onSent はメッセージを書き込んだ時刻を記録する
+func (p *Peer) onSent(msg protocol.Message, now time.Time) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if m, ok := msg.(*protocol.MsgPing); ok && m.Nonce == p.ping.nonce {
+		p.ping.sent = now
+	}
+	p.requests.sent(msg, now.Add(p.cfg.StallTimeout))
+}
+
+// onReceived はpingに応答し、pongや要求したデータの到着を記録する
+func (p *Peer) onReceived(msg protocol.Message, now time.Time) {
+	switch m := msg.(type) {
+	case *protocol.MsgPing:
+		p.QueueMessage(protocol.NewMsgPong(m.Nonce), PriorityHigh, nil)
+		return
+	case *protocol.MsgPong:
+		p.handlePong(m, now)
+		return
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.requests.received(msg)
+}
+
+// handlePong は送ったpingのpongであれば往復の時間を記録する
+func (p *Peer) handlePong(m *protocol.MsgPong, now time.Time) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.ping.nonce == 0 || m.Nonce != p.ping.nonce || p.ping.sent.IsZero() {
+		return
+	}
+	rtt := now.Sub(p.ping.sent)
+	p.ping.nonce = 0
+	p.ping.pingTime = rtt
+	if p.ping.minPing == 0 || rtt < p.ping.minPing {
+		p.ping.minPing = rtt
+	}
+}
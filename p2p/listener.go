@@ -0,0 +1,121 @@
+package p2p
+
+import (
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// 内向きの接続の待ち受け
+// 待ち受けたアドレスに接続してきたピアをManagerのAcceptInboundに渡し、
+// getheadersにはHeaderStoreのブロックヘッダーを返します
+// getaddrはAddrManager、pingはPeerが応答します
+
+// acceptRetryInterval は待ち受けでエラーが起きた場合に次を受け付けるまでの待ち時間
+const acceptRetryInterval = 100 * time.Millisecond
+
+// HeaderStore はgetheadersに応答するためのブロックヘッダーを提供するインターフェース
+type HeaderStore interface {
+	// LocateHeaders はlocatorで最初に見つかったブロックの次から、stopのブロックまでのブロックヘッダーを最大max個返します
+	// locatorのブロックが見つからない場合はジェネシスブロックの次から返します
+	LocateHeaders(locator protocol.BlockLocator, stop protocol.Hash, max int) []*protocol.BlockHeader
+}
+
+// listenAddr は待ち受けるアドレスの文字列を解決して、ネットワークの種類と共に返す
+// ポートを省略した場合はdefaultPortにします
+// IPv6のアドレスはIPv6だけで待ち受けるので、同じポートでIPv4のアドレスも待ち受けられます
+func listenAddr(s string, defaultPort protocol.NetPort) (string, *net.TCPAddr, error) {
+	if _, _, err := net.SplitHostPort(s); err != nil {
+		s = net.JoinHostPort(s, strconv.Itoa(int(defaultPort)))
+	}
+	addr, err := net.ResolveTCPAddr("tcp", s)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "待ち受けるアドレスが不正です: %s", s)
+	}
+	switch {
+	case addr.IP == nil:
+		return "tcp", addr, nil
+	case addr.IP.To4() != nil:
+		return "tcp4", addr, nil
+	default:
+		return "tcp6", addr, nil
+	}
+}
+
+// listen はManagerConfig.Listenの全てのアドレスで待ち受ける
+// 1つでも待ち受けられない場合は全て閉じてエラーを返します
+func (m *Manager) listen() ([]*net.TCPListener, error) {
+	if len(m.cfg.Listen) == 0 {
+		return nil, nil
+	}
+	params, err := protocol.NetworkParams(m.cfg.NetType)
+	if err != nil {
+		return nil, err
+	}
+	var listeners []*net.TCPListener
+	for _, s := range m.cfg.Listen {
+		network, addr, err := listenAddr(s, params.DefaultPort)
+		if err == nil {
+			var l *net.TCPListener
+			if l, err = net.ListenTCP(network, addr); err == nil {
+				listeners = append(listeners, l)
+				continue
+			}
+			err = errors.Wrapf(err, "待ち受けに失敗しました: %s", s)
+		}
+		for _, l := range listeners {
+			l.Close()
+		}
+		return nil, err
+	}
+	return listeners, nil
+}
+
+// ListenAddrs は待ち受けているアドレスを返します
+// ポートに0を指定した場合は割り当てられたポートになります
+func (m *Manager) ListenAddrs() []*net.TCPAddr {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	addrs := make([]*net.TCPAddr, 0, len(m.listeners))
+	for _, l := range m.listeners {
+		addrs = append(addrs, l.Addr().(*net.TCPAddr))
+	}
+	return addrs
+}
+
+// acceptLoop は接続を受け付けてAcceptInboundに渡す、Managerが停止すると待ち受けを閉じます
+func (m *Manager) acceptLoop(l *net.TCPListener) {
+	defer m.wg.Done()
+	go func() {
+		<-m.ctx.Done()
+		l.Close()
+	}()
+	for {
+		conn, err := l.AcceptTCP()
+		if err != nil {
+			// ファイルディスクリプタが足りない場合などは少し待ってから受け付ける
+			select {
+			case <-m.ctx.Done():
+				return
+			case <-time.After(acceptRetryInterval):
+				continue
+			}
+		}
+		// 上限やBANで拒否した場合はAcceptInboundが接続を閉じます
+		m.AcceptInbound(conn)
+	}
+}
+
+// serveHeaders はgetheadersにHeaderStoreのブロックヘッダーを返す
+func (m *Manager) serveHeaders(p *Peer, msg protocol.Message) {
+	getHeaders, ok := msg.(*protocol.MsgGetHeaders)
+	if !ok {
+		return
+	}
+	headers := protocol.NewMsgHeaders()
+	headers.Headers = m.cfg.Headers.LocateHeaders(getHeaders.BlockLocator, getHeaders.HashStop, protocol.MaxHeadersPerMsg)
+	p.QueueMessage(headers, PriorityNormal, nil)
+}
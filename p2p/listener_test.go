@@ -0,0 +1,202 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/protocol"
+)
+
+// headerChain はジェネシスブロックから順に並んだブロックヘッダーのHeaderStore
+type headerChain []*protocol.BlockHeader
+
+func (c headerChain) LocateHeaders(locator protocol.BlockLocator, stop protocol.Hash, max int) []*protocol.BlockHeader {
+	start := 1
+	for _, hash := range locator {
+		if i := c.index(hash); 0 <= i {
+			start = i + 1
+			break
+		}
+	}
+	var headers []*protocol.BlockHeader
+	for _, h := range c[start:] {
+		if len(headers) == max {
+			break
+		}
+		headers = append(headers, h)
+		if h.BlockHash() == stop {
+			break
+		}
+	}
+	return headers
+}
+
+func (c headerChain) index(hash protocol.Hash) int {
+	for i, h := range c {
+		if h.BlockHash() == hash {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestListenAddr(t *testing.T) {
+	tests := []struct {
+		in      string
+		network string
+		addr    string
+	}{
+		{"127.0.0.1", "tcp4", "127.0.0.1:18333"},
+		{"0.0.0.0:8333", "tcp4", "0.0.0.0:8333"},
+		{"::1", "tcp6", "[::1]:18333"},
+		{"[::]:0", "tcp6", "[::]:0"},
+		{":8333", "tcp", ":8333"},
+	}
+	for _, tt := range tests {
+		network, addr, err := listenAddr(tt.in, 18333)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if network != tt.network || addr.String() != tt.addr {
+			t.Errorf("アドレスが一致しません: %s %s != %s %s", network, addr, tt.network, tt.addr)
+		}
+	}
+	if _, _, err := listenAddr("127.0.0.1:port", 18333); err == nil {
+		t.Error("不正なアドレスを受け入れました")
+	}
+}
+
+func TestManagerListen(t *testing.T) {
+	listen := []string{"127.0.0.1:0"}
+	if l, err := net.Listen("tcp6", "[::1]:0"); err == nil {
+		l.Close()
+		listen = append(listen, "[::1]:0")
+	}
+	m := NewManager(ManagerConfig{
+		NetType:    core.TestNetwork,
+		Peer:       PeerConfig{Nonces: NewNonceSet()},
+		MaxInbound: len(listen),
+		Listen:     listen,
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	addrs := m.ListenAddrs()
+	if len(addrs) != len(listen) {
+		t.Fatalf("待ち受けているアドレスの数が一致しません: %v", addrs)
+	}
+
+	for _, addr := range addrs {
+		p := NewOutboundPeer(NewConnection(addr, core.TestNetwork), PeerConfig{Nonces: NewNonceSet()})
+		if err := p.Connect(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		defer p.Close()
+	}
+	waitFor(t, "内向きの接続", func() bool { return m.Count(ConnInbound) == len(listen) })
+
+	// 上限を超えた接続は拒否する
+	p := NewOutboundPeer(NewConnection(addrs[0], core.TestNetwork), PeerConfig{
+		Nonces:           NewNonceSet(),
+		HandshakeTimeout: time.Second,
+	})
+	if err := p.Connect(context.Background()); err == nil {
+		p.Close()
+		t.Error("上限を超えて接続できました")
+	}
+
+	// 停止すると待ち受けを閉じる
+	m.Stop()
+	if _, err := net.DialTCP("tcp", nil, addrs[0]); err == nil {
+		t.Error("停止した後も待ち受けています")
+	}
+
+	// 待ち受けられないアドレスは開始できない
+	m = NewManager(ManagerConfig{NetType: core.TestNetwork, Listen: []string{"127.0.0.1:0", "192.0.2.1:0"}})
+	if err := m.Start(context.Background()); err == nil {
+		m.Stop()
+		t.Error("待ち受けられないアドレスで開始できました")
+	}
+}
+
+func TestManagerServe(t *testing.T) {
+	chain := headerChain{minedHeader(protocol.Hash{})}
+	for i := 0; i < 5; i++ {
+		chain = append(chain, minedHeader(chain[len(chain)-1].BlockHash()))
+	}
+	am, err := NewAddrManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	am.allowLocal = true
+	var addrs []*protocol.NetAddressV2
+	for i := 0; i < 10; i++ {
+		addrs = append(addrs, testAddr(byte(i)+10, byte(i), 1, 1))
+	}
+	am.Add(addrs, testAddr(1, 1, 1, 1), 0)
+
+	m := NewManager(ManagerConfig{
+		NetType:        core.TestNetwork,
+		Peer:           PeerConfig{Nonces: NewNonceSet()},
+		AddrManager:    am,
+		DisableDNSSeed: true,
+		GetAddress:     func() *net.TCPAddr { return nil },
+		Headers:        chain,
+		Listen:         []string{"127.0.0.1:0"},
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	received := make(chan protocol.Message, 10)
+	handler := func(p *Peer, msg protocol.Message) { received <- msg }
+	p := NewOutboundPeer(NewConnection(m.ListenAddrs()[0], core.TestNetwork), PeerConfig{
+		Nonces: NewNonceSet(),
+		Handlers: map[string]MessageHandler{
+			"headers": handler,
+			"addrv2":  handler,
+		},
+	})
+	if err := p.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	receive := func() protocol.Message {
+		t.Helper()
+		select {
+		case msg := <-received:
+			return msg
+		case <-time.After(5 * time.Second):
+			t.Fatal("応答がありません")
+		}
+		return nil
+	}
+
+	// getheadersにはロケーターの次のブロックヘッダーを返す
+	getHeaders := protocol.NewMsgGetHeaders(protocol.BlockLocator{{1}, chain[2].BlockHash()}, chain[4].BlockHash())
+	if err := p.QueueMessage(getHeaders, PriorityNormal, nil); err != nil {
+		t.Fatal(err)
+	}
+	msg, ok := receive().(*protocol.MsgHeaders)
+	if !ok || len(msg.Headers) != 2 || msg.Headers[0].BlockHash() != chain[3].BlockHash() || msg.Headers[1].BlockHash() != chain[4].BlockHash() {
+		t.Fatalf("ブロックヘッダーが一致しません: %v", msg)
+	}
+
+	// getaddrにはAddrManagerのアドレスを返す
+	if err := p.QueueMessage(protocol.NewMsgGetAddr(), PriorityNormal, nil); err != nil {
+		t.Fatal(err)
+	}
+	if addrV2, ok := receive().(*protocol.MsgAddrV2); !ok || len(addrV2.AddrList) == 0 {
+		t.Fatalf("アドレスが返ってきません: %v", addrV2)
+	}
+
+	// pingに応答する
+	waitFor(t, "pongの受信", func() bool { return p.PingStats().PingTime != 0 })
+}
@@ -0,0 +1,719 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// ピアとの接続の管理
+// 決まった数の外向きの接続を維持し、内向きの接続を上限まで受け入れます
+// https://github.com/bitcoin/bitcoin/blob/master/src/net.cpp
+
+const (
+	// DefaultMaxOutboundFullRelay はブロックとトランザクションを中継する外向きの接続の数
+	DefaultMaxOutboundFullRelay = 8
+	// DefaultMaxOutboundBlockRelay はブロックだけを中継する外向きの接続の数
+	DefaultMaxOutboundBlockRelay = 2
+	// DefaultMaxInbound は受け入れる内向きの接続の数
+	DefaultMaxInbound = 115
+	// DefaultConnectInterval は接続先が足りているか確認する間隔
+	DefaultConnectInterval = time.Second
+	// DefaultRetryInterval は接続に失敗したアドレスに再接続するまでの最初の待ち時間
+	DefaultRetryInterval = 5 * time.Second
+	// DefaultMaxRetryInterval は再接続するまでの最大の待ち時間
+	DefaultMaxRetryInterval = 10 * time.Minute
+	// DefaultFeelerInterval はnewテーブルのアドレスに接続できるか試す間隔
+	DefaultFeelerInterval = 2 * time.Minute
+	// DefaultSeedRetryInterval はAddrManagerが空のままの場合にDNSシードに問い合わせ直す間隔
+	DefaultSeedRetryInterval = time.Minute
+	// DefaultSeedServices はDNSシードに問い合わせる時に絞り込むサービス
+	DefaultSeedServices = protocol.NodeNetwork | protocol.NodeWitness
+	// addrSaveInterval はアドレスをファイルに保存する間隔
+	addrSaveInterval = 15 * time.Minute
+
+	// maxAddressTries は1回の確認で接続先の候補を取り出す回数の上限
+	maxAddressTries = 100
+)
+
+var (
+	// ErrMaxInbound は内向きの接続が上限に達している場合のエラー
+	ErrMaxInbound = errors.New("内向きの接続が上限に達しています")
+	// ErrManagerStopped はManagerが開始していないか停止している場合のエラー
+	ErrManagerStopped = errors.New("ピアの管理は停止しています")
+)
+
+// ConnType はピアとの接続の種類を表す型
+type ConnType int
+
+const (
+	// ConnInbound はピアから接続してきた接続
+	ConnInbound ConnType = iota
+	// ConnOutboundFullRelay はブロックとトランザクションとアドレスを中継する外向きの接続
+	ConnOutboundFullRelay
+	// ConnBlockRelay はブロックだけを中継する外向きの接続、トランザクションとアドレスは中継しません
+	ConnBlockRelay
+	// ConnManual はaddnodeで指定した外向きの接続、切断しても再接続します
+	ConnManual
+	// ConnFeeler はアドレスに接続できるか確かめるための外向きの接続、ハンドシェイクの後すぐに切断します
+	ConnFeeler
+)
+
+func (t ConnType) String() string {
+	switch t {
+	case ConnInbound:
+		return "inbound"
+	case ConnOutboundFullRelay:
+		return "outbound-full-relay"
+	case ConnBlockRelay:
+		return "block-relay-only"
+	case ConnManual:
+		return "manual"
+	case ConnFeeler:
+		return "feeler"
+	default:
+		return "unknown"
+	}
+}
+
+// Outbound は外向きの接続かどうかを返します
+func (t ConnType) Outbound() bool {
+	return t != ConnInbound
+}
+
+// ManagerConfig はピアの管理の設定
+type ManagerConfig struct {
+	// 接続するネットワーク
+	NetType core.NetworkType
+	// BIP324の暗号化通信を試すかどうか
+	EnableV2 bool
+	// 各ピアの設定、ConnTypeとRelayは接続の種類に合わせて上書きします
+	Peer PeerConfig
+
+	// 外向きの接続の数、0の場合はデフォルトの値
+	MaxOutboundFullRelay  int
+	MaxOutboundBlockRelay int
+	// 内向きの接続の上限、0の場合はDefaultMaxInbound
+	MaxInbound int
+	// 内向きの接続を待ち受けるアドレス、「host:port」か「host」の形式でポートを省略した場合はネットワークのデフォルトのポート
+	// 空の場合は待ち受けません
+	Listen []string
+	// getheadersに応答するブロックヘッダー、nilの場合は応答しません
+	Headers HeaderStore
+
+	// 接続先の候補のアドレスの管理、接続の結果を記録してaddrとaddrv2で受け取ったアドレスを追加します
+	AddrManager *AddrManager
+	// AddrManagerが空の場合にDNSシードに問い合わせるのに使う、nilの場合はnet.DefaultResolver
+	Resolver Resolver
+	// DNSシードに問い合わせる時に絞り込むサービス、0の場合はDefaultSeedServices
+	SeedServices protocol.ServiceFlags
+	// DNSシードに問い合わせない
+	DisableDNSSeed bool
+	// AddrManagerが空のままの場合にDNSシードに問い合わせ直す間隔、0の場合はDefaultSeedRetryInterval
+	SeedRetryInterval time.Duration
+	// BANと接続の抑制の管理、BANしているアドレスからの接続を拒否し不正な振る舞いをしたピアを抑制します
+	BanManager *BanManager
+	// 接続先の候補を返す、候補が無い場合はnilを返す
+	// nilの場合はAddrManagerから選びます
+	GetAddress func() *net.TCPAddr
+	// アドレスのネットワークグループを返す、nilの場合はNetGroup
+	NetGroup func(addr *net.TCPAddr) string
+
+	// ピアへの接続を待つ時間、0の場合はDefaultConnectTimeout
+	// タイムアウトした場合も接続の失敗として再接続の待ち時間を延ばします
+	ConnectTimeout time.Duration
+	// 接続先が足りているか確認する間隔、0の場合はDefaultConnectInterval
+	ConnectInterval time.Duration
+	// 再接続の待ち時間、失敗するたびに倍にしてMaxRetryIntervalまで延ばします
+	RetryInterval    time.Duration
+	MaxRetryInterval time.Duration
+	// AddrManagerのnewテーブルのアドレスを試す間隔、0の場合はDefaultFeelerInterval
+	FeelerInterval time.Duration
+
+	// ハンドシェイクが完了して接続した時に呼ばれる
+	OnConnect func(p *Peer)
+	// 切断した時に呼ばれる
+	OnDisconnect func(p *Peer)
+}
+
+// retryState は接続に失敗したアドレスの再接続の待ち時間
+type retryState struct {
+	failures int
+	next     time.Time
+}
+
+// Manager はピアとの接続を管理する型
+type Manager struct {
+	cfg ManagerConfig
+
+	mtx sync.Mutex
+	// 接続しているピア、アドレスをキーにします
+	peers map[string]*Peer
+	// 接続中のアドレスと種類
+	pending map[string]ConnType
+	// addnodeで指定したアドレス
+	manual map[string]*net.TCPAddr
+	retry  map[string]*retryState
+	// 内向きの接続の待ち受け
+	listeners []*net.TCPListener
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	wake   chan struct{}
+}
+
+// NewManager はピアの管理を生成します
+func NewManager(cfg ManagerConfig) *Manager {
+	if cfg.MaxOutboundFullRelay == 0 {
+		cfg.MaxOutboundFullRelay = DefaultMaxOutboundFullRelay
+	}
+	if cfg.MaxOutboundBlockRelay == 0 {
+		cfg.MaxOutboundBlockRelay = DefaultMaxOutboundBlockRelay
+	}
+	if cfg.MaxInbound == 0 {
+		cfg.MaxInbound = DefaultMaxInbound
+	}
+	if cfg.NetGroup == nil {
+		cfg.NetGroup = func(addr *net.TCPAddr) string { return NetGroup(addr.IP) }
+	}
+	if cfg.ConnectTimeout == 0 {
+		cfg.ConnectTimeout = DefaultConnectTimeout
+	}
+	if cfg.ConnectInterval == 0 {
+		cfg.ConnectInterval = DefaultConnectInterval
+	}
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = DefaultRetryInterval
+	}
+	if cfg.MaxRetryInterval == 0 {
+		cfg.MaxRetryInterval = DefaultMaxRetryInterval
+	}
+	if cfg.FeelerInterval == 0 {
+		cfg.FeelerInterval = DefaultFeelerInterval
+	}
+	if cfg.Resolver == nil {
+		cfg.Resolver = net.DefaultResolver
+	}
+	if cfg.SeedRetryInterval == 0 {
+		cfg.SeedRetryInterval = DefaultSeedRetryInterval
+	}
+	if cfg.SeedServices == 0 {
+		cfg.SeedServices = DefaultSeedServices
+	}
+	if cfg.GetAddress == nil && cfg.AddrManager != nil {
+		cfg.GetAddress = func() *net.TCPAddr {
+			return selectTCPAddr(cfg.AddrManager, false)
+		}
+	}
+	return &Manager{
+		cfg:     cfg,
+		peers:   map[string]*Peer{},
+		pending: map[string]ConnType{},
+		manual:  map[string]*net.TCPAddr{},
+		retry:   map[string]*retryState{},
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Start は接続を維持するゴルーチンと、ManagerConfig.Listenのアドレスで待ち受けるゴルーチンを開始します
+// ctxがキャンセルされると待ち受けを閉じて全て切断します
+func (m *Manager) Start(ctx context.Context) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.cancel != nil {
+		return errors.New("ピアの管理は既に開始しています")
+	}
+	listeners, err := m.listen()
+	if err != nil {
+		return err
+	}
+	m.ctx, m.cancel = context.WithCancel(ctx)
+	m.listeners = listeners
+	for _, l := range listeners {
+		m.wg.Add(1)
+		go m.acceptLoop(l)
+	}
+	if am := m.cfg.AddrManager; am != nil && am.Size() == 0 && !m.cfg.DisableDNSSeed {
+		if params, err := protocol.NetworkParams(m.cfg.NetType); err == nil {
+			m.wg.Add(1)
+			go m.seed(params)
+		}
+	}
+	m.wg.Add(1)
+	go m.connectLoop()
+	return nil
+}
+
+// seed はDNSシードに問い合わせて接続先の候補を集める
+// AddrManagerが空のままの間はSeedRetryIntervalごとに問い合わせ直します
+func (m *Manager) seed(params *protocol.NetParams) {
+	defer m.wg.Done()
+	for {
+		ctx, cancel := context.WithTimeout(m.ctx, DefaultSeedTimeout)
+		_, err := SeedAddrManager(ctx, m.cfg.AddrManager, m.cfg.Resolver, params, m.cfg.SeedServices)
+		cancel()
+		if err == nil {
+			m.signal()
+		}
+		if 0 < m.cfg.AddrManager.Size() {
+			return
+		}
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-time.After(m.cfg.SeedRetryInterval):
+		}
+	}
+}
+
+// Stop は全てのピアを切断して、ゴルーチンが終わるまで待ちます
+func (m *Manager) Stop() {
+	m.mtx.Lock()
+	cancel := m.cancel
+	m.mtx.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	m.wg.Wait()
+	if m.cfg.AddrManager != nil {
+		m.cfg.AddrManager.Save()
+	}
+}
+
+// AddNode は常に接続するアドレスを追加します
+// 外向きの接続の数やネットワークグループの制限を受けず、切断しても再接続します
+func (m *Manager) AddNode(addr *net.TCPAddr) {
+	m.mtx.Lock()
+	m.manual[addr.String()] = addr
+	m.mtx.Unlock()
+	m.signal()
+}
+
+// RemoveNode はAddNodeで追加したアドレスを削除して切断します
+func (m *Manager) RemoveNode(addr *net.TCPAddr) error {
+	m.mtx.Lock()
+	key := addr.String()
+	if _, ok := m.manual[key]; !ok {
+		m.mtx.Unlock()
+		return errors.Errorf("addnodeで追加されていないアドレスです: %s", key)
+	}
+	delete(m.manual, key)
+	delete(m.retry, key)
+	p := m.peers[key]
+	m.mtx.Unlock()
+	if p != nil {
+		p.Close()
+	}
+	return nil
+}
+
+// Ban はサブネットをdurationの間BANして、そのサブネットのピアを切断します
+// durationが0の場合はDefaultBanDurationです
+func (m *Manager) Ban(subnet *net.IPNet, duration time.Duration) error {
+	if m.cfg.BanManager == nil {
+		return errors.New("BanManagerが設定されていません")
+	}
+	if err := m.cfg.BanManager.Ban(subnet, duration); err != nil {
+		return err
+	}
+	for _, p := range m.Peers() {
+		if subnet.Contains(p.Addr().IP) {
+			p.Close()
+		}
+	}
+	return nil
+}
+
+// Peers は接続しているピアの一覧を返します
+func (m *Manager) Peers() []*Peer {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	peers := make([]*Peer, 0, len(m.peers))
+	for _, p := range m.peers {
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+// Count は指定した種類で接続しているピアの数を返します
+func (m *Manager) Count(connType ConnType) int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.count(connType, false)
+}
+
+// count は指定した種類のピアの数を返す、pendingがtrueの場合は接続中のものも数える
+// ロックを取った状態で呼び出す
+func (m *Manager) count(connType ConnType, pending bool) int {
+	n := 0
+	for _, p := range m.peers {
+		if p.ConnType() == connType {
+			n++
+		}
+	}
+	if pending {
+		for _, t := range m.pending {
+			if t == connType {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// AcceptInbound は接続してきたピアとハンドシェイクをして管理に加えます
+// 内向きの接続が上限に達している場合は接続を閉じてErrMaxInboundを返します
+// BANしているアドレスの場合は接続を閉じてErrBannedを返します
+func (m *Manager) AcceptInbound(conn *net.TCPConn) error {
+	m.mtx.Lock()
+	if m.ctx == nil || m.ctx.Err() != nil {
+		m.mtx.Unlock()
+		conn.Close()
+		return ErrManagerStopped
+	}
+	ip := conn.RemoteAddr().(*net.TCPAddr).IP
+	if bm := m.cfg.BanManager; bm != nil && bm.IsBanned(ip) {
+		m.mtx.Unlock()
+		conn.Close()
+		return ErrBanned
+	}
+	max := m.cfg.MaxInbound
+	if m.discouraged(ip) {
+		// 抑制しているアドレスは最後の1つの枠には受け入れない
+		max--
+	}
+	if max <= m.count(ConnInbound, true) {
+		m.mtx.Unlock()
+		conn.Close()
+		return ErrMaxInbound
+	}
+	key := conn.RemoteAddr().String()
+	m.pending[key] = ConnInbound
+	m.wg.Add(1)
+	m.mtx.Unlock()
+
+	go func() {
+		defer m.wg.Done()
+		c, err := NewInboundConnection(m.ctx, conn, m.cfg.NetType)
+		if err != nil {
+			conn.Close()
+			m.finishPending(key)
+			return
+		}
+		p := NewInboundPeer(c, m.peerConfig(ConnInbound))
+		if err := p.Handshake(m.ctx); err != nil {
+			m.finishPending(key)
+			return
+		}
+		m.run(key, p)
+	}()
+	return nil
+}
+
+// peerConfig は接続の種類に合わせたピアの設定を返す
+func (m *Manager) peerConfig(connType ConnType) PeerConfig {
+	cfg := m.cfg.Peer
+	cfg.ConnType = connType
+	if connType == ConnBlockRelay {
+		cfg.Relay = false
+	}
+	handlers := map[string]MessageHandler{}
+	for command, handler := range cfg.Handlers {
+		handlers[command] = handler
+	}
+	if am := m.cfg.AddrManager; am != nil && connType != ConnBlockRelay {
+		// ブロックだけを中継するピアとはアドレスをやりとりしない
+		for _, command := range []string{"addr", "addrv2", "getaddr"} {
+			handlers[command] = chainHandlers(am.handleMessage, cfg.Handlers[command])
+		}
+	}
+	if m.cfg.Headers != nil {
+		handlers["getheaders"] = chainHandlers(m.serveHeaders, cfg.Handlers["getheaders"])
+	}
+	cfg.Handlers = handlers
+	return cfg
+}
+
+// chainHandlers は順番にハンドラを呼び出すハンドラを返す
+func chainHandlers(handlers ...MessageHandler) MessageHandler {
+	return func(p *Peer, msg protocol.Message) {
+		for _, handler := range handlers {
+			if handler != nil {
+				handler(p, msg)
+			}
+		}
+	}
+}
+
+// signal は接続を維持するゴルーチンを起こす
+func (m *Manager) signal() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// connectLoop は定期的に接続先が足りているか確認して接続する
+func (m *Manager) connectLoop() {
+	defer m.wg.Done()
+	ticker := time.NewTicker(m.cfg.ConnectInterval)
+	defer ticker.Stop()
+	feeler := time.NewTicker(m.cfg.FeelerInterval)
+	defer feeler.Stop()
+	save := time.NewTicker(addrSaveInterval)
+	defer save.Stop()
+	for {
+		m.connectManual()
+		m.connectOutbound(ConnOutboundFullRelay, m.cfg.MaxOutboundFullRelay)
+		m.connectOutbound(ConnBlockRelay, m.cfg.MaxOutboundBlockRelay)
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.wake:
+		case <-feeler.C:
+			m.connectFeeler()
+		case <-save.C:
+			if m.cfg.AddrManager != nil {
+				m.cfg.AddrManager.Save()
+			}
+		}
+	}
+}
+
+// connectFeeler はAddrManagerのnewテーブルのアドレスに接続できるか試す
+// 接続できたアドレスはtriedテーブルに移ります
+func (m *Manager) connectFeeler() {
+	if m.cfg.AddrManager == nil {
+		return
+	}
+	addr := selectTCPAddr(m.cfg.AddrManager, true)
+	if addr == nil {
+		return
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.count(ConnFeeler, true) != 0 || m.connected(addr.String()) || m.refused(addr.IP) {
+		return
+	}
+	m.dial(addr, ConnFeeler)
+}
+
+// connectManual は接続していないaddnodeのアドレスに接続する
+func (m *Manager) connectManual() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	now := time.Now()
+	for key, addr := range m.manual {
+		if m.connected(key) || m.waitingRetry(key, now) {
+			continue
+		}
+		m.dial(addr, ConnManual)
+	}
+}
+
+// connectOutbound は指定した種類の外向きの接続がmaxになるまで接続先の候補に接続する
+// 既に接続しているネットワークグループのアドレスには接続しません
+func (m *Manager) connectOutbound(connType ConnType, max int) {
+	if m.cfg.GetAddress == nil {
+		return
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	now := time.Now()
+	groups := m.outboundGroups()
+	for tries := 0; m.count(connType, true) < max && tries < maxAddressTries; tries++ {
+		addr := m.cfg.GetAddress()
+		if addr == nil {
+			return
+		}
+		key := addr.String()
+		group := m.cfg.NetGroup(addr)
+		if m.connected(key) || m.waitingRetry(key, now) || groups[group] || m.refused(addr.IP) {
+			continue
+		}
+		groups[group] = true
+		m.dial(addr, connType)
+	}
+}
+
+// outboundGroups は自動で接続した外向きの接続のネットワークグループを返す
+// ロックを取った状態で呼び出す
+func (m *Manager) outboundGroups() map[string]bool {
+	groups := map[string]bool{}
+	add := func(key string, connType ConnType) {
+		if connType != ConnOutboundFullRelay && connType != ConnBlockRelay {
+			return
+		}
+		if addr, err := net.ResolveTCPAddr("tcp", key); err == nil {
+			groups[m.cfg.NetGroup(addr)] = true
+		}
+	}
+	for key, p := range m.peers {
+		add(key, p.ConnType())
+	}
+	for key, connType := range m.pending {
+		add(key, connType)
+	}
+	return groups
+}
+
+// refused はBANしているか抑制しているアドレスかどうかを返す
+// addnodeで指定したアドレスには使いません
+func (m *Manager) refused(ip net.IP) bool {
+	bm := m.cfg.BanManager
+	return bm != nil && (bm.IsBanned(ip) || bm.IsDiscouraged(ip))
+}
+
+// discouraged は抑制しているアドレスかどうかを返す
+func (m *Manager) discouraged(ip net.IP) bool {
+	return m.cfg.BanManager != nil && m.cfg.BanManager.IsDiscouraged(ip)
+}
+
+// connected は接続しているか接続中のアドレスかどうかを返す
+func (m *Manager) connected(key string) bool {
+	_, ok := m.peers[key]
+	_, pending := m.pending[key]
+	return ok || pending
+}
+
+// waitingRetry は再接続の待ち時間が過ぎていないかどうかを返す
+func (m *Manager) waitingRetry(key string, now time.Time) bool {
+	r, ok := m.retry[key]
+	return ok && now.Before(r.next)
+}
+
+// failed は接続に失敗したアドレスの再接続の待ち時間を延ばす
+func (m *Manager) failed(key string) {
+	r, ok := m.retry[key]
+	if !ok {
+		r = &retryState{}
+		m.retry[key] = r
+	}
+	r.failures++
+	delay := m.cfg.RetryInterval
+	for i := 1; i < r.failures && delay < m.cfg.MaxRetryInterval; i++ {
+		delay *= 2
+	}
+	if m.cfg.MaxRetryInterval < delay {
+		delay = m.cfg.MaxRetryInterval
+	}
+	r.next = time.Now().Add(delay)
+}
+
+// dial はアドレスに接続するゴルーチンを開始する、ロックを取った状態で呼び出す
+func (m *Manager) dial(addr *net.TCPAddr, connType ConnType) {
+	key := addr.String()
+	m.pending[key] = connType
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		am := m.cfg.AddrManager
+		if am != nil {
+			am.Attempt(netAddressV2(addr))
+		}
+		conn := NewConnection(addr, m.cfg.NetType)
+		conn.EnableV2(m.cfg.EnableV2)
+		conn.SetConnectTimeout(m.cfg.ConnectTimeout)
+		p := NewOutboundPeer(conn, m.peerConfig(connType))
+		if err := p.Connect(m.ctx); err != nil {
+			m.mtx.Lock()
+			m.failed(key)
+			m.mtx.Unlock()
+			m.finishPending(key)
+			return
+		}
+		m.mtx.Lock()
+		delete(m.retry, key)
+		m.mtx.Unlock()
+		if am != nil {
+			am.Good(netAddressV2(addr))
+		}
+		if connType == ConnFeeler {
+			p.Close()
+			m.finishPending(key)
+			return
+		}
+		m.run(key, p)
+	}()
+}
+
+// finishPending は接続中のアドレスを取り除いて、足りない接続を補う
+func (m *Manager) finishPending(key string) {
+	m.mtx.Lock()
+	delete(m.pending, key)
+	m.mtx.Unlock()
+	m.signal()
+}
+
+// run はハンドシェイクが完了したピアを管理に加えて、切断するまで待つ
+func (m *Manager) run(key string, p *Peer) {
+	m.mtx.Lock()
+	delete(m.pending, key)
+	if m.ctx.Err() != nil {
+		m.mtx.Unlock()
+		p.Close()
+		return
+	}
+	m.peers[key] = p
+	m.mtx.Unlock()
+
+	if err := p.Start(m.ctx); err != nil {
+		p.Close()
+	} else {
+		if p.ConnType() == ConnOutboundFullRelay && m.cfg.AddrManager != nil {
+			// 接続先の候補を増やすためにアドレスを要求する
+			p.QueueMessage(protocol.NewMsgGetAddr(), PriorityNormal, nil)
+		}
+		if m.cfg.OnConnect != nil {
+			m.cfg.OnConnect(p)
+		}
+	}
+	<-p.Done()
+
+	if am := m.cfg.AddrManager; am != nil && p.ConnType().Outbound() {
+		am.Connected(netAddressV2(p.Addr()))
+	}
+	if bm := m.cfg.BanManager; bm != nil && p.Misbehaved() && p.ConnType() != ConnManual {
+		// addnodeで指定したピアは切断しても抑制しない
+		bm.Discourage(p.Addr().IP)
+	}
+	m.mtx.Lock()
+	delete(m.peers, key)
+	if p.ConnType() == ConnManual {
+		// addnodeのピアはすぐに再接続せず待ち時間を延ばす
+		m.failed(key)
+	}
+	m.mtx.Unlock()
+	if m.cfg.OnDisconnect != nil {
+		m.cfg.OnDisconnect(p)
+	}
+	m.signal()
+}
+
+// selectTCPAddr はAddrManagerから接続できるIPのアドレスを選ぶ
+// TorやI2Pのアドレスには接続できないので選び直します
+func selectTCPAddr(am *AddrManager, newOnly bool) *net.TCPAddr {
+	for tries := 0; tries < maxAddressTries; tries++ {
+		addr := am.Select(newOnly)
+		if addr == nil {
+			return nil
+		}
+		if ip := addr.IP(); ip != nil && addr.NetworkID != protocol.NetworkCJDNS {
+			return &net.TCPAddr{IP: ip, Port: int(addr.Port)}
+		}
+	}
+	return nil
+}
+
+// netAddressV2 はTCPのアドレスをAddrManagerで使う形式にする
+func netAddressV2(addr *net.TCPAddr) *protocol.NetAddressV2 {
+	return protocol.NewNetAddressV2FromIP(addr.IP, protocol.NetPort(addr.Port), 0)
+}
@@ -0,0 +1,314 @@
+package p2p
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keiji0/btcwallet/core"
+)
+
+// servePeers は接続してきたピアとハンドシェイクをするサーバーを起動する
+// keepがfalseの場合はハンドシェイクの後すぐに切断します
+func servePeers(t *testing.T, keep bool) (*net.TCPAddr, *int32, func()) {
+	var handshakes int32
+	var mtx sync.Mutex
+	var peers []*Peer
+	addr, closeListener := serve(t, func(conn net.Conn) {
+		c, err := NewInboundConnection(context.Background(), conn.(*net.TCPConn), core.TestNetwork)
+		if err != nil {
+			conn.Close()
+			return
+		}
+		p := NewInboundPeer(c, PeerConfig{Nonces: NewNonceSet()})
+		if err := p.Handshake(context.Background()); err != nil {
+			return
+		}
+		atomic.AddInt32(&handshakes, 1)
+		if !keep {
+			p.Close()
+			return
+		}
+		p.Start(context.Background())
+		mtx.Lock()
+		peers = append(peers, p)
+		mtx.Unlock()
+	})
+	return addr, &handshakes, func() {
+		closeListener()
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, p := range peers {
+			p.Close()
+		}
+	}
+}
+
+// waitFor はcondがtrueになるまで待つ
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("%sになりません", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// addressList は候補を順番に返すGetAddress
+func addressList(addrs []*net.TCPAddr) func() *net.TCPAddr {
+	var mtx sync.Mutex
+	i := 0
+	return func() *net.TCPAddr {
+		mtx.Lock()
+		defer mtx.Unlock()
+		addr := addrs[i%len(addrs)]
+		i++
+		return addr
+	}
+}
+
+// portGroup はポートごとに別のネットワークグループにする
+func portGroup(addr *net.TCPAddr) string {
+	return strconv.Itoa(addr.Port)
+}
+
+func TestManagerOutbound(t *testing.T) {
+	var addrs []*net.TCPAddr
+	for i := 0; i < 5; i++ {
+		addr, _, closeServer := servePeers(t, true)
+		defer closeServer()
+		addrs = append(addrs, addr)
+	}
+	m := NewManager(ManagerConfig{
+		NetType:               core.TestNetwork,
+		Peer:                  PeerConfig{Nonces: NewNonceSet()},
+		MaxOutboundFullRelay:  2,
+		MaxOutboundBlockRelay: 1,
+		GetAddress:            addressList(addrs),
+		NetGroup:              portGroup,
+		ConnectInterval:       10 * time.Millisecond,
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	waitFor(t, "外向きの接続の数", func() bool {
+		return m.Count(ConnOutboundFullRelay) == 2 && m.Count(ConnBlockRelay) == 1
+	})
+	time.Sleep(50 * time.Millisecond)
+	if n := len(m.Peers()); n != 3 {
+		t.Errorf("接続の数が一致しません: %d", n)
+	}
+	for _, p := range m.Peers() {
+		if p.Inbound() || p.State() != PeerEstablished {
+			t.Errorf("ピアの状態が一致しません: %v %v", p.ConnType(), p.State())
+		}
+	}
+
+	// 切断したら補う
+	m.Peers()[0].Close()
+	waitFor(t, "再接続した接続の数", func() bool {
+		return m.Count(ConnOutboundFullRelay) == 2 && m.Count(ConnBlockRelay) == 1
+	})
+}
+
+func TestManagerNetGroup(t *testing.T) {
+	// ループバックのアドレスは全て同じネットワークグループ
+	var addrs []*net.TCPAddr
+	for i := 0; i < 3; i++ {
+		addr, _, closeServer := servePeers(t, true)
+		defer closeServer()
+		addrs = append(addrs, addr)
+	}
+	m := NewManager(ManagerConfig{
+		NetType:         core.TestNetwork,
+		Peer:            PeerConfig{Nonces: NewNonceSet()},
+		GetAddress:      addressList(addrs),
+		ConnectInterval: 10 * time.Millisecond,
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	waitFor(t, "外向きの接続", func() bool { return len(m.Peers()) == 1 })
+	time.Sleep(100 * time.Millisecond)
+	if n := len(m.Peers()); n != 1 {
+		t.Errorf("同じネットワークグループに複数接続しています: %d", n)
+	}
+}
+
+func TestManagerInbound(t *testing.T) {
+	m := NewManager(ManagerConfig{
+		NetType:    core.TestNetwork,
+		Peer:       PeerConfig{Nonces: NewNonceSet()},
+		MaxInbound: 1,
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	errs := make(chan error, 2)
+	addr, closeListener := serve(t, func(conn net.Conn) {
+		errs <- m.AcceptInbound(conn.(*net.TCPConn))
+	})
+	defer closeListener()
+
+	p := NewOutboundPeer(NewConnection(addr, core.TestNetwork), PeerConfig{Nonces: NewNonceSet()})
+	if err := p.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "内向きの接続", func() bool { return m.Count(ConnInbound) == 1 })
+
+	p2 := NewOutboundPeer(NewConnection(addr, core.TestNetwork), PeerConfig{
+		Nonces:           NewNonceSet(),
+		HandshakeTimeout: time.Second,
+	})
+	if err := p2.Connect(context.Background()); err == nil {
+		t.Error("上限を超えて接続できました")
+	}
+	if err := <-errs; err != ErrMaxInbound {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+}
+
+func TestManagerAddNode(t *testing.T) {
+	// ハンドシェイクの後すぐに切断するピア
+	addr, handshakes, closeServer := servePeers(t, false)
+	defer closeServer()
+	m := NewManager(ManagerConfig{
+		NetType:         core.TestNetwork,
+		Peer:            PeerConfig{Nonces: NewNonceSet()},
+		ConnectInterval: 10 * time.Millisecond,
+		RetryInterval:   10 * time.Millisecond,
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	m.AddNode(addr)
+	waitFor(t, "再接続", func() bool { return 3 <= atomic.LoadInt32(handshakes) })
+
+	if err := m.RemoveNode(addr); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "切断", func() bool { return len(m.Peers()) == 0 && m.Count(ConnManual) == 0 })
+	time.Sleep(50 * time.Millisecond)
+	n := atomic.LoadInt32(handshakes)
+	time.Sleep(100 * time.Millisecond)
+	if atomic.LoadInt32(handshakes) != n {
+		t.Error("削除したアドレスに再接続しています")
+	}
+	if err := m.RemoveNode(addr); err == nil {
+		t.Error("追加されていないアドレスを削除できました")
+	}
+}
+
+func TestManagerConnectTimeout(t *testing.T) {
+	addr, _, closeServer := servePeers(t, false)
+	defer closeServer()
+	// 必ずタイムアウトする時間にする
+	m := NewManager(ManagerConfig{
+		NetType:         core.TestNetwork,
+		Peer:            PeerConfig{Nonces: NewNonceSet()},
+		ConnectTimeout:  time.Nanosecond,
+		ConnectInterval: 10 * time.Millisecond,
+		RetryInterval:   time.Hour,
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	m.AddNode(addr)
+	// タイムアウトも失敗として再接続を待つ
+	waitFor(t, "接続の失敗", func() bool {
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+		r, ok := m.retry[addr.String()]
+		return ok && r.failures == 1 && len(m.pending) == 0
+	})
+	if len(m.Peers()) != 0 {
+		t.Error("タイムアウトしたピアに接続しています")
+	}
+}
+
+func TestManagerStopDuringHandshake(t *testing.T) {
+	// 接続を受け付けるだけで何も送らないピア
+	var accepted int32
+	addr, closeListener := serve(t, func(conn net.Conn) {
+		defer conn.Close()
+		atomic.AddInt32(&accepted, 1)
+		io.Copy(io.Discard, conn)
+	})
+	defer closeListener()
+
+	m := NewManager(ManagerConfig{
+		NetType: core.TestNetwork,
+		Peer:    PeerConfig{Nonces: NewNonceSet()},
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	m.AddNode(addr)
+
+	// 接続してきて何も送らないピア
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	client, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AcceptInbound(conn.(*net.TCPConn)); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, "ハンドシェイクの途中", func() bool {
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+		return atomic.LoadInt32(&accepted) == 1 && len(m.pending) == 2
+	})
+	// ハンドシェイクのタイムアウトを待たずに止まる
+	start := time.Now()
+	m.Stop()
+	if d := time.Since(start); time.Second < d {
+		t.Errorf("停止に時間がかかっています: %v", d)
+	}
+}
+
+func TestManagerRetry(t *testing.T) {
+	m := NewManager(ManagerConfig{
+		RetryInterval:    time.Second,
+		MaxRetryInterval: 5 * time.Second,
+	})
+	for _, want := range []time.Duration{1, 2, 4, 5, 5} {
+		now := time.Now()
+		m.failed("a")
+		delay := m.retry["a"].next.Sub(now)
+		if delay < want*time.Second || (want+1)*time.Second < delay {
+			t.Errorf("待ち時間が一致しません: %v != %v", delay, want*time.Second)
+		}
+	}
+}
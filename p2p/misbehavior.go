@@ -0,0 +1,118 @@
+package p2p
+
+import (
+	"fmt"
+
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// 不正な振る舞いの記録
+// ピアごとに不正な振る舞いの点数を加えていき、BanThresholdに達したら切断します
+// 切断したピアのIPアドレスはManagerがBanManagerで抑制します
+
+const (
+	// BanThreshold は切断する不正な振る舞いの点数
+	BanThreshold = 100
+
+	// MisbehaviorChecksum はチェックサムが一致しないメッセージの点数
+	MisbehaviorChecksum = 10
+	// MisbehaviorOversize はサイズが上限を超えるメッセージの点数
+	MisbehaviorOversize = 20
+	// MisbehaviorNonContinuousHeaders は繋がっていないブロックヘッダーを送った場合の点数
+	MisbehaviorNonContinuousHeaders = 20
+	// MisbehaviorInvalidHeader はプルーフオブワークを満たさないブロックヘッダーの点数
+	MisbehaviorInvalidHeader = 100
+	// MisbehaviorUnsolicited は要求していないブロックを送った場合の点数
+	MisbehaviorUnsolicited = 20
+)
+
+// ErrMisbehaving は不正な振る舞いの点数がBanThresholdに達して切断した場合のエラー
+var ErrMisbehaving = errors.New("ピアが不正な振る舞いをしました")
+
+// Misbehaving はピアの不正な振る舞いの点数を加えます
+// 点数がBanThresholdに達するとピアを切断してtrueを返します
+// PeerConfigのハンドラで不正なメッセージを見つけた場合にも呼び出します
+func (p *Peer) Misbehaving(howmuch int, reason string) bool {
+	p.mtx.Lock()
+	p.score += howmuch
+	if p.misbehaved || p.score < BanThreshold {
+		misbehaved := p.misbehaved
+		p.mtx.Unlock()
+		return misbehaved
+	}
+	p.misbehaved = true
+	score := p.score
+	started := p.cancel != nil
+	p.mtx.Unlock()
+
+	err := errors.Wrapf(ErrMisbehaving, "%s: score=%d", reason, score)
+	if started {
+		p.disconnect(err)
+	} else {
+		p.Close()
+	}
+	return true
+}
+
+// BanScore はピアの不正な振る舞いの点数を返します
+func (p *Peer) BanScore() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.score
+}
+
+// Misbehaved は不正な振る舞いの点数がBanThresholdに達したかどうかを返します
+func (p *Peer) Misbehaved() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.misbehaved
+}
+
+// receiveError は受信のエラーが不正な振る舞いであれば点数を加える
+// 読み飛ばして次のメッセージを読める場合はtrueを返します
+func (p *Peer) receiveError(err error) bool {
+	switch errors.Cause(err) {
+	case protocol.ErrChecksum:
+		// v1の通信ではPayloadを読み終えてからチェックサムを確かめるので次のメッセージを読めます
+		return !p.Misbehaving(MisbehaviorChecksum, err.Error())
+	case protocol.ErrOversize:
+		p.Misbehaving(MisbehaviorOversize, err.Error())
+	}
+	return false
+}
+
+// checkMessage は受け取ったメッセージが不正であれば点数を加えてfalseを返す
+// falseの場合はハンドラに渡しません
+func (p *Peer) checkMessage(msg protocol.Message) bool {
+	switch m := msg.(type) {
+	case *protocol.MsgHeaders:
+		if howmuch, reason := checkHeaders(m.Headers); howmuch != 0 {
+			p.Misbehaving(howmuch, reason)
+			return false
+		}
+	case *protocol.MsgBlock, *protocol.MsgMerkleBlock:
+		p.mtx.Lock()
+		requested := p.requests.requested(msg)
+		p.mtx.Unlock()
+		if !requested {
+			p.Misbehaving(MisbehaviorUnsolicited, fmt.Sprintf("要求していない%sを受け取りました", msg.Command()))
+			return false
+		}
+	}
+	return true
+}
+
+// checkHeaders はブロックヘッダーがプルーフオブワークを満たして繋がっているか確かめる
+// 不正な場合はその点数と理由を返します
+func checkHeaders(headers []*protocol.BlockHeader) (int, string) {
+	for i, h := range headers {
+		if err := h.CheckProofOfWork(); err != nil {
+			return MisbehaviorInvalidHeader, err.Error()
+		}
+		if 0 < i && h.PrevBlock != headers[i-1].BlockHash() {
+			return MisbehaviorNonContinuousHeaders, fmt.Sprintf("ブロックヘッダーが繋がっていません: hash=%s", h.BlockHash())
+		}
+	}
+	return 0, ""
+}
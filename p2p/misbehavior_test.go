@@ -0,0 +1,225 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// minedHeader はregtestの難易度でプルーフオブワークを満たすブロックヘッダーを返す
+func minedHeader(prev protocol.Hash) *protocol.BlockHeader {
+	h := &protocol.BlockHeader{Version: 4, PrevBlock: prev, Timestamp: protocol.Uint32Time(time.Now()), Bits: 0x207fffff}
+	for h.CheckProofOfWork() != nil {
+		h.Nonce++
+	}
+	return h
+}
+
+func TestCheckHeaders(t *testing.T) {
+	h1 := minedHeader(protocol.Hash{})
+	h2 := minedHeader(h1.BlockHash())
+	if howmuch, reason := checkHeaders([]*protocol.BlockHeader{h1, h2}); howmuch != 0 {
+		t.Errorf("正しいブロックヘッダーが不正になりました: %s", reason)
+	}
+	if howmuch, _ := checkHeaders([]*protocol.BlockHeader{h2, h1}); howmuch != MisbehaviorNonContinuousHeaders {
+		t.Errorf("点数が一致しません: %d", howmuch)
+	}
+	invalid := *h2
+	invalid.Bits = 0x1d00ffff
+	if howmuch, _ := checkHeaders([]*protocol.BlockHeader{h1, &invalid}); howmuch != MisbehaviorInvalidHeader {
+		t.Errorf("点数が一致しません: %d", howmuch)
+	}
+}
+
+func TestPeerMisbehavingChecksum(t *testing.T) {
+	out, in := connectPeers(t, PeerConfig{}, PeerConfig{})
+	defer in.Close()
+	defer out.Close()
+
+	err := errors.Wrap(protocol.ErrChecksum, "command=ping")
+	for i := 1; i < BanThreshold/MisbehaviorChecksum; i++ {
+		if !out.receiveError(err) {
+			t.Fatalf("チェックサムのエラーで読み込みをやめました: %d", i)
+		}
+	}
+	if out.BanScore() != BanThreshold-MisbehaviorChecksum || out.Misbehaved() {
+		t.Errorf("点数が一致しません: %d", out.BanScore())
+	}
+	if out.receiveError(err) || !out.Misbehaved() || out.State() != PeerDisconnected {
+		t.Errorf("点数が上限に達しても切断しません: %d %v", out.BanScore(), out.State())
+	}
+	if out.receiveError(errors.New("読み込みに失敗しました")) {
+		t.Error("その他のエラーで読み込みを続けます")
+	}
+}
+
+func TestPeerMisbehaving(t *testing.T) {
+	received := make(chan protocol.Message, 10)
+	out, in := connectPeers(t, PeerConfig{
+		OnMessage: func(p *Peer, msg protocol.Message) {
+			switch msg.(type) {
+			case *protocol.MsgHeaders, *protocol.MsgBlock:
+				received <- msg
+			}
+		},
+	}, PeerConfig{})
+	defer in.Close()
+	if err := out.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	h1 := minedHeader(protocol.Hash{})
+	h2 := minedHeader(h1.BlockHash())
+	send := func(msg protocol.Message) {
+		t.Helper()
+		if err := in.Send(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	headers := func(hs ...*protocol.BlockHeader) *protocol.MsgHeaders {
+		msg := protocol.NewMsgHeaders()
+		msg.Headers = hs
+		return msg
+	}
+
+	// 正しいブロックヘッダーはハンドラに渡す
+	send(headers(h1, h2))
+	select {
+	case msg := <-received:
+		if m, ok := msg.(*protocol.MsgHeaders); !ok || len(m.Headers) != 2 {
+			t.Fatalf("メッセージが一致しません: %v", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ブロックヘッダーを受け取れません")
+	}
+
+	// 繋がっていないブロックヘッダーと要求していないブロックはハンドラに渡さずに点数を加える
+	send(headers(h2, h1))
+	send(protocol.NewMsgBlock(&protocol.Block{Header: *h1}, protocol.WitnessEncoding))
+	send(protocol.NewMsgHeaders())
+	select {
+	case msg := <-received:
+		if m, ok := msg.(*protocol.MsgHeaders); !ok || len(m.Headers) != 0 {
+			t.Fatalf("不正なメッセージがハンドラに渡されました: %v", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ブロックヘッダーを受け取れません")
+	}
+	if score := out.BanScore(); score != MisbehaviorNonContinuousHeaders+MisbehaviorUnsolicited {
+		t.Errorf("点数が一致しません: %d", score)
+	}
+
+	// プルーフオブワークを満たさないブロックヘッダーで切断する
+	invalid := *h2
+	invalid.Bits = 0x1d00ffff
+	send(headers(h1, &invalid))
+	select {
+	case <-out.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("不正なブロックヘッダーで切断しません")
+	}
+	if err := out.Err(); errors.Cause(err) != ErrMisbehaving || !out.Misbehaved() {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+}
+
+func TestManagerBan(t *testing.T) {
+	bm, err := NewBanManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(ManagerConfig{
+		NetType:    core.TestNetwork,
+		Peer:       PeerConfig{Nonces: NewNonceSet()},
+		BanManager: bm,
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	errs := make(chan error, 2)
+	addr, closeListener := serve(t, func(conn net.Conn) {
+		errs <- m.AcceptInbound(conn.(*net.TCPConn))
+	})
+	defer closeListener()
+
+	p := NewOutboundPeer(NewConnection(addr, core.TestNetwork), PeerConfig{Nonces: NewNonceSet()})
+	if err := p.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "内向きの接続", func() bool { return m.Count(ConnInbound) == 1 })
+
+	// BANしたサブネットのピアは切断して、接続を拒否する
+	if err := m.Ban(mustParseSubnet(t, "127.0.0.0/8"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "BANしたピアの切断", func() bool { return m.Count(ConnInbound) == 0 })
+	p2 := NewOutboundPeer(NewConnection(addr, core.TestNetwork), PeerConfig{
+		Nonces:           NewNonceSet(),
+		HandshakeTimeout: time.Second,
+	})
+	if err := p2.Connect(context.Background()); err == nil {
+		t.Error("BANしたアドレスから接続できました")
+	}
+	if err := <-errs; err != ErrBanned {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+}
+
+func TestManagerDiscourage(t *testing.T) {
+	// ハンドシェイクの後に不正なブロックヘッダーを送るピア
+	handshakes := make(chan struct{}, 10)
+	addr, closeListener := serve(t, func(conn net.Conn) {
+		c, err := NewInboundConnection(context.Background(), conn.(*net.TCPConn), core.TestNetwork)
+		if err != nil {
+			conn.Close()
+			return
+		}
+		p := NewInboundPeer(c, PeerConfig{Nonces: NewNonceSet()})
+		if err := p.Handshake(context.Background()); err != nil {
+			return
+		}
+		handshakes <- struct{}{}
+		msg := protocol.NewMsgHeaders()
+		msg.Headers = []*protocol.BlockHeader{{Bits: 0x1d00ffff}}
+		p.Send(msg)
+	})
+	defer closeListener()
+
+	bm, err := NewBanManager("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(ManagerConfig{
+		NetType:               core.TestNetwork,
+		Peer:                  PeerConfig{Nonces: NewNonceSet()},
+		MaxOutboundFullRelay:  1,
+		MaxOutboundBlockRelay: -1,
+		BanManager:            bm,
+		GetAddress:            addressList([]*net.TCPAddr{addr}),
+		ConnectInterval:       10 * time.Millisecond,
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	waitFor(t, "不正なピアの抑制", func() bool { return bm.IsDiscouraged(addr.IP) })
+	waitFor(t, "不正なピアの切断", func() bool { return len(m.Peers()) == 0 })
+	// 抑制したアドレスには接続しない
+	time.Sleep(100 * time.Millisecond)
+	if n := len(handshakes); n != 1 || len(m.Peers()) != 0 {
+		t.Errorf("抑制したアドレスに接続しました: %d", n)
+	}
+}
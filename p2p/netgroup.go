@@ -0,0 +1,44 @@
+package p2p
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+)
+
+// ネットワークグループ
+// 同じ組織が管理していそうなアドレスをまとめて、接続先が偏らないようにします
+// https://github.com/bitcoin/bitcoin/blob/master/src/netgroup.cpp
+
+// NetGroup はIPアドレスが属するネットワークグループを返します
+// IPv4は/16、IPv6は/32でまとめ、ローカルのアドレスは全て同じグループにします
+// 内部用のアドレスはそれぞれ別のグループになります
+func NetGroup(ip net.IP) string {
+	if isInternal(ip) {
+		// 内部用のアドレスは名前ごとに別のグループにする
+		return "internal:" + hex.EncodeToString(ip.To16()[len(internalPrefix):])
+	}
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsPrivate() || ip.IsLinkLocalUnicast() {
+		return "local"
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(16, 32)).String() + "/16"
+	}
+	return ip.Mask(net.CIDRMask(32, 128)).String() + "/32"
+}
+
+// internalPrefix は接続先ではなくDNSシードなどの名前を表す内部用のIPv6アドレスのプレフィックス
+// Bitcoin CoreのNET_INTERNALと同じで、続く10バイトに名前のSHA256の先頭を入れます
+var internalPrefix = []byte{0xfd, 0x6b, 0x88, 0xc0, 0x87, 0x24}
+
+// internalAddress は名前から内部用のIPv6アドレスを作ります
+func internalAddress(name string) net.IP {
+	h := sha256.Sum256([]byte(name))
+	return append(append(net.IP{}, internalPrefix...), h[:net.IPv6len-len(internalPrefix)]...)
+}
+
+// isInternal は内部用のアドレスかどうかを返します
+func isInternal(ip net.IP) bool {
+	return len(ip) == net.IPv6len && bytes.HasPrefix(ip, internalPrefix)
+}
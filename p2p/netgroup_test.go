@@ -0,0 +1,27 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+)
+
+func TestNetGroup(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"1.2.3.4", "1.2.0.0/16"},
+		{"1.2.200.100", "1.2.0.0/16"},
+		{"1.3.3.4", "1.3.0.0/16"},
+		{"::ffff:1.2.3.4", "1.2.0.0/16"},
+		{"2001:db8:1234::1", "2001:db8::/32"},
+		{"127.0.0.1", "local"},
+		{"192.168.0.1", "local"},
+		{"::1", "local"},
+	}
+	for _, test := range tests {
+		if got := NetGroup(net.ParseIP(test.ip)); got != test.want {
+			t.Errorf("%s: %s != %s", test.ip, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package p2p
+
+import (
+	"sync"
+
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// DefaultOutboundQueueSize は送信待ちにできるメッセージの数
+const DefaultOutboundQueueSize = 100
+
+var (
+	// ErrQueueFull は送信待ちのメッセージが上限に達している場合のエラー
+	ErrQueueFull = errors.New("送信待ちのメッセージが多すぎます")
+	// ErrPeerDisconnected はピアが切断している場合のエラー
+	ErrPeerDisconnected = errors.New("ピアは切断しています")
+)
+
+// MessagePriority は送信の優先度を表す型、値が小さいほど先に送ります
+type MessagePriority int
+
+const (
+	// PriorityHigh はpingやpongなどの接続の維持に必要なメッセージの優先度
+	PriorityHigh MessagePriority = iota
+	// PriorityNormal は通常のメッセージの優先度
+	PriorityNormal
+	// PriorityLow はblockやtxなどの大きなデータを送るメッセージの優先度
+	PriorityLow
+
+	numPriorities = iota
+)
+
+// outboundMessage は送信待ちのメッセージ
+type outboundMessage struct {
+	msg protocol.Message
+	// 送信の結果を通知する、nilの場合は通知しない
+	done chan<- error
+}
+
+// notify は送信の結果を通知する
+// 書き込みのゴルーチンを止めないように、doneに空きがない場合は通知せずに捨てます
+func (m *outboundMessage) notify(err error) {
+	if m.done == nil {
+		return
+	}
+	select {
+	case m.done <- err:
+	default:
+	}
+}
+
+// outboundQueue は優先度ごとに先入れ先出しで送信待ちのメッセージを保持する
+// 全ての優先度を合わせた数がlimitを超えると追加できません
+type outboundQueue struct {
+	mtx     sync.Mutex
+	queues  [numPriorities][]*outboundMessage
+	size    int
+	limit   int
+	stopped bool
+	// メッセージが追加された、または停止したことを書き込み側に知らせる
+	signal chan struct{}
+}
+
+func newOutboundQueue(limit int) *outboundQueue {
+	return &outboundQueue{
+		limit:  limit,
+		signal: make(chan struct{}, 1),
+	}
+}
+
+// push はメッセージを追加する
+func (q *outboundQueue) push(m *outboundMessage, priority MessagePriority) error {
+	if priority < 0 || numPriorities <= priority {
+		return errors.Errorf("優先度が不正です: %d", priority)
+	}
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.stopped {
+		return ErrPeerDisconnected
+	}
+	if q.limit <= q.size {
+		return ErrQueueFull
+	}
+	q.queues[priority] = append(q.queues[priority], m)
+	q.size++
+	q.wake()
+	return nil
+}
+
+// pop は最も優先度が高いメッセージを取り出す
+func (q *outboundQueue) pop() (*outboundMessage, bool) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	for i, queue := range q.queues {
+		if len(queue) != 0 {
+			m := queue[0]
+			queue[0] = nil
+			q.queues[i] = queue[1:]
+			q.size--
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// len は送信待ちのメッセージの数を返す
+func (q *outboundQueue) len() int {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.size
+}
+
+// stop は以降のメッセージの追加を拒否する、送信待ちのメッセージはそのまま残る
+func (q *outboundQueue) stop() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.stopped = true
+	q.wake()
+}
+
+// isStopped は停止しているかどうかを返す
+func (q *outboundQueue) isStopped() bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.stopped
+}
+
+// drop は停止して送信待ちのメッセージを全て捨てる、捨てたメッセージにはerrを通知する
+func (q *outboundQueue) drop(err error) {
+	q.mtx.Lock()
+	q.stopped = true
+	queues := q.queues
+	q.queues = [numPriorities][]*outboundMessage{}
+	q.size = 0
+	q.mtx.Unlock()
+	for _, queue := range queues {
+		for _, m := range queue {
+			m.notify(err)
+		}
+	}
+}
+
+// wake は書き込み側を起こす、ロックを取った状態で呼び出す
+func (q *outboundQueue) wake() {
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
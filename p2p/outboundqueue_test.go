@@ -0,0 +1,67 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/keiji0/btcwallet/protocol"
+)
+
+func TestOutboundQueue(t *testing.T) {
+	q := newOutboundQueue(3)
+	push := func(nonce uint64, priority MessagePriority) error {
+		return q.push(&outboundMessage{msg: protocol.NewMsgPing(nonce)}, priority)
+	}
+	if err := push(1, PriorityLow); err != nil {
+		t.Fatal(err)
+	}
+	if err := push(2, PriorityNormal); err != nil {
+		t.Fatal(err)
+	}
+	if err := push(3, PriorityHigh); err != nil {
+		t.Fatal(err)
+	}
+	if err := push(4, PriorityHigh); err != ErrQueueFull {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+
+	// 優先度が高い順に取り出す
+	for _, want := range []uint64{3, 2, 1} {
+		m, ok := q.pop()
+		if !ok {
+			t.Fatal("メッセージがありません")
+		}
+		if nonce := m.msg.(*protocol.MsgPing).Nonce; nonce != want {
+			t.Errorf("順番が一致しません: %d != %d", nonce, want)
+		}
+	}
+	if _, ok := q.pop(); ok {
+		t.Error("空のキューから取り出せました")
+	}
+
+	// 捨てたメッセージにはエラーを通知する
+	done := make(chan error, 1)
+	if err := q.push(&outboundMessage{msg: protocol.NewMsgPing(5), done: done}, PriorityNormal); err != nil {
+		t.Fatal(err)
+	}
+	q.drop(ErrPeerDisconnected)
+	if err := <-done; err != ErrPeerDisconnected {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+	if err := push(6, PriorityNormal); err != ErrPeerDisconnected {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+}
+
+func TestOutboundMessageNotify(t *testing.T) {
+	// バッファのないdoneや受け取られないdoneでも通知で止まらない
+	unbuffered := make(chan error)
+	(&outboundMessage{msg: protocol.NewMsgPing(1), done: unbuffered}).notify(ErrPeerDisconnected)
+
+	done := make(chan error, 1)
+	m := &outboundMessage{msg: protocol.NewMsgPing(2), done: done}
+	m.notify(nil)
+	m.notify(ErrPeerDisconnected)
+	if err := <-done; err != nil {
+		t.Errorf("最初の結果が通知されていません: %v", err)
+	}
+}
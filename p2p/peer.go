@@ -0,0 +1,719 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// ピアとのversionとverackのハンドシェイク
+// https://en.bitcoin.it/wiki/Version_Handshake
+
+const (
+	// DefaultHandshakeTimeout はハンドシェイクが完了するまで待つ時間
+	DefaultHandshakeTimeout = 60 * time.Second
+	// DefaultWriteTimeout はメッセージの書き込みが完了するまで待つ時間
+	DefaultWriteTimeout = 30 * time.Second
+	// MinPeerVersion は接続を受け入れるピアのプロトコルの最小のバージョン
+	MinPeerVersion = protocol.BIP37Version
+)
+
+var (
+	// ErrSelfConnection は自分自身に接続した場合のエラー
+	ErrSelfConnection = errors.New("自分自身に接続しています")
+	// ErrHandshakeTimeout はハンドシェイクが時間内に完了しなかった場合のエラー
+	ErrHandshakeTimeout = errors.New("ハンドシェイクがタイムアウトしました")
+	// ErrObsoleteVersion はピアのプロトコルのバージョンが古すぎる場合のエラー
+	ErrObsoleteVersion = errors.New("ピアのプロトコルのバージョンが古すぎます")
+)
+
+// PeerState はピアとの接続の状態を表す型
+type PeerState int
+
+const (
+	// PeerNew は接続する前の状態
+	PeerNew PeerState = iota
+	// PeerConnecting はTCPで接続している状態
+	PeerConnecting
+	// PeerHandshaking はversionとverackを交換している状態
+	PeerHandshaking
+	// PeerEstablished はハンドシェイクが完了してメッセージを送受信できる状態
+	PeerEstablished
+	// PeerDisconnected は切断した状態
+	PeerDisconnected
+)
+
+func (s PeerState) String() string {
+	switch s {
+	case PeerNew:
+		return "new"
+	case PeerConnecting:
+		return "connecting"
+	case PeerHandshaking:
+		return "handshaking"
+	case PeerEstablished:
+		return "established"
+	case PeerDisconnected:
+		return "disconnected"
+	default:
+		return "unknown"
+	}
+}
+
+// NonceSet は自分が送ったversionのNonceを保持して自分自身への接続を検出する型
+type NonceSet struct {
+	mtx    sync.Mutex
+	nonces map[uint64]bool
+}
+
+// NewNonceSet は空のNonceSetを生成します
+func NewNonceSet() *NonceSet {
+	return &NonceSet{nonces: map[uint64]bool{}}
+}
+
+// defaultNonces はPeerConfigで指定しない場合に全てのピアで共有するNonceSet
+var defaultNonces = NewNonceSet()
+
+// generate は重複しないNonceを生成して記録する
+func (s *NonceSet) generate() (uint64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for {
+		nonce, err := randomNonce()
+		if err != nil {
+			return 0, err
+		}
+		if !s.nonces[nonce] {
+			s.nonces[nonce] = true
+			return nonce, nil
+		}
+	}
+}
+
+// randomNonce は0以外のランダムなNonceを生成する
+func randomNonce() (uint64, error) {
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, errors.Wrap(err, "Nonceの生成に失敗しました")
+		}
+		if nonce := binary.LittleEndian.Uint64(b[:]); nonce != 0 {
+			return nonce, nil
+		}
+	}
+}
+
+func (s *NonceSet) contains(nonce uint64) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.nonces[nonce]
+}
+
+func (s *NonceSet) remove(nonce uint64) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.nonces, nonce)
+}
+
+// PeerConfig はピアとのハンドシェイクで自身について通知する内容の設定
+type PeerConfig struct {
+	// 自身が提供するサービス
+	Services protocol.ServiceFlags
+	// ユーザーエージェント、空の場合はprotocol.DefaultUserAgent
+	UserAgent string
+	// 自身が持っているブロックの高さ
+	StartHeight int32
+	// ピアからトランザクションのinvを受け取るかどうか
+	Relay bool
+	// ハンドシェイクのタイムアウト、0の場合はDefaultHandshakeTimeout
+	HandshakeTimeout time.Duration
+	// 自分自身への接続の検出に使うNonceSet、nilの場合は全てのピアで共有するもの
+	Nonces *NonceSet
+	// 状態が変わった時に呼ばれる
+	OnStateChange func(p *Peer, from, to PeerState)
+	// 外向きの接続の種類、指定しない場合はConnOutboundFullRelay
+	ConnType ConnType
+
+	// メッセージの書き込みのタイムアウト、0の場合はDefaultWriteTimeout
+	WriteTimeout time.Duration
+	// 送信待ちにできるメッセージの数、0の場合はDefaultOutboundQueueSize
+	OutboundQueueSize int
+	// コマンド名ごとのメッセージのハンドラ、読み込むゴルーチンから順番に呼ばれる
+	Handlers map[string]MessageHandler
+	// Handlersに無いメッセージのハンドラ
+	OnMessage MessageHandler
+
+	// pingを送る間隔、0の場合はDefaultPingInterval
+	PingInterval time.Duration
+	// pongを待つ時間、0の場合はDefaultPingTimeout
+	PingTimeout time.Duration
+	// getdataとgetheadersの応答を待つ時間、0の場合はDefaultStallTimeout
+	StallTimeout time.Duration
+}
+
+// MessageHandler はピアから受け取ったメッセージを処理する関数
+type MessageHandler func(p *Peer, msg protocol.Message)
+
+// Peer はハンドシェイクを済ませたピアとの接続を表す型
+type Peer struct {
+	conn    *Connection
+	cfg     PeerConfig
+	inbound bool
+	nonce   uint64
+
+	mtx   sync.Mutex
+	state PeerState
+
+	// ピアのversionの内容
+	remote *protocol.MsgVersion
+	// ピアが要求した機能
+	wtxidRelay  bool
+	sendAddrV2  bool
+	sendHeaders bool
+
+	// pingとpongの状態と応答を待っている要求
+	ping     pingState
+	requests *requestTracker
+	// 不正な振る舞いの点数と、BanThresholdに達したかどうか
+	score      int
+	misbehaved bool
+
+	// 送信待ちのメッセージ
+	queue *outboundQueue
+	// 読み書きのゴルーチンを止める、Startするまではnil
+	cancel context.CancelFunc
+	// 切断した原因
+	disconnected bool
+	err          error
+	finishOnce   sync.Once
+	done         chan struct{}
+}
+
+// NewOutboundPeer は自分から接続するピアを生成します
+func NewOutboundPeer(conn *Connection, cfg PeerConfig) *Peer {
+	return newPeer(conn, cfg, false)
+}
+
+// NewInboundPeer は接続してきたピアを生成します
+func NewInboundPeer(conn *Connection, cfg PeerConfig) *Peer {
+	return newPeer(conn, cfg, true)
+}
+
+func newPeer(conn *Connection, cfg PeerConfig, inbound bool) *Peer {
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = protocol.DefaultUserAgent
+	}
+	if cfg.HandshakeTimeout == 0 {
+		cfg.HandshakeTimeout = DefaultHandshakeTimeout
+	}
+	if cfg.Nonces == nil {
+		cfg.Nonces = defaultNonces
+	}
+	if cfg.WriteTimeout == 0 {
+		cfg.WriteTimeout = DefaultWriteTimeout
+	}
+	if cfg.OutboundQueueSize == 0 {
+		cfg.OutboundQueueSize = DefaultOutboundQueueSize
+	}
+	if inbound {
+		cfg.ConnType = ConnInbound
+	} else if cfg.ConnType == ConnInbound {
+		cfg.ConnType = ConnOutboundFullRelay
+	}
+	if cfg.PingInterval == 0 {
+		cfg.PingInterval = DefaultPingInterval
+	}
+	if cfg.PingTimeout == 0 {
+		cfg.PingTimeout = DefaultPingTimeout
+	}
+	if cfg.StallTimeout == 0 {
+		cfg.StallTimeout = DefaultStallTimeout
+	}
+	p := &Peer{
+		conn:     conn,
+		cfg:      cfg,
+		inbound:  inbound,
+		state:    PeerNew,
+		requests: newRequestTracker(),
+		queue:    newOutboundQueue(cfg.OutboundQueueSize),
+		done:     make(chan struct{}),
+	}
+	if inbound {
+		p.state = PeerConnecting
+	}
+	return p
+}
+
+// State はピアとの接続の状態を返します
+func (p *Peer) State() PeerState {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.state
+}
+
+// setState は状態を変更してOnStateChangeを呼び出す
+func (p *Peer) setState(state PeerState) {
+	p.mtx.Lock()
+	from := p.state
+	p.state = state
+	p.mtx.Unlock()
+	if from != state && p.cfg.OnStateChange != nil {
+		p.cfg.OnStateChange(p, from, state)
+	}
+}
+
+// Inbound はピアから接続してきたかどうかを返します
+func (p *Peer) Inbound() bool {
+	return p.inbound
+}
+
+// Addr はピアのアドレスを返します
+func (p *Peer) Addr() *net.TCPAddr {
+	return p.conn.Addr()
+}
+
+// ConnType はピアとの接続の種類を返します
+func (p *Peer) ConnType() ConnType {
+	return p.cfg.ConnType
+}
+
+// Connection はピアとのコネクションを返します
+func (p *Peer) Connection() *Connection {
+	return p.conn
+}
+
+// ProtocolVersion はピアと合意したプロトコルのバージョンを返します
+func (p *Peer) ProtocolVersion() protocol.Version {
+	return p.conn.ProtocolVersion()
+}
+
+// RemoteVersion はピアから受け取ったversionを返します
+// ハンドシェイクが完了するまではnilを返します
+func (p *Peer) RemoteVersion() *protocol.MsgVersion {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.remote
+}
+
+// Services はピアが提供するサービスを返します
+func (p *Peer) Services() protocol.ServiceFlags {
+	if v := p.RemoteVersion(); v != nil {
+		return v.Services
+	}
+	return 0
+}
+
+// UserAgent はピアのユーザーエージェントを返します
+func (p *Peer) UserAgent() string {
+	if v := p.RemoteVersion(); v != nil {
+		return string(v.UserAgent)
+	}
+	return ""
+}
+
+// StartHeight はハンドシェイクの時点でピアが持っていたブロックの高さを返します
+func (p *Peer) StartHeight() int32 {
+	if v := p.RemoteVersion(); v != nil {
+		return v.StartHeight
+	}
+	return 0
+}
+
+// WantsWTxIDRelay はピアがwtxidでトランザクションを通知するよう要求したかどうかを返します(BIP339)
+func (p *Peer) WantsWTxIDRelay() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.wtxidRelay
+}
+
+// WantsAddrV2 はピアがaddrv2でアドレスを通知するよう要求したかどうかを返します(BIP155)
+func (p *Peer) WantsAddrV2() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.sendAddrV2
+}
+
+// WantsHeaders はピアがheadersで新しいブロックを通知するよう要求したかどうかを返します(BIP130)
+func (p *Peer) WantsHeaders() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.sendHeaders
+}
+
+// Connect はピアに接続してハンドシェイクをします
+// ctxがキャンセルされると接続やハンドシェイクを中断します
+func (p *Peer) Connect(ctx context.Context) error {
+	if p.inbound {
+		return errors.New("接続してきたピアにはConnectできません")
+	}
+	p.setState(PeerConnecting)
+	if err := p.conn.Connect(ctx); err != nil {
+		p.finish()
+		return err
+	}
+	return p.Handshake(ctx)
+}
+
+// Handshake はversionとverackを交換して機能のネゴシエーションをします
+// 自分から接続した場合は先にversionを送り、接続してきた場合はversionを受け取ってから送ります
+// 失敗した場合やctxがキャンセルされた場合は接続を閉じます
+func (p *Peer) Handshake(ctx context.Context) (err error) {
+	p.setState(PeerHandshaking)
+	defer func() {
+		if err != nil {
+			p.Close()
+		}
+	}()
+
+	p.conn.SetDeadline(time.Now().Add(p.cfg.HandshakeTimeout))
+	defer p.conn.SetDeadline(time.Time{})
+
+	stop := closeOnCancel(ctx, p.conn)
+	err = p.handshake()
+	if stop() {
+		return errors.Wrap(ctx.Err(), "ハンドシェイクを中断しました")
+	}
+	if err != nil {
+		if ne, ok := errors.Cause(err).(net.Error); ok && ne.Timeout() {
+			return errors.Wrap(ErrHandshakeTimeout, err.Error())
+		}
+		return err
+	}
+
+	// sendheadersはverackの後に送る
+	if protocol.SendHeadersVersion <= p.conn.ProtocolVersion() {
+		if err := p.conn.Send(&protocol.MsgSendHeaders{}); err != nil {
+			return err
+		}
+	}
+	p.setState(PeerEstablished)
+	return nil
+}
+
+func (p *Peer) handshake() error {
+	nonce, err := p.cfg.Nonces.generate()
+	if err != nil {
+		return err
+	}
+	p.nonce = nonce
+	defer p.cfg.Nonces.remove(nonce)
+
+	if !p.inbound {
+		if err := p.sendVersion(); err != nil {
+			return err
+		}
+	}
+
+	var gotVersion, gotVerAck bool
+	for !gotVersion || !gotVerAck {
+		msg, err := p.conn.Receive()
+		if err != nil {
+			return err
+		}
+		switch m := msg.(type) {
+		case *protocol.MsgVersion:
+			if gotVersion {
+				return errors.New("versionを2回受け取りました")
+			}
+			gotVersion = true
+			if err := p.handleVersion(m); err != nil {
+				return err
+			}
+		case *protocol.MsgVerAck:
+			if !gotVersion {
+				return errors.New("versionより前にverackを受け取りました")
+			}
+			gotVerAck = true
+		case *protocol.MsgWTxIDRelay, *protocol.MsgSendAddrV2:
+			// verackより前にしか送れない
+			if !gotVersion || gotVerAck {
+				return errors.Errorf("%sを送れるのはversionとverackの間だけです", msg.Command())
+			}
+			p.handleFeature(msg)
+		case *protocol.MsgSendHeaders:
+			p.handleFeature(msg)
+		default:
+			// ハンドシェイクが終わるまでの他のメッセージは無視する
+		}
+	}
+	return nil
+}
+
+// sendVersion は自身のversionを送る
+func (p *Peer) sendVersion() error {
+	remote := p.conn.Addr()
+	addrRecv := protocol.NetAddress{IP: remote.IP, Port: protocol.NetPort(remote.Port)}
+	addrFrom := protocol.NetAddress{Services: p.cfg.Services, IP: net.IPv4zero}
+	msg := protocol.NewMsgVersion(&addrRecv, &addrFrom, p.nonce, p.cfg.StartHeight)
+	msg.Services = p.cfg.Services
+	msg.UserAgent = protocol.UserAgentName(p.cfg.UserAgent)
+	msg.Relay = p.cfg.Relay
+	return p.conn.Send(msg)
+}
+
+// handleVersion はピアのversionを検証して記録し、機能のネゴシエーションとverackを送る
+func (p *Peer) handleVersion(m *protocol.MsgVersion) error {
+	if p.inbound && p.cfg.Nonces.contains(m.Nonce) {
+		return ErrSelfConnection
+	}
+	if m.ProtocolVersion < MinPeerVersion {
+		return errors.Wrapf(ErrObsoleteVersion, "version=%d", m.ProtocolVersion)
+	}
+
+	p.mtx.Lock()
+	p.remote = m
+	p.mtx.Unlock()
+	p.conn.NegotiateVersion(m.ProtocolVersion)
+
+	if p.inbound {
+		if err := p.sendVersion(); err != nil {
+			return err
+		}
+	}
+	// wtxidrelayとsendaddrv2はverackより前に送る
+	if protocol.WTxIDRelayVersion <= p.conn.ProtocolVersion() {
+		if err := p.conn.Send(&protocol.MsgWTxIDRelay{}); err != nil {
+			return err
+		}
+		if err := p.conn.Send(&protocol.MsgSendAddrV2{}); err != nil {
+			return err
+		}
+	}
+	return p.conn.Send(&protocol.MsgVerAck{})
+}
+
+// handleFeature はピアが要求した機能を記録する
+func (p *Peer) handleFeature(msg protocol.Message) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	switch msg.(type) {
+	case *protocol.MsgWTxIDRelay:
+		p.wtxidRelay = true
+	case *protocol.MsgSendAddrV2:
+		p.sendAddrV2 = true
+	case *protocol.MsgSendHeaders:
+		p.sendHeaders = true
+	}
+}
+
+// Send はピアにメッセージを送ります
+// Startした後はQueueMessageを使います
+func (p *Peer) Send(msg protocol.Message) error {
+	return p.conn.Send(msg)
+}
+
+// Receive はピアからメッセージを受け取ります
+// ハンドシェイクの後に届いたsendheadersはここで記録します
+func (p *Peer) Receive() (protocol.Message, error) {
+	msg, err := p.conn.Receive()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := msg.(*protocol.MsgSendHeaders); ok {
+		p.handleFeature(msg)
+	}
+	return msg, nil
+}
+
+// Close はピアとの接続を閉じます
+// Startしている場合は送信待ちのメッセージを捨てて、読み書きのゴルーチンが終わるまで待ちます
+func (p *Peer) Close() error {
+	if p.isStarted() {
+		p.disconnect(nil)
+		<-p.done
+		return nil
+	}
+	if p.State() == PeerDisconnected {
+		return nil
+	}
+	err := p.conn.Close()
+	p.finish()
+	return err
+}
+
+// finish は送信待ちのメッセージを捨てて切断した状態にする
+func (p *Peer) finish() {
+	p.finishOnce.Do(func() {
+		p.queue.drop(ErrPeerDisconnected)
+		p.setState(PeerDisconnected)
+		close(p.done)
+	})
+}
+
+// Start はメッセージを読み込むゴルーチンと書き込むゴルーチン、接続を維持するゴルーチンを開始します
+// ハンドシェイクが完了してから呼び出します、ctxがキャンセルされると切断します
+// 開始した後はSendとReceiveを使わずに、QueueMessageとPeerConfigのハンドラでやりとりします
+func (p *Peer) Start(ctx context.Context) error {
+	p.mtx.Lock()
+	if p.state != PeerEstablished || p.cancel != nil {
+		p.mtx.Unlock()
+		return errors.Errorf("ピアを開始できません: %v", p.state)
+	}
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.mtx.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		p.readLoop()
+	}()
+	go func() {
+		defer wg.Done()
+		p.writeLoop(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		p.keepaliveLoop(ctx)
+	}()
+	go func() {
+		<-ctx.Done()
+		p.disconnect(ctx.Err())
+		// 読み込みで止まっているゴルーチンを起こす
+		p.conn.Close()
+		wg.Wait()
+		p.finish()
+	}()
+	return nil
+}
+
+// Shutdown は新しいメッセージの追加をやめて、送信待ちのメッセージを全て送ってから切断します
+// 送り終えると送信側を閉じて、ピアが接続を閉じるまで待ちます
+// それまでにctxがキャンセルされた場合は残りを捨てて切断し、ctxのエラーを返します
+func (p *Peer) Shutdown(ctx context.Context) error {
+	if !p.isStarted() {
+		return p.Close()
+	}
+	p.queue.stop()
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.disconnect(ctx.Err())
+		<-p.done
+		return ctx.Err()
+	}
+}
+
+// Done は切断して読み書きのゴルーチンが終わった時に閉じるチャンネルを返します
+func (p *Peer) Done() <-chan struct{} {
+	return p.done
+}
+
+// Err は切断した原因を返します
+// Closeやキャンセルではなくエラーで切断した場合にそのエラーを返します
+func (p *Peer) Err() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.err
+}
+
+// QueueMessage はメッセージを送信待ちに追加します
+// doneを指定すると送信の結果が通知されます
+// 通知は待たずに送るので、doneにはバッファを持たせます、空きがない場合の結果は捨てられます
+func (p *Peer) QueueMessage(msg protocol.Message, priority MessagePriority, done chan<- error) error {
+	return p.queue.push(&outboundMessage{msg: msg, done: done}, priority)
+}
+
+// QueuedMessages は送信待ちのメッセージの数を返します
+func (p *Peer) QueuedMessages() int {
+	return p.queue.len()
+}
+
+func (p *Peer) isStarted() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.cancel != nil
+}
+
+// disconnect は切断した原因を記録して読み書きのゴルーチンを止める
+// 最初に呼ばれた時の原因だけを記録します
+func (p *Peer) disconnect(err error) {
+	p.mtx.Lock()
+	if !p.disconnected {
+		p.disconnected = true
+		if err != context.Canceled {
+			p.err = err
+		}
+	}
+	cancel := p.cancel
+	p.mtx.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// readLoop はメッセージを読み込んでハンドラを呼び出す
+// pingには自動で応答し、不正なメッセージはハンドラに渡さずに点数を加えます
+func (p *Peer) readLoop() {
+	for {
+		msg, err := p.Receive()
+		if err != nil {
+			if p.receiveError(err) {
+				continue
+			}
+			p.disconnect(err)
+			return
+		}
+		valid := p.checkMessage(msg)
+		p.onReceived(msg, time.Now())
+		if !valid {
+			continue
+		}
+		if handler, ok := p.cfg.Handlers[msg.Command()]; ok {
+			handler(p, msg)
+		} else if p.cfg.OnMessage != nil {
+			p.cfg.OnMessage(p, msg)
+		}
+	}
+}
+
+// writeLoop は送信待ちのメッセージを優先度の順に書き込む
+func (p *Peer) writeLoop(ctx context.Context) {
+	for {
+		m, ok := p.queue.pop()
+		if !ok {
+			// Shutdownで止めた場合は全て送り終わったので送信側を閉じる
+			// すぐに切断すると受信していないデータがあった場合にRSTで送ったデータが捨てられる
+			if p.queue.isStopped() {
+				if err := p.conn.CloseWrite(); err != nil {
+					p.disconnect(err)
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.queue.signal:
+				continue
+			}
+		}
+		if ctx.Err() != nil {
+			m.notify(ErrPeerDisconnected)
+			return
+		}
+		err := p.write(m.msg)
+		if err == nil {
+			p.onSent(m.msg, time.Now())
+		}
+		m.notify(err)
+		if err != nil {
+			p.disconnect(err)
+			return
+		}
+	}
+}
+
+// write は書き込みのタイムアウトを設定してメッセージを送る
+func (p *Peer) write(msg protocol.Message) error {
+	if err := p.conn.SetWriteDeadline(time.Now().Add(p.cfg.WriteTimeout)); err != nil {
+		return err
+	}
+	return p.conn.Send(msg)
+}
@@ -0,0 +1,383 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// acceptPeer はループバックで待ち受けて、接続してきたピアとハンドシェイクした結果をchに送る
+func acceptPeer(t *testing.T, cfg PeerConfig) (*net.TCPAddr, <-chan *Peer, <-chan error) {
+	l, err := net.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	peers := make(chan *Peer, 1)
+	errs := make(chan error, 1)
+	go func() {
+		defer l.Close()
+		tcp, err := l.AcceptTCP()
+		if err != nil {
+			errs <- err
+			return
+		}
+		conn, err := NewInboundConnection(context.Background(), tcp, core.TestNetwork)
+		if err != nil {
+			errs <- err
+			return
+		}
+		p := NewInboundPeer(conn, cfg)
+		if err := p.Handshake(context.Background()); err != nil {
+			errs <- err
+			return
+		}
+		peers <- p
+	}()
+	return l.Addr().(*net.TCPAddr), peers, errs
+}
+
+func TestPeerHandshake(t *testing.T) {
+	addr, peers, errs := acceptPeer(t, PeerConfig{
+		Services:    protocol.NodeNetwork,
+		UserAgent:   "/inbound:0.1/",
+		StartHeight: 200,
+		Nonces:      NewNonceSet(),
+	})
+
+	var mtx sync.Mutex
+	var states []PeerState
+	p := NewOutboundPeer(NewConnection(addr, core.TestNetwork), PeerConfig{
+		UserAgent:   "/outbound:0.1/",
+		StartHeight: 100,
+		Nonces:      NewNonceSet(),
+		OnStateChange: func(p *Peer, from, to PeerState) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			states = append(states, to)
+		},
+	})
+	if err := p.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	var in *Peer
+	select {
+	case in = <-peers:
+	case err := <-errs:
+		t.Fatal(err)
+	}
+	defer in.Close()
+
+	if p.State() != PeerEstablished || in.State() != PeerEstablished {
+		t.Errorf("状態が一致しません: %v, %v", p.State(), in.State())
+	}
+	if p.ProtocolVersion() != protocol.CurrentVersion {
+		t.Errorf("バージョンが一致しません: %d", p.ProtocolVersion())
+	}
+	if p.UserAgent() != "/inbound:0.1/" || p.StartHeight() != 200 || p.Services() != protocol.NodeNetwork {
+		t.Errorf("ピアの情報が一致しません: %v", p.RemoteVersion())
+	}
+	if in.UserAgent() != "/outbound:0.1/" || in.StartHeight() != 100 || !in.Inbound() {
+		t.Errorf("ピアの情報が一致しません: %v", in.RemoteVersion())
+	}
+	if !p.WantsWTxIDRelay() || !p.WantsAddrV2() || !in.WantsWTxIDRelay() || !in.WantsAddrV2() {
+		t.Error("wtxidrelayとsendaddrv2が記録されていません")
+	}
+
+	// sendheadersはハンドシェイクの後に届く
+	if err := in.Send(protocol.NewMsgPing(1)); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		msg, err := p.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := msg.(*protocol.MsgPing); ok {
+			break
+		}
+	}
+	if !p.WantsHeaders() {
+		t.Error("sendheadersが記録されていません")
+	}
+
+	p.Close()
+	mtx.Lock()
+	defer mtx.Unlock()
+	want := []PeerState{PeerConnecting, PeerHandshaking, PeerEstablished, PeerDisconnected}
+	if len(states) != len(want) {
+		t.Fatalf("状態の遷移が一致しません: %v", states)
+	}
+	for i := range want {
+		if states[i] != want[i] {
+			t.Errorf("状態の遷移が一致しません: %v", states)
+		}
+	}
+}
+
+func TestPeerSelfConnection(t *testing.T) {
+	nonces := NewNonceSet()
+	addr, _, errs := acceptPeer(t, PeerConfig{Nonces: nonces})
+
+	p := NewOutboundPeer(NewConnection(addr, core.TestNetwork), PeerConfig{Nonces: nonces})
+	if err := p.Connect(context.Background()); err == nil {
+		t.Error("自分自身への接続が成功しました")
+	}
+	if err := <-errs; errors.Cause(err) != ErrSelfConnection {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+	if p.State() != PeerDisconnected {
+		t.Errorf("状態が一致しません: %v", p.State())
+	}
+}
+
+func TestPeerHandshakeTimeout(t *testing.T) {
+	// 接続を受け付けるだけで何も送らないピア
+	addr, closeListener := serve(t, func(conn net.Conn) {
+		defer conn.Close()
+		time.Sleep(time.Second)
+	})
+	defer closeListener()
+
+	p := NewOutboundPeer(NewConnection(addr, core.TestNetwork), PeerConfig{
+		HandshakeTimeout: 100 * time.Millisecond,
+		Nonces:           NewNonceSet(),
+	})
+	if err := p.Connect(context.Background()); errors.Cause(err) != ErrHandshakeTimeout {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+}
+
+// connectPeers はハンドシェイクを済ませた自分から接続したピアと接続してきたピアを返す
+func connectPeers(t *testing.T, outCfg, inCfg PeerConfig) (*Peer, *Peer) {
+	inCfg.Nonces = NewNonceSet()
+	addr, peers, errs := acceptPeer(t, inCfg)
+	outCfg.Nonces = NewNonceSet()
+	out := NewOutboundPeer(NewConnection(addr, core.TestNetwork), outCfg)
+	if err := out.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case in := <-peers:
+		return out, in
+	case err := <-errs:
+		t.Fatal(err)
+	}
+	return nil, nil
+}
+
+func TestPeerMessageLoop(t *testing.T) {
+	// pingには自動で応答する
+	pongs := make(chan uint64, 10)
+	out, in := connectPeers(t, PeerConfig{
+		Handlers: map[string]MessageHandler{
+			"pong": func(p *Peer, msg protocol.Message) {
+				// 接続の維持のために自動で送ったpingのpongは除く
+				if nonce := msg.(*protocol.MsgPong).Nonce; nonce <= 3 {
+					pongs <- nonce
+				}
+			},
+		},
+	}, PeerConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := out.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 3)
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		if err := out.QueueMessage(protocol.NewMsgPing(nonce), PriorityNormal, done); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case got := <-pongs:
+			if got != nonce {
+				t.Errorf("pongが一致しません: %d != %d", got, nonce)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("pongが届きません")
+		}
+	}
+
+	// 相手が切断するとエラーで終わる
+	in.Close()
+	select {
+	case <-out.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("切断されません")
+	}
+	if out.Err() == nil {
+		t.Error("切断の原因が記録されていません")
+	}
+	if out.State() != PeerDisconnected {
+		t.Errorf("状態が一致しません: %v", out.State())
+	}
+	if err := out.QueueMessage(protocol.NewMsgPing(4), PriorityNormal, nil); err != ErrPeerDisconnected {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+}
+
+func TestPeerCancel(t *testing.T) {
+	out, in := connectPeers(t, PeerConfig{}, PeerConfig{})
+	defer in.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := out.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	select {
+	case <-out.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("キャンセルしても切断されません")
+	}
+	if err := out.Err(); err != nil {
+		t.Errorf("エラーが記録されています: %v", err)
+	}
+}
+
+func TestPeerShutdown(t *testing.T) {
+	received := make(chan uint64, 10)
+	out, in := connectPeers(t, PeerConfig{}, PeerConfig{
+		Handlers: map[string]MessageHandler{
+			"ping": func(p *Peer, msg protocol.Message) {
+				if nonce := msg.(*protocol.MsgPing).Nonce; nonce <= 5 {
+					received <- nonce
+				}
+			},
+		},
+	})
+	defer in.Close()
+	if err := in.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// 開始前に追加したメッセージもShutdownで全て送る
+	for nonce := uint64(1); nonce <= 5; nonce++ {
+		if err := out.QueueMessage(protocol.NewMsgPing(nonce), PriorityNormal, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := out.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := out.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for nonce := uint64(1); nonce <= 5; nonce++ {
+		select {
+		case got := <-received:
+			if got != nonce {
+				t.Errorf("pingが一致しません: %d != %d", got, nonce)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("pingが届きません")
+		}
+	}
+}
+
+func TestPeerPingStats(t *testing.T) {
+	out, in := connectPeers(t, PeerConfig{PingInterval: 50 * time.Millisecond}, PeerConfig{})
+	defer out.Close()
+	defer in.Close()
+	if err := in.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := out.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for out.PingStats().PingTime == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("pongまでの時間が記録されません")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	stats := out.PingStats()
+	if stats.MinPing == 0 || stats.PingTime < stats.MinPing {
+		t.Errorf("統計が不正です: %+v", stats)
+	}
+}
+
+func TestPeerPingTimeout(t *testing.T) {
+	// 接続してきた側は開始しないのでpingに応答しない
+	out, in := connectPeers(t, PeerConfig{PingTimeout: 100 * time.Millisecond}, PeerConfig{})
+	defer in.Close()
+	if err := out.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-out.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("pongが返ってこなくても切断されません")
+	}
+	if err := out.Err(); errors.Cause(err) != ErrPingTimeout {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+}
+
+func TestPeerStall(t *testing.T) {
+	// getheadersにheadersで応答するピア
+	out, in := connectPeers(t, PeerConfig{StallTimeout: 200 * time.Millisecond}, PeerConfig{
+		Handlers: map[string]MessageHandler{
+			"getheaders": func(p *Peer, msg protocol.Message) {
+				p.QueueMessage(protocol.NewMsgHeaders(), PriorityNormal, nil)
+			},
+		},
+	})
+	defer in.Close()
+	if err := in.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := out.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	if err := out.QueueMessage(protocol.NewMsgGetHeaders(protocol.BlockLocator{}, protocol.Hash{}), PriorityNormal, done); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for out.PendingRequests() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("headersを受け取っても要求が残っています")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// 応答の無いgetdataは期限を過ぎると切断する
+	getData := protocol.NewMsgGetData()
+	getData.AddInvVect(protocol.NewInvVect(protocol.InvTypeBlock, protocol.Hash{1}))
+	if err := out.QueueMessage(getData, PriorityNormal, nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-out.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("要求したデータが届かなくても切断されません")
+	}
+	if err := out.Err(); errors.Cause(err) != ErrStalled {
+		t.Errorf("エラーが一致しません: %v", err)
+	}
+}
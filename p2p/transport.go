@@ -0,0 +1,102 @@
+package p2p
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/p2p/bip324"
+	"github.com/keiji0/btcwallet/protocol"
+)
+
+// Transport はピアとメッセージを送受信する方式のインターフェース
+// 平文のv1とBIP324で暗号化するv2を実装します
+type Transport interface {
+	// メッセージを合意したプロトコルのバージョンで送信する
+	WriteMessage(msg protocol.Message, pver protocol.Version) error
+	// メッセージを合意したプロトコルのバージョンで受信する
+	ReadMessage(pver protocol.Version) (protocol.Message, error)
+}
+
+// v1Transport はメッセージヘッダーを付けて平文で送受信する
+type v1Transport struct {
+	r       io.Reader
+	w       io.Writer
+	netType core.NetworkType
+}
+
+// NewV1Transport は平文のv1のプロトコルで送受信するTransportを生成します
+func NewV1Transport(rw io.ReadWriter, netType core.NetworkType) Transport {
+	return &v1Transport{r: rw, w: rw, netType: netType}
+}
+
+func (t *v1Transport) WriteMessage(msg protocol.Message, pver protocol.Version) error {
+	return protocol.Send(t.w, t.netType, pver, msg)
+}
+
+func (t *v1Transport) ReadMessage(pver protocol.Version) (protocol.Message, error) {
+	return protocol.Receive(t.r, t.netType, pver)
+}
+
+// v2Transport はBIP324のパケットで暗号化して送受信する
+type v2Transport struct {
+	t *bip324.Transport
+}
+
+// NewV2Transport はBIP324のハンドシェイクをしてv2のプロトコルで送受信するTransportを生成します
+// ピアがv2に対応していない場合はbip324.ErrNoV2Supportが返るので、接続し直してv1を使います
+func NewV2Transport(rw io.ReadWriter, netType core.NetworkType, initiating bool) (Transport, error) {
+	magic, err := protocol.NetworkTypeMessageMagic(netType)
+	if err != nil {
+		return nil, err
+	}
+	t := bip324.NewTransport(rw, magic.Bytes(), initiating)
+	if err := t.Handshake(); err != nil {
+		return nil, err
+	}
+	return &v2Transport{t: t}, nil
+}
+
+// AcceptTransport は接続してきたピアとのTransportを生成します
+// ピアがv1のversionメッセージを送ってきた場合は読み込んだバイト列を戻してv1で受信します
+func AcceptTransport(rw io.ReadWriter, netType core.NetworkType) (Transport, error) {
+	magic, err := protocol.NetworkTypeMessageMagic(netType)
+	if err != nil {
+		return nil, err
+	}
+	t := bip324.NewTransport(rw, magic.Bytes(), false)
+	if err := t.Handshake(); err == bip324.ErrV1Peer {
+		return &v1Transport{
+			r:       io.MultiReader(bytes.NewReader(t.ReceivedPrefix()), rw),
+			w:       rw,
+			netType: netType,
+		}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &v2Transport{t: t}, nil
+}
+
+func (t *v2Transport) WriteMessage(msg protocol.Message, pver protocol.Version) error {
+	payload, err := protocol.EncodePayload(msg, pver)
+	if err != nil {
+		return err
+	}
+	contents, err := bip324.EncodeContents(msg.Command(), payload)
+	if err != nil {
+		return err
+	}
+	return t.t.WritePacket(contents)
+}
+
+func (t *v2Transport) ReadMessage(pver protocol.Version) (protocol.Message, error) {
+	contents, err := t.t.ReadPacket()
+	if err != nil {
+		return nil, err
+	}
+	command, payload, err := bip324.DecodeContents(contents)
+	if err != nil {
+		return nil, err
+	}
+	return protocol.DecodePayload(command, payload, pver)
+}
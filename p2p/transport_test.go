@@ -0,0 +1,190 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// serve はループバックで待ち受けて、接続ごとにhandleを呼び出す
+func serve(t *testing.T, handle func(conn net.Conn)) (*net.TCPAddr, func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go handle(conn)
+		}
+	}()
+	return l.Addr().(*net.TCPAddr), func() { l.Close() }
+}
+
+// echoPing はpingを受け取ってpongを返すピア
+func echoPing(transport Transport) error {
+	msg, err := transport.ReadMessage(protocol.CurrentVersion)
+	if err != nil {
+		return err
+	}
+	ping, ok := msg.(*protocol.MsgPing)
+	if !ok {
+		return errors.Errorf("pingではありません: %v", msg)
+	}
+	return transport.WriteMessage(protocol.NewMsgPong(ping.Nonce), protocol.CurrentVersion)
+}
+
+func pingPong(t *testing.T, conn *Connection) {
+	if err := conn.Send(protocol.NewMsgPing(7)); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := conn.Receive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pong, ok := msg.(*protocol.MsgPong); !ok || pong.Nonce != 7 {
+		t.Errorf("pongが一致しません: %v", msg)
+	}
+}
+
+func TestConnectionV2(t *testing.T) {
+	addr, closeListener := serve(t, func(conn net.Conn) {
+		defer conn.Close()
+		transport, err := AcceptTransport(conn, core.MainNetwork)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if _, ok := transport.(*v2Transport); !ok {
+			t.Errorf("v2で接続されていません")
+		}
+		if err := echoPing(transport); err != nil {
+			t.Error(err)
+		}
+	})
+	defer closeListener()
+
+	conn := NewConnection(addr, core.MainNetwork)
+	conn.EnableV2(true)
+	if err := conn.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if !conn.IsV2() {
+		t.Errorf("v2で接続されていません")
+	}
+	pingPong(t, conn)
+}
+
+func TestV2TransportUnknownShortID(t *testing.T) {
+	addr, closeListener := serve(t, func(conn net.Conn) {
+		defer conn.Close()
+		transport, err := AcceptTransport(conn, core.MainNetwork)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		// 未定義の短縮IDのメッセージを送ってもピアは切断しない
+		if err := transport.(*v2Transport).t.WritePacket([]byte{0xff, 1, 2}); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := echoPing(transport); err != nil {
+			t.Error(err)
+		}
+	})
+	defer closeListener()
+
+	conn := NewConnection(addr, core.MainNetwork)
+	conn.EnableV2(true)
+	if err := conn.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	msg, err := conn.Receive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unknown, ok := msg.(*protocol.UnknownMessage); !ok || !bytes.Equal(unknown.Payload, []byte{1, 2}) {
+		t.Errorf("UnknownMessageではありません: %v", msg)
+	}
+	pingPong(t, conn)
+}
+
+func TestAcceptTransportV1(t *testing.T) {
+	addr, closeListener := serve(t, func(conn net.Conn) {
+		defer conn.Close()
+		transport, err := AcceptTransport(conn, core.MainNetwork)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		// 判定のために読んだversionのヘッダーも含めて受信できる
+		msg, err := transport.ReadMessage(protocol.CurrentVersion)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if _, ok := msg.(*protocol.MsgVersion); !ok {
+			t.Errorf("versionではありません: %v", msg)
+		}
+		if err := echoPing(transport); err != nil {
+			t.Error(err)
+		}
+	})
+	defer closeListener()
+
+	conn := NewConnection(addr, core.MainNetwork)
+	if err := conn.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if conn.IsV2() {
+		t.Errorf("v1で接続されていません")
+	}
+	na := protocol.NetAddress{IP: addr.IP, Port: protocol.NetPort(addr.Port)}
+	if err := conn.Send(protocol.NewMsgVersion(&na, &na, 0, 0)); err != nil {
+		t.Fatal(err)
+	}
+	pingPong(t, conn)
+}
+
+func TestConnectionV1Fallback(t *testing.T) {
+	// v1だけに対応したピアはマジックが一致しないと切断する
+	magic, _ := protocol.NetworkTypeMessageMagic(core.MainNetwork)
+	addr, closeListener := serve(t, func(conn net.Conn) {
+		defer conn.Close()
+		header := make([]byte, 24)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		if m := magic.Bytes(); !bytes.Equal(header[:4], m[:]) {
+			return
+		}
+		transport := &v1Transport{r: io.MultiReader(bytes.NewReader(header), conn), w: conn, netType: core.MainNetwork}
+		if err := echoPing(transport); err != nil {
+			t.Error(err)
+		}
+	})
+	defer closeListener()
+
+	conn := NewConnection(addr, core.MainNetwork)
+	conn.EnableV2(true)
+	if err := conn.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if conn.IsV2() {
+		t.Errorf("v1で接続し直していません")
+	}
+	pingPong(t, conn)
+}
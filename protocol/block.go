@@ -0,0 +1,197 @@
+package protocol
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// ブロックのデータ構造
+// https://en.bitcoin.it/wiki/Protocol_documentation#block
+
+// MaxBlockWeight はブロックの最大weight(BIP141)
+const MaxBlockWeight = 4000000
+
+// minTxSize はトランザクションの最小のバイトサイズ
+// Version(4) + 入力数(1) + TxIn(41) + 出力数(1) + TxOut(9) + LockTime(4)
+const minTxSize = 60
+
+// witnessCommitmentHeader はコインベースに含めるウィットネスコミットメントの出力スクリプトの先頭
+// OP_RETURN PUSH36 0xaa21a9ed
+var witnessCommitmentHeader = []byte{0x6a, 0x24, 0xaa, 0x21, 0xa9, 0xed}
+
+// Block はブロックを表す型
+type Block struct {
+	Header       BlockHeader
+	Transactions []*Tx
+}
+
+// BlockHash はブロックのハッシュを返します
+func (b *Block) BlockHash() Hash {
+	return b.Header.BlockHash()
+}
+
+// Serialize はブロックをシリアライズします
+func (b *Block) Serialize(w io.Writer) error {
+	return b.serialize(w, WitnessEncoding)
+}
+
+// SerializeNoWitness はウィットネスを含めずにブロックをシリアライズします
+func (b *Block) SerializeNoWitness(w io.Writer) error {
+	return b.serialize(w, BaseEncoding)
+}
+
+// serialize はブロックを指定した形式でシリアライズする
+// トランザクションをウィットネスの形式に合わせて1つずつ書くため、タグを使わずに書いています
+func (b *Block) serialize(w io.Writer, encoding MessageEncoding) error {
+	if err := b.Header.Serialize(w); err != nil {
+		return err
+	}
+	if err := Serialize(w, VarUint(len(b.Transactions))); err != nil {
+		return err
+	}
+	for _, tx := range b.Transactions {
+		var err error
+		if encoding == BaseEncoding {
+			err = tx.SerializeNoWitness(w)
+		} else {
+			err = tx.Serialize(w)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize はブロックをデシリアライズします
+// トランザクションは1つずつrから読み込むので、rがネットワークでもブロック全体をバッファする必要はありません
+func (b *Block) Deserialize(r io.Reader) error {
+	if err := b.Header.Deserialize(r); err != nil {
+		return err
+	}
+	var count VarUint
+	if err := Deserialize(r, &count); err != nil {
+		return err
+	}
+	if messageMaxSize/minTxSize < count {
+		return errors.Errorf("トランザクションの数が多すぎます: count=%d", count)
+	}
+	b.Transactions = make([]*Tx, 0, preallocCount(count))
+	for i := VarUint(0); i < count; i++ {
+		tx := &Tx{}
+		if err := tx.Deserialize(r); err != nil {
+			return errors.Wrapf(err, "トランザクションの読み込みに失敗しました: index=%d", i)
+		}
+		b.Transactions = append(b.Transactions, tx)
+	}
+	return nil
+}
+
+// CheckMerkleRoot はブロックヘッダーのマークルルートがトランザクションと一致するか検証します
+func (b *Block) CheckMerkleRoot() error {
+	hashes := make([]Hash, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		hashes[i] = tx.TxHash()
+	}
+	root, mutated := calcMerkleRoot(hashes)
+	if root != b.Header.MerkleRoot {
+		return errors.Errorf("マークルルートが一致しません: %s != %s", root, b.Header.MerkleRoot)
+	}
+	// 末尾のトランザクションを複製しても同じマークルルートになるブロックを拒否する(CVE-2012-2459)
+	if mutated {
+		return errors.New("トランザクションが重複しています")
+	}
+	return nil
+}
+
+// WitnessMerkleRoot はwtxidのマークルルートを計算します
+// コインベースのwtxidは0として計算します
+func (b *Block) WitnessMerkleRoot() Hash {
+	hashes := make([]Hash, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		if i != 0 {
+			hashes[i] = tx.WitnessHash()
+		}
+	}
+	return CalcMerkleRoot(hashes)
+}
+
+// CheckWitnessCommitment はコインベースのウィットネスコミットメントを検証します(BIP141)
+// ウィットネスを持つトランザクションがない場合はコミットメントがなくても問題ありません
+// https://github.com/bitcoin/bips/blob/master/bip-0141.mediawiki#commitment-structure
+func (b *Block) CheckWitnessCommitment() error {
+	if len(b.Transactions) == 0 {
+		return errors.New("コインベースがありません")
+	}
+	coinbase := b.Transactions[0]
+
+	// コミットメントは条件に合う最後の出力を使う
+	var commitment []byte
+	for _, out := range coinbase.TxOut {
+		if len(witnessCommitmentHeader)+HashSize <= len(out.PkScript) && bytes.HasPrefix(out.PkScript, witnessCommitmentHeader) {
+			commitment = out.PkScript[len(witnessCommitmentHeader) : len(witnessCommitmentHeader)+HashSize]
+		}
+	}
+
+	if commitment == nil {
+		for _, tx := range b.Transactions {
+			if tx.HasWitness() {
+				return errors.New("ウィットネスを持つトランザクションがあるのにコミットメントがありません")
+			}
+		}
+		return nil
+	}
+
+	if len(coinbase.TxIn) != 1 || len(coinbase.TxIn[0].Witness) != 1 || len(coinbase.TxIn[0].Witness[0]) != HashSize {
+		return errors.New("コインベースのウィットネスの予約値が不正です")
+	}
+	root := b.WitnessMerkleRoot()
+	expected := DoubleHash(append(root[:], coinbase.TxIn[0].Witness[0]...))
+	if !bytes.Equal(expected[:], commitment) {
+		return errors.Errorf("ウィットネスコミットメントが一致しません: %x != %x", expected, commitment)
+	}
+	return nil
+}
+
+// CalcMerkleRoot はハッシュの一覧からマークルルートを計算します
+// 各段の要素数が奇数の場合は最後の要素を複製します
+func CalcMerkleRoot(hashes []Hash) Hash {
+	root, _ := calcMerkleRoot(hashes)
+	return root
+}
+
+// calcMerkleRoot はマークルルートを計算し、いずれかの段で同じハッシュの組があったかどうかを返す
+// Bitcoin CoreのComputeMerkleRootのmutatedと同じ判定です
+func calcMerkleRoot(hashes []Hash) (root Hash, mutated bool) {
+	if len(hashes) == 0 {
+		return Hash{}, false
+	}
+	level := append([]Hash{}, hashes...)
+	for 1 < len(level) {
+		// 複製する前の組だけを調べる
+		for i := 0; i+1 < len(level); i += 2 {
+			if level[i] == level[i+1] {
+				mutated = true
+			}
+		}
+		if len(level)%2 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+		next := make([]Hash, 0, len(level)/2)
+		for i := 0; i < len(level); i += 2 {
+			next = append(next, hashMerkleBranches(level[i], level[i+1]))
+		}
+		level = next
+	}
+	return level[0], mutated
+}
+
+// hashMerkleBranches は2つのハッシュを連結したハッシュを計算する
+func hashMerkleBranches(left, right Hash) Hash {
+	var buf [HashSize * 2]byte
+	copy(buf[:HashSize], left[:])
+	copy(buf[HashSize:], right[:])
+	return DoubleHash(buf[:])
+}
@@ -0,0 +1,155 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// BIP143のネイティブP2WPKHの例の署名済みトランザクション
+// https://github.com/bitcoin/bips/blob/master/bip-0143.mediawiki#native-p2wpkh
+const witnessTx = "01000000000102fff7f7881a8099afa6940d42d1e7f6362bec38171ea3edf433541db4e4ad969f00000000494830450221008b9d1dc26ba6a9cb62127b02742fa9d754cd3bebf337f7a55d114c8e5cdd30be022040529b194ba3f9281a99f2b1c0a19c0489bc22ede944ccf4ecbab4cc618ef3ed01eeffffffef51e1b804cc89d182d279655c3aa89e815b1b309fe287d9b2b55d57b90ec68a0100000000ffffffff02202cb206000000001976a9148280b37df378db99f66f85c95a783a76ac7a6d5988ac9093510d000000001976a9143bde42dbee7e4dbe6a21b2d50ce2f0167faa815988ac000247304402203609e17b84f6a7d30c80bfa610b5b4542f32a8a0d5447a12fb1366d7f01cc44a0220573a954c4518331561406f90300e8f3358f51928d43c212a8caed02de67eebee0121025476c2e83188368da1ff3e292e7acafcdb3566bb0ad253f62fc70f07aeee635711000000"
+
+func TestWitnessTx(t *testing.T) {
+	raw, _ := hex.DecodeString(witnessTx)
+
+	tx := &Tx{}
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		t.Fatal(err)
+	}
+	if !tx.HasWitness() || len(tx.TxIn[0].Witness) != 0 || len(tx.TxIn[1].Witness) != 2 {
+		t.Fatalf("ウィットネスが読み込まれていません")
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tx.Serialize(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, buf.Bytes()) {
+		t.Errorf("シリアライズしたトランザクションが一致しません")
+	}
+
+	// txidはウィットネスを除いたものから計算する
+	stripped := &bytes.Buffer{}
+	if err := NewMsgTx(tx, BaseEncoding).Serialize(stripped, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	if tx.TxHash() != DoubleHash(stripped.Bytes()) {
+		t.Errorf("txidがウィットネスを除いたハッシュと一致しません")
+	}
+	if tx.WitnessHash() != DoubleHash(raw) || tx.TxHash() == tx.WitnessHash() {
+		t.Errorf("wtxidが一致しません")
+	}
+	if tx.Weight() != stripped.Len()*3+len(raw) {
+		t.Errorf("weightが一致しません: %d", tx.Weight())
+	}
+}
+
+func TestGenesisBlock(t *testing.T) {
+	raw, _ := hex.DecodeString(genesisBlockHeader + "01" + genesisCoinbaseTx)
+
+	msg := &MsgBlock{}
+	if err := msg.Deserialize(bytes.NewReader(raw), CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	if len(msg.Block.Transactions) != 1 {
+		t.Fatalf("トランザクションの数が一致しません: %d", len(msg.Block.Transactions))
+	}
+	if err := msg.Block.CheckMerkleRoot(); err != nil {
+		t.Error(err)
+	}
+	if err := msg.Block.CheckWitnessCommitment(); err != nil {
+		t.Error(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := msg.Serialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, buf.Bytes()) {
+		t.Errorf("シリアライズしたブロックが一致しません")
+	}
+}
+
+func TestCalcMerkleRoot(t *testing.T) {
+	a, b, c := Hash{0x01}, Hash{0x02}, Hash{0x03}
+	ab := hashMerkleBranches(a, b)
+	cc := hashMerkleBranches(c, c)
+	tests := []struct {
+		hashes   []Hash
+		expected Hash
+	}{
+		{[]Hash{a}, a},
+		{[]Hash{a, b}, ab},
+		{[]Hash{a, b, c}, hashMerkleBranches(ab, cc)},
+	}
+	for _, test := range tests {
+		if root := CalcMerkleRoot(test.hashes); root != test.expected {
+			t.Errorf("マークルルートが一致しません: %d, %s", len(test.hashes), root)
+		}
+	}
+}
+
+func TestCheckMerkleRootMutated(t *testing.T) {
+	raw, _ := hex.DecodeString(genesisCoinbaseTx)
+	newTx := func(lockTime uint32) *Tx {
+		tx := &Tx{}
+		if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+			t.Fatal(err)
+		}
+		tx.LockTime = lockTime
+		return tx
+	}
+	a, b, c := newTx(1), newTx(2), newTx(3)
+	block := &Block{Transactions: []*Tx{a, b, c}}
+	block.Header.MerkleRoot = CalcMerkleRoot([]Hash{a.TxHash(), b.TxHash(), c.TxHash()})
+	if err := block.CheckMerkleRoot(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 最後のトランザクションを複製しても同じマークルルートになるが拒否する(CVE-2012-2459)
+	mutated := &Block{Header: block.Header, Transactions: []*Tx{a, b, c, c}}
+	if CalcMerkleRoot([]Hash{a.TxHash(), b.TxHash(), c.TxHash(), c.TxHash()}) != block.Header.MerkleRoot {
+		t.Fatal("複製したブロックのマークルルートが一致しません")
+	}
+	if err := mutated.CheckMerkleRoot(); err == nil {
+		t.Error("トランザクションを複製したブロックが検証を通りました")
+	}
+}
+
+func TestWitnessCommitment(t *testing.T) {
+	raw, _ := hex.DecodeString(witnessTx)
+	tx := &Tx{}
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		t.Fatal(err)
+	}
+
+	reserved := make([]byte, HashSize)
+	coinbase := NewTx()
+	coinbase.AddTxIn(&TxIn{
+		PreviousOutPoint: OutPoint{Index: 0xffffffff},
+		SignatureScript:  []byte{0x01, 0x01},
+		Sequence:         MaxTxInSequenceNum,
+		Witness:          TxWitness{reserved},
+	})
+	block := &Block{Transactions: []*Tx{coinbase, tx}}
+
+	root := block.WitnessMerkleRoot()
+	commitment := DoubleHash(append(root[:], reserved...))
+	coinbase.AddTxOut(&TxOut{PkScript: append(append([]byte{}, witnessCommitmentHeader...), commitment[:]...)})
+	if err := block.CheckWitnessCommitment(); err != nil {
+		t.Error(err)
+	}
+
+	// ウィットネスを書き換えるとコミットメントが一致しなくなる
+	tx.TxIn[1].Witness[0][10] ^= 0xff
+	if err := block.CheckWitnessCommitment(); err == nil {
+		t.Errorf("ウィットネスを書き換えてもコミットメントの検証に成功しました")
+	}
+
+	// コミットメントがないのにウィットネスを持つトランザクションがある
+	coinbase.TxOut = nil
+	if err := block.CheckWitnessCommitment(); err == nil {
+		t.Errorf("コミットメントがないのに検証に成功しました")
+	}
+}
@@ -0,0 +1,97 @@
+package protocol
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// ブロックヘッダーのデータ構造
+// https://en.bitcoin.it/wiki/Protocol_documentation#Block_Headers
+
+// BlockHeaderSize はブロックヘッダーのバイトサイズ
+const BlockHeaderSize = 80
+
+// BlockHeader はブロックヘッダーを表す型
+type BlockHeader struct {
+	// ブロックのバージョン
+	Version int32
+	// 前のブロックのハッシュ
+	PrevBlock Hash
+	// ブロックに含まれるトランザクションのマークルルート
+	MerkleRoot Hash
+	// ブロックが作られた時刻
+	Timestamp Uint32Time
+	// 採掘難易度をコンパクト形式で表したもの
+	Bits uint32
+	// 採掘で変更する値
+	Nonce uint32
+}
+
+// BlockHash はブロックヘッダーのハッシュを計算します
+func (h *BlockHeader) BlockHash() Hash {
+	buf := bytes.NewBuffer(make([]byte, 0, BlockHeaderSize))
+	// bytes.Bufferへの書き込みは失敗しない
+	_ = h.Serialize(buf)
+	return DoubleHash(buf.Bytes())
+}
+
+// Target はBitsが表す採掘の目標値を返します
+// ブロックのハッシュを数値として見た時にこの値以下である必要があります
+func (h *BlockHeader) Target() *big.Int {
+	return CompactToBig(h.Bits)
+}
+
+// CheckProofOfWork はブロックのハッシュがBitsの目標値を満たしているか検証します
+func (h *BlockHeader) CheckProofOfWork() error {
+	target := h.Target()
+	if target.Sign() <= 0 {
+		return errors.Errorf("目標値が不正です: bits=%#x", h.Bits)
+	}
+	if HashToBig(h.BlockHash()).Cmp(target) > 0 {
+		return errors.Errorf("ブロックのハッシュが目標値を満たしていません: hash=%s, bits=%#x", h.BlockHash(), h.Bits)
+	}
+	return nil
+}
+
+// Serialize はブロックヘッダーをシリアライズします
+func (h *BlockHeader) Serialize(w io.Writer) error {
+	return BulkSerialize(w, h.Version, h.PrevBlock, h.MerkleRoot, h.Timestamp, h.Bits, h.Nonce)
+}
+
+// Deserialize はブロックヘッダーをデシリアライズします
+func (h *BlockHeader) Deserialize(r io.Reader) error {
+	return BulkDeserialize(r, &h.Version, &h.PrevBlock, &h.MerkleRoot, &h.Timestamp, &h.Bits, &h.Nonce)
+}
+
+// HashToBig はハッシュをリトルエンディアンの数値として返します
+func HashToBig(h Hash) *big.Int {
+	var r Hash
+	for i := range h {
+		r[i] = h[HashSize-1-i]
+	}
+	return new(big.Int).SetBytes(r[:])
+}
+
+// CompactToBig はコンパクト形式の数値を返します
+// 上位1バイトが指数、下位3バイトが仮数で、仮数の最上位ビットは符号になります
+// https://developer.bitcoin.org/reference/block_chain.html#target-nbits
+func CompactToBig(compact uint32) *big.Int {
+	mantissa := compact & 0x007fffff
+	negative := compact&0x00800000 != 0
+	exponent := uint(compact >> 24)
+
+	var n *big.Int
+	if exponent <= 3 {
+		n = big.NewInt(int64(mantissa >> (8 * (3 - exponent))))
+	} else {
+		n = big.NewInt(int64(mantissa))
+		n.Lsh(n, 8*(exponent-3))
+	}
+	if negative {
+		n.Neg(n)
+	}
+	return n
+}
@@ -0,0 +1,114 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// ジェネシスブロックのヘッダー
+const genesisBlockHeader = "0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a29ab5f49ffff001d1dac2b7c"
+
+func TestBlockHeader(t *testing.T) {
+	raw, _ := hex.DecodeString(genesisBlockHeader)
+
+	h := &BlockHeader{}
+	if err := h.Deserialize(bytes.NewReader(raw)); err != nil {
+		t.Fatal(err)
+	}
+	if time.Time(h.Timestamp).Unix() != 1231006505 || h.Bits != 0x1d00ffff || h.Nonce != 2083236893 {
+		t.Errorf("ブロックヘッダーの値が一致しません: %#v", h)
+	}
+	if h.MerkleRoot.String() != "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b" {
+		t.Errorf("マークルルートが一致しません: %s", h.MerkleRoot)
+	}
+	if h.BlockHash().String() != "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f" {
+		t.Errorf("ブロックのハッシュが一致しません: %s", h.BlockHash())
+	}
+
+	buf := &bytes.Buffer{}
+	if err := h.Serialize(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, buf.Bytes()) || buf.Len() != BlockHeaderSize {
+		t.Errorf("シリアライズしたブロックヘッダーが一致しません: %x", buf.Bytes())
+	}
+
+	if err := h.CheckProofOfWork(); err != nil {
+		t.Error(err)
+	}
+	h.Nonce++
+	if err := h.CheckProofOfWork(); err == nil {
+		t.Errorf("Nonceを変えてもPoWの検証に成功しました")
+	}
+}
+
+func TestCompactToBig(t *testing.T) {
+	tests := []struct {
+		compact  uint32
+		expected string
+	}{
+		{0x1d00ffff, "ffff0000000000000000000000000000000000000000000000000000"},
+		{0x05009234, "92340000"},
+		{0x04923456, "-12345600"},
+		{0x03123456, "123456"},
+		{0x02123456, "1234"},
+		{0x01003456, "0"},
+	}
+	for _, test := range tests {
+		expected, _ := new(big.Int).SetString(test.expected, 16)
+		if n := CompactToBig(test.compact); n.Cmp(expected) != 0 {
+			t.Errorf("数値が一致しません: %#x, %x != %s", test.compact, n, test.expected)
+		}
+	}
+}
+
+func TestBlockLocator(t *testing.T) {
+	chain := make([]Hash, 100)
+	for i := range chain {
+		chain[i] = Hash{byte(i)}
+	}
+
+	locator := NewBlockLocator(chain)
+	expected := []int{99, 98, 97, 96, 95, 94, 93, 92, 91, 90, 88, 84, 76, 60, 28, 0}
+	if len(locator) != len(expected) {
+		t.Fatalf("ブロックロケーターの数が一致しません: %d", len(locator))
+	}
+	for i, height := range expected {
+		if locator[i] != chain[height] {
+			t.Errorf("ブロックロケーターのハッシュが一致しません: %d, %x", height, locator[i][0])
+		}
+	}
+
+	if locator := NewBlockLocator(chain[:1]); len(locator) != 1 || locator[0] != chain[0] {
+		t.Errorf("ジェネシスブロックだけのブロックロケーターが不正です: %v", locator)
+	}
+}
+
+func TestMsgGetHeadersSerialize(t *testing.T) {
+	msg := &MsgGetHeaders{ProtocolVersion: 70016, BlockLocator: BlockLocator{{0x01}, {0x02}}, HashStop: Hash{0x03}}
+	var buf bytes.Buffer
+	if err := msg.Serialize(&buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	expected := "80110100" + "02" + "01" + zeros(31) + "02" + zeros(31) + "03" + zeros(31)
+	if hex.EncodeToString(buf.Bytes()) != expected {
+		t.Errorf("シリアライズ結果が一致しません: %x", buf.Bytes())
+	}
+
+	msg.BlockLocator = make(BlockLocator, MaxBlockLocatorsPerMsg+1)
+	if err := msg.Serialize(&bytes.Buffer{}, CurrentVersion); err == nil {
+		t.Error("ブロックロケーターの数が上限を超えてもエラーになりません")
+	}
+	payload := append([]byte{0x80, 0x11, 0x01, 0x00}, byte(MaxBlockLocatorsPerMsg+1))
+	if err := (&MsgGetHeaders{}).Deserialize(bytes.NewReader(payload), CurrentVersion); err == nil {
+		t.Error("ブロックロケーターの数が上限を超えてもエラーになりません")
+	}
+}
+
+// zeros はnバイトの0を16進数の文字列で返す
+func zeros(n int) string {
+	return hex.EncodeToString(make([]byte, n))
+}
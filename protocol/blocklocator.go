@@ -0,0 +1,26 @@
+package protocol
+
+// MaxBlockLocatorsPerMsg はブロックロケーターに含められるハッシュの最大数
+// https://github.com/bitcoin/bitcoin/blob/master/src/net_processing.cpp
+const MaxBlockLocatorsPerMsg = 101
+
+// BlockLocator は自身が持っているチェーンをピアに伝えるためのブロックハッシュの一覧
+// 先頭から最新のブロックの順に並び、古くなるほど間隔が広がります
+type BlockLocator []Hash
+
+// NewBlockLocator は高さの順に並んだチェーンのハッシュからブロックロケーターを生成します
+// 最新の10ブロックは全て含め、それ以降は間隔を倍にしながら、最後に必ずジェネシスブロックを含めます
+func NewBlockLocator(chain []Hash) BlockLocator {
+	locator := BlockLocator{}
+	step := 1
+	for height := len(chain) - 1; 0 < height; height -= step {
+		locator = append(locator, chain[height])
+		if 10 <= len(locator) {
+			step *= 2
+		}
+	}
+	if 0 < len(chain) {
+		locator = append(locator, chain[0])
+	}
+	return locator
+}
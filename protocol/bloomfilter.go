@@ -0,0 +1,241 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"sync"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/util/hash"
+)
+
+// トランザクションを絞り込むためのブルームフィルタ(BIP37)
+// https://github.com/bitcoin/bips/blob/master/bip-0037.mediawiki
+
+const (
+	// MaxFilterLoadFilterSize はfilterloadのフィルタの最大バイトサイズ
+	MaxFilterLoadFilterSize = 36000
+	// MaxFilterLoadHashFuncs はfilterloadのハッシュ関数の最大数
+	MaxFilterLoadHashFuncs = 50
+	// MaxFilterAddDataSize はfilteraddで追加できるデータの最大バイトサイズ
+	MaxFilterAddDataSize = 520
+
+	// bloomHashSeedFactor はハッシュ関数ごとのシードを作るための係数
+	bloomHashSeedFactor = 0xfba4c795
+)
+
+// BloomUpdateType はフィルタに一致した出力をフィルタに追加するかどうかを表す型
+// 追加した出力を使うトランザクションもフィルタに一致するようになります
+type BloomUpdateType uint8
+
+const (
+	// BloomUpdateNone は一致した出力を追加しない
+	BloomUpdateNone BloomUpdateType = 0
+	// BloomUpdateAll は一致した全ての出力を追加する
+	BloomUpdateAll BloomUpdateType = 1
+	// BloomUpdateP2PubKeyOnly はP2PKとベアマルチシグの出力だけを追加する
+	BloomUpdateP2PubKeyOnly BloomUpdateType = 2
+
+	// bloomUpdateMask はBloomUpdateTypeを取り出すためのマスク
+	bloomUpdateMask BloomUpdateType = 3
+)
+
+// BloomFilter はBIP37のブルームフィルタ
+// ピアがトランザクションを照合しながら出力を追加するので、同じ処理を手元でも行えるようにしています
+type BloomFilter struct {
+	mtx       sync.Mutex
+	data      []byte
+	hashFuncs uint32
+	tweak     uint32
+	flags     BloomUpdateType
+}
+
+// NewBloomFilter は要素数と偽陽性率からブルームフィルタを生成します
+// tweakはハッシュ関数のシードに加える値で、フィルタから要素を推測されにくくするために乱数を使います
+func NewBloomFilter(elements int, fpRate float64, tweak uint32, flags BloomUpdateType) *BloomFilter {
+	if elements < 1 {
+		elements = 1
+	}
+	fpRate = math.Min(math.Max(fpRate, 1e-9), 1)
+
+	// BIP37の式でフィルタのサイズとハッシュ関数の数を決める
+	size := uint32(-1 / (math.Ln2 * math.Ln2) * float64(elements) * math.Log(fpRate) / 8)
+	if MaxFilterLoadFilterSize < size {
+		size = MaxFilterLoadFilterSize
+	}
+	if size < 1 {
+		size = 1
+	}
+	hashFuncs := uint32(float64(int(size)*8/elements) * math.Ln2)
+	if MaxFilterLoadHashFuncs < hashFuncs {
+		hashFuncs = MaxFilterLoadHashFuncs
+	}
+	if hashFuncs < 1 {
+		hashFuncs = 1
+	}
+	return &BloomFilter{
+		data:      make([]byte, size),
+		hashFuncs: hashFuncs,
+		tweak:     tweak,
+		flags:     flags,
+	}
+}
+
+// LoadBloomFilter はfilterloadのメッセージからブルームフィルタを生成します
+func LoadBloomFilter(msg *MsgFilterLoad) *BloomFilter {
+	return &BloomFilter{
+		data:      append([]byte{}, msg.Filter...),
+		hashFuncs: msg.HashFuncs,
+		tweak:     msg.Tweak,
+		flags:     msg.Flags,
+	}
+}
+
+// MsgFilterLoad はピアに送るfilterloadのメッセージを生成します
+func (f *BloomFilter) MsgFilterLoad() *MsgFilterLoad {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return NewMsgFilterLoad(append([]byte{}, f.data...), f.hashFuncs, f.tweak, f.flags)
+}
+
+// Add はデータをフィルタに追加します
+func (f *BloomFilter) Add(data []byte) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.add(data)
+}
+
+// AddOutPoint は出力をフィルタに追加します
+func (f *BloomFilter) AddOutPoint(op *OutPoint) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.add(outPointBytes(op))
+}
+
+// Contains はデータがフィルタに含まれている可能性があるか判定します
+func (f *BloomFilter) Contains(data []byte) bool {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.contains(data)
+}
+
+// MatchTxAndUpdate はトランザクションがフィルタに一致するか判定します
+// 出力が一致した場合はフラグに応じてその出力をフィルタに追加します
+func (f *BloomFilter) MatchTxAndUpdate(tx *Tx) bool {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	txid := tx.TxHash()
+	matched := f.contains(txid[:])
+	for i, out := range tx.TxOut {
+		for _, data := range core.PushedData(out.PkScript) {
+			if !f.contains(data) {
+				continue
+			}
+			matched = true
+			switch f.flags & bloomUpdateMask {
+			case BloomUpdateAll:
+				f.add(outPointBytes(&OutPoint{Hash: txid, Index: uint32(i)}))
+			case BloomUpdateP2PubKeyOnly:
+				if isPubKeyScript(out.PkScript) {
+					f.add(outPointBytes(&OutPoint{Hash: txid, Index: uint32(i)}))
+				}
+			}
+			break
+		}
+	}
+	if matched {
+		return true
+	}
+
+	for _, in := range tx.TxIn {
+		if f.contains(outPointBytes(&in.PreviousOutPoint)) {
+			return true
+		}
+		for _, data := range core.PushedData(in.SignatureScript) {
+			if f.contains(data) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hash はn番目のハッシュ関数でフィルタのビットの位置を求める
+func (f *BloomFilter) hash(n uint32, data []byte) uint32 {
+	return hash.Murmur3(n*bloomHashSeedFactor+f.tweak, data) % uint32(len(f.data)*8)
+}
+
+func (f *BloomFilter) add(data []byte) {
+	if len(f.data) == 0 {
+		return
+	}
+	for i := uint32(0); i < f.hashFuncs; i++ {
+		bit := f.hash(i, data)
+		f.data[bit>>3] |= 1 << (bit & 7)
+	}
+}
+
+func (f *BloomFilter) contains(data []byte) bool {
+	if len(f.data) == 0 {
+		return true
+	}
+	for i := uint32(0); i < f.hashFuncs; i++ {
+		bit := f.hash(i, data)
+		if f.data[bit>>3]&(1<<(bit&7)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// outPointBytes はフィルタに追加するための出力のバイト列を返す
+func outPointBytes(op *OutPoint) []byte {
+	b := make([]byte, HashSize+4)
+	copy(b, op.Hash[:])
+	binary.LittleEndian.PutUint32(b[HashSize:], op.Index)
+	return b
+}
+
+// isPubKeyScript は出力スクリプトがP2PKかベアマルチシグか判定する
+func isPubKeyScript(script []byte) bool {
+	n := len(script)
+	if n == 0 {
+		return false
+	}
+	switch core.OpCode(script[n-1]) {
+	case core.OpCheckSig:
+		// <pubkey> OP_CHECKSIG
+		data := core.PushedData(script[:n-1])
+		return len(data) == 1 && isPubKeySize(len(data[0])) &&
+			bytes.Equal(core.NewScriptBuilder().AddData(data[0]).Script(), script[:n-1])
+	case core.OpCheckMultiSig:
+		// OP_m <pubkey>... OP_n OP_CHECKMULTISIG
+		if n < 3 || !isSmallInt(script[0]) || !isSmallInt(script[n-2]) {
+			return false
+		}
+		keys := core.PushedData(script[1 : n-2])
+		required, total := int(script[0]-byte(core.Op1))+1, int(script[n-2]-byte(core.Op1))+1
+		if len(keys) != total || total < required {
+			return false
+		}
+		b := core.NewScriptBuilder()
+		for _, key := range keys {
+			if !isPubKeySize(len(key)) {
+				return false
+			}
+			b.AddData(key)
+		}
+		return bytes.Equal(b.Script(), script[1:n-2])
+	}
+	return false
+}
+
+func isPubKeySize(n int) bool {
+	return n == 33 || n == 65
+}
+
+func isSmallInt(op byte) bool {
+	return byte(core.Op1) <= op && op <= byte(core.Op16)
+}
@@ -0,0 +1,95 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/keiji0/btcwallet/core"
+)
+
+func TestBloomFilter(t *testing.T) {
+	// Bitcoin Coreのbloom_tests.cppのテストベクタ
+	tests := []struct {
+		tweak    uint32
+		expected string
+	}{
+		{0, "03614e9b050000000000000001"},
+		{2147483649, "03ce4299050000000100008001"},
+	}
+	for _, test := range tests {
+		f := NewBloomFilter(3, 0.01, test.tweak, BloomUpdateAll)
+
+		data, _ := hex.DecodeString("99108ad8ed9bb6274d3980bab5a85c048f0950c8")
+		f.Add(data)
+		if !f.Contains(data) {
+			t.Errorf("追加したデータが含まれていません")
+		}
+		other, _ := hex.DecodeString("19108ad8ed9bb6274d3980bab5a85c048f0950c8")
+		if f.Contains(other) {
+			t.Errorf("追加していないデータが含まれています")
+		}
+		for _, s := range []string{"b5a2c786d9ef4658287ced5914b37a1b4aa32eee", "b9300670b4c5366e95b2699e8b18bc75e5f729c5"} {
+			data, _ := hex.DecodeString(s)
+			f.Add(data)
+		}
+
+		buf := &bytes.Buffer{}
+		if err := f.MsgFilterLoad().Serialize(buf, CurrentVersion); err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(buf.Bytes()) != test.expected {
+			t.Errorf("シリアライズしたフィルタが一致しません: %x != %s", buf.Bytes(), test.expected)
+		}
+	}
+}
+
+func TestBloomFilterMatchTx(t *testing.T) {
+	pubKey := append([]byte{0x02}, bytes.Repeat([]byte{0x11}, 32)...)
+	p2pk := core.NewScriptBuilder().AddData(pubKey).AddOp(core.OpCheckSig).Script()
+	pubKeyHash := bytes.Repeat([]byte{0x22}, 20)
+	p2pkh := core.NewScriptBuilder().AddOp(core.OpDup).AddOp(core.OpHash160).AddData(pubKeyHash).AddOp(core.OpEqualVerify).AddOp(core.OpCheckSig).Script()
+
+	funding := NewTx()
+	funding.AddTxIn(&TxIn{PreviousOutPoint: OutPoint{Hash: Hash{0x01}}})
+	funding.AddTxOut(&TxOut{Value: 1000, PkScript: p2pkh})
+	funding.AddTxOut(&TxOut{Value: 2000, PkScript: p2pk})
+
+	spendP2PKH := NewTx()
+	spendP2PKH.AddTxIn(&TxIn{PreviousOutPoint: OutPoint{Hash: funding.TxHash(), Index: 0}})
+	spendP2PK := NewTx()
+	spendP2PK.AddTxIn(&TxIn{PreviousOutPoint: OutPoint{Hash: funding.TxHash(), Index: 1}})
+
+	tests := []struct {
+		flags      BloomUpdateType
+		spendP2PKH bool
+		spendP2PK  bool
+	}{
+		{BloomUpdateNone, false, false},
+		{BloomUpdateAll, true, true},
+		{BloomUpdateP2PubKeyOnly, false, true},
+	}
+	for _, test := range tests {
+		f := NewBloomFilter(10, 0.000001, 0, test.flags)
+		f.Add(pubKeyHash)
+		f.Add(pubKey)
+		if !f.MatchTxAndUpdate(funding) {
+			t.Fatalf("出力のスクリプトのデータに一致しません: flags=%d", test.flags)
+		}
+		// 一致した出力を使うトランザクションはフラグによって一致する
+		if f.MatchTxAndUpdate(spendP2PKH) != test.spendP2PKH {
+			t.Errorf("P2PKHの出力を使うトランザクションの判定が一致しません: flags=%d", test.flags)
+		}
+		if f.MatchTxAndUpdate(spendP2PK) != test.spendP2PK {
+			t.Errorf("P2PKの出力を使うトランザクションの判定が一致しません: flags=%d", test.flags)
+		}
+	}
+
+	// txidに一致する
+	f := NewBloomFilter(1, 0.000001, 0, BloomUpdateNone)
+	txid := funding.TxHash()
+	f.Add(txid[:])
+	if !f.MatchTxAndUpdate(funding) {
+		t.Errorf("txidに一致しません")
+	}
+}
@@ -0,0 +1,568 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// 構造体のタグでシリアライズ方法を指定するコーデック
+// メッセージのフィールドを一度宣言するだけでシリアライズとデシリアライズができます
+//
+// タグは「btc」で、カンマ区切りで複数指定できます
+//   btc:"varint"       整数を可変長数値(VarUint)としてシリアライズする
+//   btc:"bigendian"    整数をビッグエンディアンでシリアライズする
+//   btc:"len=32"       バイト列を長さを付けずに固定長でシリアライズする
+//   btc:"max=1000"     スライスの要素数の上限、バイト列以外のスライスには必須
+//   btc:"minver=70001" プロトコルのバージョンがこれ以上の場合だけシリアライズする
+//   btc:"pver"         このフィールドの値を以降のフィールドのプロトコルのバージョンとして使う
+//   btc:"optional"     デシリアライズ時にデータが終わっていれば、このフィールド以降を読み込まない
+//   btc:"-"            シリアライズしない
+//
+// タグのないフィールドは型に応じてSerializeと同じ形式になります
+
+// codecTag は構造体のタグの名前
+const codecTag = "btc"
+
+// SerializeStruct は構造体のフィールドをタグに従ってシリアライズします
+// vは構造体か構造体のポインタを指定します
+func SerializeStruct(w io.Writer, pver Version, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		// フィールドのポインタを取れるようにコピーする
+		p := reflect.New(rv.Type())
+		p.Elem().Set(rv)
+		rv = p
+	}
+	if rv.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("構造体ではありません: %T", v)
+	}
+	e := &encoder{w: w, pver: pver}
+	return e.encodeStruct(rv.Elem())
+}
+
+// DeserializeStruct はタグに従ってデシリアライズした値を構造体のフィールドに読み込みます
+// vは構造体のポインタを指定します
+func DeserializeStruct(r io.Reader, pver Version, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("構造体のポインタではありません: %T", v)
+	}
+	d := &decoder{r: &countingReader{r: r}, pver: pver}
+	return d.decodeStruct(rv.Elem())
+}
+
+// fieldCodec は構造体の1つのフィールドのシリアライズ方法
+type fieldCodec struct {
+	index      int
+	name       string
+	varint     bool
+	bigEndian  bool
+	fixedLen   int
+	maxCount   int
+	minVersion Version
+	pver       bool
+	optional   bool
+}
+
+// structCodec は構造体のシリアライズ方法
+type structCodec struct {
+	fields []*fieldCodec
+}
+
+// structCodecs は型ごとのstructCodecのキャッシュ
+var structCodecs sync.Map
+
+// フィールドを辿らずにSerializeと同じ専用の形式でシリアライズする型
+var (
+	varUintType    = reflect.TypeOf(VarUint(0))
+	netPortType    = reflect.TypeOf(NetPort(0))
+	uint32TimeType = reflect.TypeOf(Uint32Time{})
+	int64TimeType  = reflect.TypeOf(Int64Time{})
+	netAddressType = reflect.TypeOf(NetAddress{})
+)
+
+// bigEndianField はビッグエンディアンでシリアライズするフィールド
+var bigEndianField = &fieldCodec{name: "port", bigEndian: true}
+
+// getStructCodec は型のstructCodecを返す、キャッシュがなければタグを解析して生成する
+func getStructCodec(t reflect.Type) (*structCodec, error) {
+	if c, ok := structCodecs.Load(t); ok {
+		return c.(*structCodec), nil
+	}
+	c := &structCodec{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get(codecTag)
+		if tag == "-" || (sf.PkgPath != "" && !sf.Anonymous) {
+			continue
+		}
+		f, err := parseFieldTag(i, sf.Name, tag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "タグの解析に失敗しました: %v.%s", t, sf.Name)
+		}
+		// 要素数の上限がないと、デシリアライズで要素を読む前に大きなスライスを確保してしまう
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Slice && ft.Elem().Kind() != reflect.Uint8 && f.maxCount <= 0 {
+			return nil, errors.Errorf("スライスの要素数の上限(max)がありません: %v.%s", t, sf.Name)
+		}
+		c.fields = append(c.fields, f)
+	}
+	structCodecs.Store(t, c)
+	return c, nil
+}
+
+// parseFieldTag はフィールドのタグを解析する
+func parseFieldTag(index int, name, tag string) (*fieldCodec, error) {
+	f := &fieldCodec{index: index, name: name}
+	if tag == "" {
+		return f, nil
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		key, value := opt, ""
+		if i := strings.IndexByte(opt, '='); 0 <= i {
+			key, value = opt[:i], opt[i+1:]
+		}
+		var err error
+		switch key {
+		case "varint":
+			f.varint = true
+		case "bigendian":
+			f.bigEndian = true
+		case "pver":
+			f.pver = true
+		case "optional":
+			f.optional = true
+		case "len":
+			f.fixedLen, err = strconv.Atoi(value)
+		case "max":
+			f.maxCount, err = strconv.Atoi(value)
+		case "minver":
+			var v int64
+			v, err = strconv.ParseInt(value, 10, 32)
+			f.minVersion = Version(v)
+		default:
+			return nil, errors.Errorf("不明なタグです: %q", opt)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "タグの値が不正です: %q", opt)
+		}
+	}
+	return f, nil
+}
+
+// encoder はタグに従ってシリアライズする
+type encoder struct {
+	w    io.Writer
+	pver Version
+	buf  [16]byte
+}
+
+func (e *encoder) encodeStruct(v reflect.Value) error {
+	c, err := getStructCodec(v.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range c.fields {
+		if e.pver < f.minVersion {
+			continue
+		}
+		fv := v.Field(f.index)
+		if err := e.encodeValue(fv, f); err != nil {
+			return err
+		}
+		if f.pver {
+			e.pver = Version(fv.Int())
+		}
+	}
+	return nil
+}
+
+func (e *encoder) encodeValue(v reflect.Value, f *fieldCodec) error {
+	t := v.Type()
+	switch t {
+	case varUintType:
+		return e.encodeVarUint(v.Uint())
+	case netPortType:
+		return e.encodeUint(v.Uint(), 2, bigEndianField)
+	case uint32TimeType:
+		p := v.Addr().Interface().(*Uint32Time)
+		return e.encodeUint(uint64(time.Time(*p).Unix()), 4, f)
+	case int64TimeType:
+		p := v.Addr().Interface().(*Int64Time)
+		return e.encodeUint(uint64(time.Time(*p).Unix()), 8, f)
+	case netAddressType:
+		p := v.Addr().Interface().(*NetAddress)
+		if err := e.encodeUint(uint64(p.Services), 8, f); err != nil {
+			return err
+		}
+		ip := e.buf[:16]
+		copy(ip, p.IP.To16())
+		if err := e.write(ip); err != nil {
+			return err
+		}
+		return e.encodeUint(uint64(p.Port), 2, bigEndianField)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		e.buf[0] = 0
+		if v.Bool() {
+			e.buf[0] = 1
+		}
+		return e.write(e.buf[:1])
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return e.encodeUint(uint64(v.Int()), int(t.Size()), f)
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return e.encodeUint(v.Uint(), int(t.Size()), f)
+
+	case reflect.String:
+		return serializeString(e.w, v.String())
+
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return e.write(v.Slice(0, v.Len()).Bytes())
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := e.encodeValue(v.Index(i), elemField); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			b := v.Bytes()
+			if 0 < f.fixedLen {
+				if len(b) != f.fixedLen {
+					return errors.Errorf("バイト列の長さが一致しません: %s, %d != %d", f.name, len(b), f.fixedLen)
+				}
+				return e.write(b)
+			}
+			return serializeBytes(e.w, b)
+		}
+		if 0 < f.maxCount && f.maxCount < v.Len() {
+			return errors.Errorf("要素の数が上限を超えています: %s, count=%d", f.name, v.Len())
+		}
+		if err := e.encodeVarUint(uint64(v.Len())); err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := e.encodeValue(v.Index(i), elemField); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return errors.Errorf("nilはシリアライズできません: %s", f.name)
+		}
+		return e.encodeValue(v.Elem(), f)
+
+	case reflect.Struct:
+		return e.encodeStruct(v)
+	}
+	return errors.Errorf("invalid type: %v", t)
+}
+
+func (e *encoder) encodeUint(v uint64, size int, f *fieldCodec) error {
+	if f.varint {
+		return e.encodeVarUint(v)
+	}
+	b := e.buf[:size]
+	var order binary.ByteOrder = defaultByteOrder
+	if f.bigEndian {
+		order = binary.BigEndian
+	}
+	switch size {
+	case 1:
+		b[0] = byte(v)
+	case 2:
+		order.PutUint16(b, uint16(v))
+	case 4:
+		order.PutUint32(b, uint32(v))
+	default:
+		order.PutUint64(b, v)
+	}
+	return e.write(b)
+}
+
+// encodeVarUint はserializeVarUintと同じ形式で可変長数値をシリアライズする
+func (e *encoder) encodeVarUint(v uint64) error {
+	switch {
+	case v <= varUint8Max:
+		e.buf[0] = byte(v)
+		return e.write(e.buf[:1])
+	case v <= math.MaxUint16:
+		e.buf[0] = varUint16Tag
+		defaultByteOrder.PutUint16(e.buf[1:], uint16(v))
+		return e.write(e.buf[:3])
+	case v <= math.MaxUint32:
+		e.buf[0] = varUint32Tag
+		defaultByteOrder.PutUint32(e.buf[1:], uint32(v))
+		return e.write(e.buf[:5])
+	default:
+		e.buf[0] = varUint64Tag
+		defaultByteOrder.PutUint64(e.buf[1:], v)
+		return e.write(e.buf[:9])
+	}
+}
+
+func (e *encoder) write(b []byte) error {
+	if _, err := e.w.Write(b); err != nil {
+		return errors.Wrap(err, "書き込みに失敗しました")
+	}
+	return nil
+}
+
+// elemField はスライスや配列の要素のシリアライズ方法
+var elemField = &fieldCodec{name: "elem"}
+
+// decoder はタグに従ってデシリアライズする
+type decoder struct {
+	r    *countingReader
+	pver Version
+	buf  [16]byte
+}
+
+func (d *decoder) decodeStruct(v reflect.Value) error {
+	c, err := getStructCodec(v.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range c.fields {
+		if d.pver < f.minVersion {
+			continue
+		}
+		fv := v.Field(f.index)
+		start := d.r.n
+		if err := d.decodeValue(fv, f); err != nil {
+			// 古いノードは後ろのフィールドを省略することがある
+			if f.optional && start == d.r.n && errors.Cause(err) == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if f.pver {
+			d.pver = Version(fv.Int())
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeValue(v reflect.Value, f *fieldCodec) error {
+	t := v.Type()
+	switch t {
+	case varUintType:
+		n, err := d.decodeVarUint()
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+	case netPortType:
+		n, err := d.decodeUint(2, bigEndianField)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+	case uint32TimeType:
+		n, err := d.decodeUint(4, f)
+		if err != nil {
+			return err
+		}
+		*v.Addr().Interface().(*Uint32Time) = Uint32Time(time.Unix(int64(n), 0))
+		return nil
+	case int64TimeType:
+		n, err := d.decodeUint(8, f)
+		if err != nil {
+			return err
+		}
+		*v.Addr().Interface().(*Int64Time) = Int64Time(time.Unix(int64(n), 0))
+		return nil
+	case netAddressType:
+		p := v.Addr().Interface().(*NetAddress)
+		services, err := d.decodeUint(8, f)
+		if err != nil {
+			return err
+		}
+		ip := make(net.IP, net.IPv6len)
+		if err := d.read(ip); err != nil {
+			return err
+		}
+		port, err := d.decodeUint(2, bigEndianField)
+		if err != nil {
+			return err
+		}
+		p.Services, p.IP, p.Port = ServiceFlags(services), ip, NetPort(port)
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if err := d.read(d.buf[:1]); err != nil {
+			return err
+		}
+		v.SetBool(d.buf[0] != 0)
+		return nil
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := d.decodeUint(int(t.Size()), f)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+		return nil
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := d.decodeUint(int(t.Size()), f)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+
+	case reflect.String:
+		var s string
+		if err := deserializeString(d.r, &s); err != nil {
+			return err
+		}
+		v.SetString(s)
+		return nil
+
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return d.read(v.Slice(0, v.Len()).Bytes())
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := d.decodeValue(v.Index(i), elemField); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			var b []byte
+			if 0 < f.fixedLen {
+				b = make([]byte, f.fixedLen)
+				if err := d.read(b); err != nil {
+					return err
+				}
+			} else if err := deserializeBytes(d.r, &b); err != nil {
+				return err
+			}
+			v.SetBytes(b)
+			return nil
+		}
+		count, err := d.decodeVarUint()
+		if err != nil {
+			return err
+		}
+		if uint64(f.maxCount) < count {
+			return errors.Errorf("要素の数が上限を超えています: %s, count=%d", f.name, count)
+		}
+		s := reflect.MakeSlice(t, int(count), int(count))
+		for i := 0; i < s.Len(); i++ {
+			if err := d.decodeValue(s.Index(i), elemField); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return d.decodeValue(v.Elem(), f)
+
+	case reflect.Struct:
+		return d.decodeStruct(v)
+	}
+	return errors.Errorf("invalid type: %v", t)
+}
+
+func (d *decoder) decodeUint(size int, f *fieldCodec) (uint64, error) {
+	if f.varint {
+		return d.decodeVarUint()
+	}
+	b := d.buf[:size]
+	if err := d.read(b); err != nil {
+		return 0, err
+	}
+	var order binary.ByteOrder = defaultByteOrder
+	if f.bigEndian {
+		order = binary.BigEndian
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(order.Uint16(b)), nil
+	case 4:
+		return uint64(order.Uint32(b)), nil
+	default:
+		return order.Uint64(b), nil
+	}
+}
+
+// decodeVarUint はdeserializeVarUintと同じ形式の可変長数値をデシリアライズする
+func (d *decoder) decodeVarUint() (uint64, error) {
+	if err := d.read(d.buf[:1]); err != nil {
+		return 0, err
+	}
+	switch d.buf[0] {
+	case varUint16Tag:
+		if err := d.read(d.buf[:2]); err != nil {
+			return 0, err
+		}
+		return uint64(defaultByteOrder.Uint16(d.buf[:])), nil
+	case varUint32Tag:
+		if err := d.read(d.buf[:4]); err != nil {
+			return 0, err
+		}
+		return uint64(defaultByteOrder.Uint32(d.buf[:])), nil
+	case varUint64Tag:
+		if err := d.read(d.buf[:8]); err != nil {
+			return 0, err
+		}
+		return defaultByteOrder.Uint64(d.buf[:]), nil
+	default:
+		return uint64(d.buf[0]), nil
+	}
+}
+
+func (d *decoder) read(b []byte) error {
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		return errors.Wrap(err, "読み込みに失敗しました")
+	}
+	return nil
+}
+
+// countingReader は読み込んだバイト数を数えるReader
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
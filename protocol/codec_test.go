@@ -0,0 +1,177 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// codecTestStruct はタグの組み合わせを確認するための構造体
+type codecTestStruct struct {
+	Version  Version  `btc:"pver"`
+	Count    uint64   `btc:"varint"`
+	Port     uint16   `btc:"bigendian"`
+	Key      []byte   `btc:"len=4"`
+	Items    []uint32 `btc:"max=2"`
+	Hash     Hash
+	internal int
+	Skip     string `btc:"-"`
+	Relay    bool   `btc:"minver=70001"`
+}
+
+func TestCodec(t *testing.T) {
+	v := &codecTestStruct{
+		Version: 70001,
+		Count:   0xfd,
+		Port:    8333,
+		Key:     []byte{0x01, 0x02, 0x03, 0x04},
+		Items:   []uint32{1, 2},
+		Hash:    Hash{0xff},
+		Relay:   true,
+	}
+	buf := &bytes.Buffer{}
+	if err := SerializeStruct(buf, 0, v); err != nil {
+		t.Fatal(err)
+	}
+	expected := "71110100" + "fdfd00" + "208d" + "01020304" + "020100000002000000" + "ff" + hex.EncodeToString(make([]byte, HashSize-1)) + "01"
+	if hex.EncodeToString(buf.Bytes()) != expected {
+		t.Errorf("シリアライズ結果が一致しません: %x", buf.Bytes())
+	}
+
+	decoded := &codecTestStruct{}
+	if err := DeserializeStruct(buf, 0, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(v, decoded) {
+		t.Errorf("デシリアライズ結果が一致しません: %#v != %#v", v, decoded)
+	}
+
+	// バージョンが古い場合はminverのフィールドを含めない
+	v.Version = 60002
+	buf.Reset()
+	if err := SerializeStruct(buf, 0, v); err != nil {
+		t.Fatal(err)
+	}
+	decoded = &codecTestStruct{}
+	if err := DeserializeStruct(buf, 0, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Relay {
+		t.Errorf("古いバージョンでminverのフィールドが読み込まれました")
+	}
+
+	// 上限や長さが不正な場合はエラー
+	v.Items = []uint32{1, 2, 3}
+	if err := SerializeStruct(ioutil.Discard, 0, v); err == nil {
+		t.Errorf("上限を超えた要素がシリアライズできました")
+	}
+	v.Items = nil
+	v.Key = []byte{0x01}
+	if err := SerializeStruct(ioutil.Discard, 0, v); err == nil {
+		t.Errorf("長さが不正なバイト列がシリアライズできました")
+	}
+
+	// 要素数の上限がないスライスは使えない
+	var noMax struct {
+		Items []uint32
+	}
+	if err := SerializeStruct(ioutil.Discard, 0, &noMax); err == nil {
+		t.Errorf("上限のないスライスがシリアライズできました")
+	}
+	if err := DeserializeStruct(bytes.NewReader([]byte{0xfe, 0xff, 0xff, 0xff, 0x01}), 0, &noMax); err == nil {
+		t.Errorf("上限のないスライスがデシリアライズできました")
+	}
+}
+
+func TestMsgVersionCompatibility(t *testing.T) {
+	addr := &NetAddress{IP: net.ParseIP("127.0.0.1"), Port: 8333}
+	msg := NewMsgVersion(addr, addr, 1, 0)
+	msg.ProtocolVersion = 60002
+	msg.Relay = true
+
+	buf := &bytes.Buffer{}
+	if err := msg.Serialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	// BulkSerializeで書いていた頃の形式からRelayを除いたもの
+	manual := &bytes.Buffer{}
+	if err := BulkSerialize(manual, msg.ProtocolVersion, msg.Services, msg.Timestamp, msg.AddrRerv, msg.AddrFrom, msg.Nonce, msg.UserAgent, msg.StartHeight); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), manual.Bytes()) {
+		t.Errorf("シリアライズ結果が一致しません: %x != %x", buf.Bytes(), manual.Bytes())
+	}
+
+	decoded := &MsgVersion{}
+	if err := decoded.Deserialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Relay || decoded.StartHeight != msg.StartHeight {
+		t.Errorf("古いバージョンのメッセージが読み込めません: %#v", decoded)
+	}
+}
+
+func newBenchmarkMsgVersion() *MsgVersion {
+	addr := &NetAddress{Services: NodeNetwork | NodeWitness, IP: net.ParseIP("192.0.2.1"), Port: 8333}
+	msg := NewMsgVersion(addr, addr, 0x0123456789abcdef, 800000)
+	msg.Timestamp = Int64Time(time.Unix(1700000000, 0))
+	return msg
+}
+
+func BenchmarkSerializeStruct(b *testing.B) {
+	msg := newBenchmarkMsgVersion()
+	buf := &bytes.Buffer{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := SerializeStruct(buf, CurrentVersion, msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBulkSerialize(b *testing.B) {
+	msg := newBenchmarkMsgVersion()
+	buf := &bytes.Buffer{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := BulkSerialize(buf, msg.ProtocolVersion, msg.Services, msg.Timestamp, msg.AddrRerv, msg.AddrFrom, msg.Nonce, msg.UserAgent, msg.StartHeight, msg.Relay); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDeserializeStruct(b *testing.B) {
+	buf := &bytes.Buffer{}
+	if err := newBenchmarkMsgVersion().Serialize(buf, CurrentVersion); err != nil {
+		b.Fatal(err)
+	}
+	data := buf.Bytes()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		msg := &MsgVersion{}
+		if err := DeserializeStruct(bytes.NewReader(data), CurrentVersion, msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBulkDeserialize(b *testing.B) {
+	buf := &bytes.Buffer{}
+	if err := newBenchmarkMsgVersion().Serialize(buf, CurrentVersion); err != nil {
+		b.Fatal(err)
+	}
+	data := buf.Bytes()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		msg := &MsgVersion{}
+		if err := BulkDeserialize(bytes.NewReader(data), &msg.ProtocolVersion, &msg.Services, &msg.Timestamp, &msg.AddrRerv, &msg.AddrFrom, &msg.Nonce, &msg.UserAgent, &msg.StartHeight, &msg.Relay); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
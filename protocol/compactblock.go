@@ -0,0 +1,218 @@
+package protocol
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/keiji0/btcwallet/util/hash"
+	"github.com/pkg/errors"
+)
+
+// コンパクトブロックのデータ構造(BIP152)
+// https://github.com/bitcoin/bips/blob/master/bip-0152.mediawiki
+
+const (
+	// ShortTxIDSize はショートトランザクションIDのバイトサイズ
+	ShortTxIDSize = 6
+
+	// maxCompactBlockTxs はコンパクトブロックに含められるトランザクションの最大数
+	// 最小のトランザクションのweight(10バイト×4)でブロックを埋めた数
+	maxCompactBlockTxs = MaxBlockWeight / 40
+	// maxCompactBlockIndex は差分で符号化するトランザクションのインデックスの最大値
+	maxCompactBlockIndex = 0xffff
+)
+
+// ErrReconstructBlock はコンパクトブロックからブロックを復元できなかった場合のエラー
+// ショートトランザクションIDの衝突などで起きるので、getdataでブロック全体を要求します
+var ErrReconstructBlock = errors.New("コンパクトブロックからブロックを復元できませんでした")
+
+// ShortTxID はSipHashで短縮したトランザクションのID
+type ShortTxID uint64
+
+// PrefilledTx はコンパクトブロックにそのまま含めるトランザクション
+type PrefilledTx struct {
+	// ブロック内のトランザクションのインデックス
+	Index uint16
+	Tx    *Tx
+}
+
+// HeaderAndShortIDs はブロックヘッダーとショートトランザクションIDでブロックを表す型
+type HeaderAndShortIDs struct {
+	Header BlockHeader
+	// ショートトランザクションIDの鍵に使う値
+	Nonce        uint64
+	ShortIDs     []ShortTxID
+	PrefilledTxs []*PrefilledTx
+}
+
+// NewHeaderAndShortIDs はブロックからコンパクトブロックを生成します
+// コインベースは受信側のメモリプールにないので常にそのまま含めます
+// useWTxIDはsendcmpctのバージョン2で合意した場合にwtxidでショートIDを計算します
+func NewHeaderAndShortIDs(block *Block, nonce uint64, useWTxID bool) *HeaderAndShortIDs {
+	h := &HeaderAndShortIDs{
+		Header: block.Header,
+		Nonce:  nonce,
+	}
+	k0, k1 := h.ShortIDKeys()
+	for i, tx := range block.Transactions {
+		if i == 0 {
+			h.PrefilledTxs = append(h.PrefilledTxs, &PrefilledTx{Index: 0, Tx: tx})
+			continue
+		}
+		h.ShortIDs = append(h.ShortIDs, NewShortTxID(k0, k1, txHashForShortID(tx, useWTxID)))
+	}
+	return h
+}
+
+// TxCount はブロックに含まれるトランザクションの数を返します
+func (h *HeaderAndShortIDs) TxCount() int {
+	return len(h.ShortIDs) + len(h.PrefilledTxs)
+}
+
+// ShortIDKeys はブロックヘッダーとNonceからSipHashの鍵を計算します
+func (h *HeaderAndShortIDs) ShortIDKeys() (k0, k1 uint64) {
+	buf := bytes.NewBuffer(make([]byte, 0, BlockHeaderSize+8))
+	// bytes.Bufferへの書き込みは失敗しない
+	_ = h.Header.Serialize(buf)
+	_ = Serialize(buf, h.Nonce)
+	sum := sha256.Sum256(buf.Bytes())
+	return binary.LittleEndian.Uint64(sum[0:8]), binary.LittleEndian.Uint64(sum[8:16])
+}
+
+// NewShortTxID はトランザクションのハッシュからショートトランザクションIDを計算します
+func NewShortTxID(k0, k1 uint64, txHash Hash) ShortTxID {
+	return ShortTxID(hash.SipHash(k0, k1, txHash[:]) & (1<<(ShortTxIDSize*8) - 1))
+}
+
+// txHashForShortID はショートIDの計算に使うトランザクションのハッシュを返す
+func txHashForShortID(tx *Tx, useWTxID bool) Hash {
+	if useWTxID {
+		return tx.WitnessHash()
+	}
+	return tx.TxHash()
+}
+
+// serialize はコンパクトブロックをシリアライズする
+// ショートIDが6バイトでPrefilledTxsのインデックスが差分になるため、タグを使わずに書いています
+func (h *HeaderAndShortIDs) serialize(w io.Writer, encoding MessageEncoding) error {
+	if maxCompactBlockTxs < h.TxCount() {
+		return errors.Errorf("トランザクションの数が多すぎます: count=%d", h.TxCount())
+	}
+	if err := h.Header.Serialize(w); err != nil {
+		return err
+	}
+	if err := Serialize(w, h.Nonce); err != nil {
+		return err
+	}
+	if err := Serialize(w, VarUint(len(h.ShortIDs))); err != nil {
+		return err
+	}
+	var buf [8]byte
+	for _, id := range h.ShortIDs {
+		binary.LittleEndian.PutUint64(buf[:], uint64(id))
+		if _, err := w.Write(buf[:ShortTxIDSize]); err != nil {
+			return errors.Wrap(err, "ショートトランザクションIDの書き込みに失敗しました")
+		}
+	}
+
+	if err := Serialize(w, VarUint(len(h.PrefilledTxs))); err != nil {
+		return err
+	}
+	indexes := make([]uint32, len(h.PrefilledTxs))
+	for i, p := range h.PrefilledTxs {
+		indexes[i] = uint32(p.Index)
+	}
+	diffs, err := differentialIndexes(indexes)
+	if err != nil {
+		return err
+	}
+	for i, p := range h.PrefilledTxs {
+		if err := Serialize(w, VarUint(diffs[i])); err != nil {
+			return err
+		}
+		if encoding == BaseEncoding {
+			err = p.Tx.SerializeNoWitness(w)
+		} else {
+			err = p.Tx.Serialize(w)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deserialize はコンパクトブロックをデシリアライズする
+func (h *HeaderAndShortIDs) deserialize(r io.Reader) error {
+	if err := h.Header.Deserialize(r); err != nil {
+		return err
+	}
+	if err := Deserialize(r, &h.Nonce); err != nil {
+		return err
+	}
+	var count VarUint
+	if err := Deserialize(r, &count); err != nil {
+		return err
+	}
+	if maxCompactBlockTxs < count {
+		return errors.Errorf("ショートトランザクションIDの数が多すぎます: count=%d", count)
+	}
+	h.ShortIDs = make([]ShortTxID, count)
+	var buf [8]byte
+	for i := range h.ShortIDs {
+		if _, err := io.ReadFull(r, buf[:ShortTxIDSize]); err != nil {
+			return errors.Wrap(err, "ショートトランザクションIDの読み込みに失敗しました")
+		}
+		h.ShortIDs[i] = ShortTxID(binary.LittleEndian.Uint64(buf[:]))
+	}
+
+	if err := Deserialize(r, &count); err != nil {
+		return err
+	}
+	if maxCompactBlockTxs-uint64(len(h.ShortIDs)) < uint64(count) {
+		return errors.Errorf("トランザクションの数が多すぎます: count=%d", uint64(count)+uint64(len(h.ShortIDs)))
+	}
+	h.PrefilledTxs = make([]*PrefilledTx, count)
+	last := -1
+	for i := range h.PrefilledTxs {
+		var diff VarUint
+		if err := Deserialize(r, &diff); err != nil {
+			return err
+		}
+		index, err := nextIndex(last, diff)
+		if err != nil {
+			return err
+		}
+		last = index
+		p := &PrefilledTx{Index: uint16(index), Tx: &Tx{}}
+		if err := p.Tx.Deserialize(r); err != nil {
+			return errors.Wrapf(err, "トランザクションの読み込みに失敗しました: index=%d", index)
+		}
+		h.PrefilledTxs[i] = p
+	}
+	return nil
+}
+
+// differentialIndexes は昇順のインデックスを直前のインデックスとの差分に変換する
+func differentialIndexes(indexes []uint32) ([]uint32, error) {
+	diffs := make([]uint32, len(indexes))
+	last := -1
+	for i, index := range indexes {
+		if int(index) <= last || maxCompactBlockIndex < index {
+			return nil, errors.Errorf("インデックスが昇順ではありません: %d", index)
+		}
+		diffs[i] = uint32(int(index) - last - 1)
+		last = int(index)
+	}
+	return diffs, nil
+}
+
+// nextIndex は直前のインデックスと差分から次のインデックスを求める
+func nextIndex(last int, diff VarUint) (int, error) {
+	if maxCompactBlockIndex < diff || maxCompactBlockIndex < last+int(diff)+1 {
+		return 0, errors.Errorf("インデックスが大きすぎます: last=%d, diff=%d", last, diff)
+	}
+	return last + int(diff) + 1, nil
+}
@@ -0,0 +1,134 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// testCompactBlock はコインベースとcount個のトランザクションを持つブロックを生成する
+func testCompactBlock(t *testing.T, count int) *Block {
+	raw, _ := hex.DecodeString(genesisCoinbaseTx)
+	coinbase := &Tx{}
+	if err := coinbase.Deserialize(bytes.NewReader(raw)); err != nil {
+		t.Fatal(err)
+	}
+	block := &Block{Transactions: []*Tx{coinbase}}
+	for i := 0; i < count; i++ {
+		tx := NewTx()
+		tx.AddTxIn(&TxIn{PreviousOutPoint: OutPoint{Hash: Hash{byte(i + 1)}}, Sequence: MaxTxInSequenceNum})
+		tx.AddTxOut(&TxOut{Value: int64(1000 * (i + 1)), PkScript: []byte{0x51}})
+		block.Transactions = append(block.Transactions, tx)
+	}
+	hashes := make([]Hash, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		hashes[i] = tx.TxHash()
+	}
+	block.Header = BlockHeader{Version: 4, MerkleRoot: CalcMerkleRoot(hashes), Timestamp: Uint32Time(time.Unix(1700000000, 0)), Bits: 0x207fffff}
+	return block
+}
+
+func TestCompactBlock(t *testing.T) {
+	block := testCompactBlock(t, 5)
+	cmpct := NewHeaderAndShortIDs(block, 0x1122334455667788, true)
+	if cmpct.TxCount() != len(block.Transactions) || len(cmpct.PrefilledTxs) != 1 {
+		t.Fatalf("コンパクトブロックのトランザクション数が一致しません: %d", cmpct.TxCount())
+	}
+
+	buf := &bytes.Buffer{}
+	if err := NewMsgCmpctBlock(cmpct, WitnessEncoding).Serialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	// ヘッダー(80) + Nonce(8) + ID数(1) + ID(6*5) + 事前に含めた数(1) + インデックス(1) + コインベース
+	if size := BlockHeaderSize + 8 + 1 + ShortTxIDSize*5 + 1 + 1 + block.Transactions[0].SerializeSize(); buf.Len() != size {
+		t.Errorf("シリアライズしたサイズが一致しません: %d != %d", buf.Len(), size)
+	}
+	msg := &MsgCmpctBlock{}
+	if err := msg.Deserialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(msg.Block, cmpct) {
+		t.Errorf("デシリアライズしたコンパクトブロックが一致しません")
+	}
+	if err := msg.Serialize(&bytes.Buffer{}, CompactBlocksVersion-1); err == nil {
+		t.Errorf("古いバージョンのピアにcmpctblockが送れてしまいます")
+	}
+}
+
+func TestGetBlockTxnIndexes(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := NewMsgGetBlockTxn(Hash{0x01}, []uint32{1, 2, 5}).Serialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	// インデックスは直前との差分-1で送る
+	if diffs := buf.Bytes()[HashSize:]; !bytes.Equal(diffs, []byte{3, 1, 0, 2}) {
+		t.Errorf("インデックスの符号化が一致しません: %x", diffs)
+	}
+
+	if err := NewMsgGetBlockTxn(Hash{}, []uint32{2, 2}).Serialize(&bytes.Buffer{}, CurrentVersion); err == nil {
+		t.Errorf("昇順でないインデックスがシリアライズできました")
+	}
+	overflow := append(make([]byte, HashSize), 2, 0xfd, 0xff, 0xff, 0x00)
+	if err := (&MsgGetBlockTxn{}).Deserialize(bytes.NewReader(overflow), CurrentVersion); err == nil {
+		t.Errorf("16bitを超えるインデックスがデシリアライズできました")
+	}
+}
+
+func TestPartialBlock(t *testing.T) {
+	block := testCompactBlock(t, 5)
+	cmpct := NewHeaderAndShortIDs(block, 42, false)
+
+	// メモリプールには2つだけあり、関係ないトランザクションも含まれる
+	unrelated := NewTx()
+	unrelated.AddTxIn(&TxIn{PreviousOutPoint: OutPoint{Hash: Hash{0xff}}})
+	mempool := []*Tx{block.Transactions[4], unrelated, block.Transactions[2]}
+
+	partial, err := NewPartialBlock(cmpct, mempool, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if partial.IsComplete() {
+		t.Fatalf("足りないトランザクションがあるのに揃っていると判定されました")
+	}
+	req := partial.NewMsgGetBlockTxn()
+	if req.BlockHash != block.BlockHash() || !reflect.DeepEqual(req.Indexes, []uint32{1, 3, 5}) {
+		t.Fatalf("要求するトランザクションが一致しません: %v", req.Indexes)
+	}
+
+	// 足りないトランザクションの順番が違うとマークルルートが一致しない
+	wrong := []*Tx{block.Transactions[3], block.Transactions[1], block.Transactions[5]}
+	if _, err := partial.FillBlock(wrong); errors.Cause(err) != ErrReconstructBlock {
+		t.Errorf("不正なブロックが復元できました: %v", err)
+	}
+
+	missing := []*Tx{block.Transactions[1], block.Transactions[3], block.Transactions[5]}
+	restored, err := partial.FillBlock(missing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(restored, block) {
+		t.Errorf("復元したブロックが一致しません")
+	}
+
+	// メモリプールに全てあれば要求せずに復元できる
+	partial, err = NewPartialBlock(cmpct, block.Transactions[1:], false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !partial.IsComplete() {
+		t.Fatalf("トランザクションが揃っていません: %v", partial.MissingIndexes())
+	}
+	if _, err := partial.FillBlock(nil); err != nil {
+		t.Error(err)
+	}
+
+	// ショートIDが重複するコンパクトブロックはブロック全体を要求する
+	cmpct.ShortIDs[1] = cmpct.ShortIDs[0]
+	if _, err := NewPartialBlock(cmpct, mempool, false); errors.Cause(err) != ErrReconstructBlock {
+		t.Errorf("ショートIDの重複が検出されませんでした: %v", err)
+	}
+}
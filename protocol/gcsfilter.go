@@ -0,0 +1,268 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"sort"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/util/hash"
+	"github.com/pkg/errors"
+)
+
+// Golomb-Rice符号で圧縮した集合(GCS)によるコンパクトブロックフィルタ(BIP158)
+// https://github.com/bitcoin/bips/blob/master/bip-0158.mediawiki
+
+// FilterType はコンパクトブロックフィルタの種類を表す型
+type FilterType uint8
+
+const (
+	// FilterTypeBasic はブロックの出力スクリプトと使用した出力のスクリプトを含む基本フィルタ
+	FilterTypeBasic FilterType = 0x00
+)
+
+const (
+	// BasicFilterP は基本フィルタのGolomb-Rice符号のパラメータ
+	BasicFilterP = 19
+	// BasicFilterM は基本フィルタの偽陽性率の逆数
+	BasicFilterM = 784931
+)
+
+// GCSFilter はGolomb-Rice符号で圧縮した集合
+type GCSFilter struct {
+	n    uint32
+	p    uint8
+	m    uint64
+	data []byte
+}
+
+// NewGCSFilter は要素の一覧からフィルタを生成します
+// keyはSipHashの鍵で、基本フィルタではブロックハッシュの先頭16バイトを使います
+func NewGCSFilter(p uint8, m uint64, key [16]byte, items [][]byte) (*GCSFilter, error) {
+	if 32 < p {
+		return nil, errors.Errorf("Golomb-Rice符号のパラメータが大きすぎます: p=%d", p)
+	}
+	if 0xffffffff < uint64(len(items)) {
+		return nil, errors.Errorf("要素の数が多すぎます: count=%d", len(items))
+	}
+	f := &GCSFilter{n: uint32(len(items)), p: p, m: m}
+	values := f.hashedValues(key, items)
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	w := &bitWriter{}
+	var last uint64
+	for _, v := range values {
+		delta := v - last
+		last = v
+		// 商は1を並べて0で終わる単進符号、余りはpビットで書き込む
+		for q := delta >> p; 0 < q; q-- {
+			w.writeBit(true)
+		}
+		w.writeBit(false)
+		w.writeBits(delta, p)
+	}
+	f.data = w.bytes()
+	return f, nil
+}
+
+// LoadGCSFilter はcfilterで受け取ったシリアライズされたフィルタを読み込みます
+func LoadGCSFilter(p uint8, m uint64, b []byte) (*GCSFilter, error) {
+	r := bytes.NewReader(b)
+	var n VarUint
+	if err := Deserialize(r, &n); err != nil {
+		return nil, errors.Wrap(err, "フィルタの要素数の読み込みに失敗しました")
+	}
+	if 0xffffffff < n {
+		return nil, errors.Errorf("フィルタの要素数が多すぎます: count=%d", n)
+	}
+	return &GCSFilter{n: uint32(n), p: p, m: m, data: b[len(b)-r.Len():]}, nil
+}
+
+// N はフィルタの要素数を返します
+func (f *GCSFilter) N() uint32 {
+	return f.n
+}
+
+// Bytes はフィルタを要素数を付けてシリアライズしたバイト列を返します
+func (f *GCSFilter) Bytes() []byte {
+	buf := &bytes.Buffer{}
+	// bytes.Bufferへの書き込みは失敗しない
+	_ = Serialize(buf, VarUint(f.n))
+	buf.Write(f.data)
+	return buf.Bytes()
+}
+
+// Hash はフィルタヘッダーの計算に使うフィルタのハッシュを返します
+func (f *GCSFilter) Hash() Hash {
+	return DoubleHash(f.Bytes())
+}
+
+// Match は要素がフィルタに含まれている可能性があるか判定します
+func (f *GCSFilter) Match(key [16]byte, item []byte) (bool, error) {
+	return f.MatchAny(key, [][]byte{item})
+}
+
+// MatchAny は要素のいずれかがフィルタに含まれている可能性があるか判定します
+func (f *GCSFilter) MatchAny(key [16]byte, items [][]byte) (bool, error) {
+	if f.n == 0 || len(items) == 0 {
+		return false, nil
+	}
+	targets := f.hashedValues(key, items)
+	sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+
+	// 両方とも昇順なので、フィルタを先頭から展開しながら突き合わせる
+	r := &bitReader{data: f.data}
+	var value uint64
+	for i := uint32(0); i < f.n; i++ {
+		delta, err := f.readDelta(r)
+		if err != nil {
+			return false, err
+		}
+		value += delta
+		for 0 < len(targets) && targets[0] < value {
+			targets = targets[1:]
+		}
+		if len(targets) == 0 {
+			return false, nil
+		}
+		if targets[0] == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// readDelta はGolomb-Rice符号で書かれた1つの差分を読み込む
+func (f *GCSFilter) readDelta(r *bitReader) (uint64, error) {
+	var q uint64
+	for {
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, errors.Wrap(err, "フィルタが途中で終わっています")
+		}
+		if !bit {
+			break
+		}
+		q++
+	}
+	rem, err := r.readBits(f.p)
+	if err != nil {
+		return 0, errors.Wrap(err, "フィルタが途中で終わっています")
+	}
+	return q<<f.p | rem, nil
+}
+
+// hashedValues は要素をSipHashで[0, N*M)の範囲の値に変換する
+func (f *GCSFilter) hashedValues(key [16]byte, items [][]byte) []uint64 {
+	k0 := binary.LittleEndian.Uint64(key[0:8])
+	k1 := binary.LittleEndian.Uint64(key[8:16])
+	nm := uint64(f.n) * f.m
+	values := make([]uint64, len(items))
+	for i, item := range items {
+		// 剰余の代わりに64bitの積の上位を使って範囲に収める
+		values[i], _ = bits.Mul64(hash.SipHash(k0, k1, item), nm)
+	}
+	return values
+}
+
+// BasicFilterKey はブロックハッシュから基本フィルタのSipHashの鍵を返します
+func BasicFilterKey(blockHash Hash) [16]byte {
+	var key [16]byte
+	copy(key[:], blockHash[:16])
+	return key
+}
+
+// NewBasicFilter はブロックから基本フィルタを生成します
+// prevOutScriptsにはコインベース以外の入力が使用した出力のスクリプトを指定します
+// 空のスクリプトとOP_RETURNの出力は含めません
+func NewBasicFilter(block *Block, prevOutScripts [][]byte) (*GCSFilter, error) {
+	seen := map[string]bool{}
+	var items [][]byte
+	add := func(script []byte) {
+		if len(script) == 0 || seen[string(script)] {
+			return
+		}
+		seen[string(script)] = true
+		items = append(items, script)
+	}
+	for _, tx := range block.Transactions {
+		for _, out := range tx.TxOut {
+			if 0 < len(out.PkScript) && core.OpCode(out.PkScript[0]) == core.OpReturn {
+				continue
+			}
+			add(out.PkScript)
+		}
+	}
+	for _, script := range prevOutScripts {
+		add(script)
+	}
+	return NewGCSFilter(BasicFilterP, BasicFilterM, BasicFilterKey(block.BlockHash()), items)
+}
+
+// FilterHeader はフィルタのハッシュと前のブロックのフィルタヘッダーからフィルタヘッダーを計算します
+// ジェネシスブロックの前のフィルタヘッダーは0になります
+func FilterHeader(filterHash, prevHeader Hash) Hash {
+	var buf [HashSize * 2]byte
+	copy(buf[:HashSize], filterHash[:])
+	copy(buf[HashSize:], prevHeader[:])
+	return DoubleHash(buf[:])
+}
+
+// bitWriter は上位ビットから順にビットを書き込む
+type bitWriter struct {
+	data []byte
+	used uint8
+}
+
+func (w *bitWriter) writeBit(bit bool) {
+	if w.used == 0 {
+		w.data = append(w.data, 0)
+		w.used = 8
+	}
+	w.used--
+	if bit {
+		w.data[len(w.data)-1] |= 1 << w.used
+	}
+}
+
+func (w *bitWriter) writeBits(v uint64, n uint8) {
+	for i := int(n) - 1; 0 <= i; i-- {
+		w.writeBit(v&(1<<uint(i)) != 0)
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	return w.data
+}
+
+// bitReader は上位ビットから順にビットを読み込む
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) readBit() (bool, error) {
+	if len(r.data)*8 <= r.pos {
+		return false, io.ErrUnexpectedEOF
+	}
+	bit := r.data[r.pos/8]&(0x80>>uint(r.pos%8)) != 0
+	r.pos++
+	return bit, nil
+}
+
+func (r *bitReader) readBits(n uint8) (uint64, error) {
+	var v uint64
+	for i := uint8(0); i < n; i++ {
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		v <<= 1
+		if bit {
+			v |= 1
+		}
+	}
+	return v, nil
+}
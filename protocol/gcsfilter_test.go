@@ -0,0 +1,88 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBasicFilterGenesis(t *testing.T) {
+	// BIP158のtestnet-19.jsonのテストネットのジェネシスブロック
+	raw, _ := hex.DecodeString(genesisCoinbaseTx)
+	coinbase := &Tx{}
+	if err := coinbase.Deserialize(bytes.NewReader(raw)); err != nil {
+		t.Fatal(err)
+	}
+	block := &Block{
+		Header: BlockHeader{
+			Version:    1,
+			MerkleRoot: coinbase.TxHash(),
+			Timestamp:  Uint32Time(time.Unix(1296688602, 0)),
+			Bits:       0x1d00ffff,
+			Nonce:      414098458,
+		},
+		Transactions: []*Tx{coinbase},
+	}
+	if hash := block.BlockHash().String(); hash != "000000000933ea01ad0ee984209779baaec3ced90fa3f408719526f8d77f4943" {
+		t.Fatalf("ブロックハッシュが一致しません: %s", hash)
+	}
+
+	filter, err := NewBasicFilter(block, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(filter.Bytes()) != "019dfca8" {
+		t.Errorf("フィルタが一致しません: %x", filter.Bytes())
+	}
+	header := FilterHeader(filter.Hash(), Hash{})
+	if header.String() != "21584579b7eb08997773e5aeff3a7f932700042d0ed2a6129012b7d7ae81b750" {
+		t.Errorf("フィルタヘッダーが一致しません: %s", header)
+	}
+
+	key := BasicFilterKey(block.BlockHash())
+	if ok, err := filter.Match(key, coinbase.TxOut[0].PkScript); err != nil || !ok {
+		t.Errorf("出力スクリプトがフィルタに一致しません: %v", err)
+	}
+}
+
+func TestGCSFilterMatch(t *testing.T) {
+	var key [16]byte
+	copy(key[:], "0123456789abcdef")
+	var items [][]byte
+	for i := 0; i < 200; i++ {
+		items = append(items, []byte(fmt.Sprintf("item-%d", i)))
+	}
+	filter, err := NewGCSFilter(BasicFilterP, BasicFilterM, key, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadGCSFilter(BasicFilterP, BasicFilterM, filter.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.N() != uint32(len(items)) {
+		t.Fatalf("要素数が一致しません: %d", loaded.N())
+	}
+	for _, item := range items {
+		if ok, err := loaded.Match(key, item); err != nil || !ok {
+			t.Fatalf("追加した要素が一致しません: %s, %v", item, err)
+		}
+	}
+
+	others := [][]byte{[]byte("other-1"), []byte("other-2")}
+	if ok, _ := loaded.MatchAny(key, others); ok {
+		t.Errorf("追加していない要素が一致しました")
+	}
+	if ok, _ := loaded.MatchAny(key, append(others, items[100])); !ok {
+		t.Errorf("いずれかの要素が一致しません")
+	}
+
+	// 途中で切れたフィルタはエラーになる
+	truncated, _ := LoadGCSFilter(BasicFilterP, BasicFilterM, filter.Bytes()[:10])
+	if _, err := truncated.Match(key, items[199]); err == nil {
+		t.Errorf("途中で切れたフィルタが読み込めました")
+	}
+}
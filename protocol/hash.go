@@ -0,0 +1,49 @@
+package protocol
+
+import (
+	"encoding/hex"
+
+	"github.com/keiji0/btcwallet/util/hash"
+	"github.com/pkg/errors"
+)
+
+// HashSize はブロックやトランザクションのハッシュのバイトサイズ
+const HashSize = 32
+
+// Hash はブロックやトランザクションを識別するためのハッシュを表す型
+// Sha256x2で計算された値をそのままのバイト順で保持します
+type Hash [HashSize]byte
+
+// DoubleHash はデータのSha256x2からHashを生成します
+func DoubleHash(b []byte) Hash {
+	var h Hash
+	copy(h[:], hash.Sha256x2(b))
+	return h
+}
+
+// NewHashFromString は表示用の16進数文字列からHashを生成します
+// 表示用の文字列はバイト順が逆になっているので元に戻します
+func NewHashFromString(s string) (Hash, error) {
+	var h Hash
+	if len(s) != HashSize*2 {
+		return h, errors.Errorf("ハッシュの文字列の長さが不正です: %q", s)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return h, errors.Wrapf(err, "ハッシュの文字列のデコードに失敗しました: %q", s)
+	}
+	for i := range b {
+		h[i] = b[HashSize-1-i]
+	}
+	return h, nil
+}
+
+// String はバイト順を逆にした表示用の16進数文字列を返します
+// ブロックエクスプローラーやbitcoin-cliで表示される形式になります
+func (h Hash) String() string {
+	var r Hash
+	for i := range h {
+		r[i] = h[HashSize-1-i]
+	}
+	return hex.EncodeToString(r[:])
+}
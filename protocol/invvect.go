@@ -0,0 +1,81 @@
+package protocol
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// インベントリのデータ構造
+// https://en.bitcoin.it/wiki/Protocol_documentation#Inventory_Vectors
+
+// MaxInvPerMsg は1つのメッセージに含められるインベントリの最大数
+// https://github.com/bitcoin/bitcoin/blob/master/src/net_processing.cpp
+const MaxInvPerMsg = 50000
+
+// InvWitnessFlag はウィットネスを含むデータを要求するためのフラグ(BIP144)
+const InvWitnessFlag InvType = 1 << 30
+
+// InvType はインベントリが指すデータの種類を表す型
+type InvType uint32
+
+const (
+	// InvTypeError はエラーを表す種類、無視される
+	InvTypeError InvType = 0
+	// InvTypeTx はトランザクション
+	InvTypeTx InvType = 1
+	// InvTypeBlock はブロック
+	InvTypeBlock InvType = 2
+	// InvTypeFilteredBlock はBIP37のフィルタを適用したmerkleblock
+	InvTypeFilteredBlock InvType = 3
+	// InvTypeCmpctBlock はBIP152のコンパクトブロック
+	InvTypeCmpctBlock InvType = 4
+	// InvTypeWTx はwtxidで指定するトランザクション(BIP339)
+	InvTypeWTx InvType = 5
+	// InvTypeWitnessTx はウィットネスを含むトランザクション
+	InvTypeWitnessTx = InvTypeTx | InvWitnessFlag
+	// InvTypeWitnessBlock はウィットネスを含むブロック
+	InvTypeWitnessBlock = InvTypeBlock | InvWitnessFlag
+	// InvTypeFilteredWitnessBlock はウィットネスを含むmerkleblock
+	InvTypeFilteredWitnessBlock = InvTypeFilteredBlock | InvWitnessFlag
+)
+
+// invTypeNames はInvTypeの表示名
+var invTypeNames = map[InvType]string{
+	InvTypeError:                "ERROR",
+	InvTypeTx:                   "MSG_TX",
+	InvTypeBlock:                "MSG_BLOCK",
+	InvTypeFilteredBlock:        "MSG_FILTERED_BLOCK",
+	InvTypeCmpctBlock:           "MSG_CMPCT_BLOCK",
+	InvTypeWTx:                  "MSG_WTX",
+	InvTypeWitnessTx:            "MSG_WITNESS_TX",
+	InvTypeWitnessBlock:         "MSG_WITNESS_BLOCK",
+	InvTypeFilteredWitnessBlock: "MSG_FILTERED_WITNESS_BLOCK",
+}
+
+// String はInvTypeの名前を返します
+func (t InvType) String() string {
+	if name, ok := invTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown InvType (%d)", uint32(t))
+}
+
+// InvVect はトランザクションやブロックを指し示すインベントリ
+type InvVect struct {
+	Type InvType
+	Hash Hash
+}
+
+// NewInvVect はInvVectを生成します
+func NewInvVect(typ InvType, hash Hash) *InvVect {
+	return &InvVect{Type: typ, Hash: hash}
+}
+
+// addInvVect はインベントリの一覧に上限を超えないように追加する
+func addInvVect(list []*InvVect, iv *InvVect) ([]*InvVect, error) {
+	if MaxInvPerMsg <= len(list) {
+		return list, errors.Errorf("インベントリの数が上限を超えます: max=%d", MaxInvPerMsg)
+	}
+	return append(list, iv), nil
+}
@@ -0,0 +1,49 @@
+package protocol
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHashString(t *testing.T) {
+	// ジェネシスブロックのハッシュ
+	s := "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	h, err := NewHashFromString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h[0] != 0x6f || h[HashSize-1] != 0x00 {
+		t.Errorf("バイト順が逆になっていません: %x", h)
+	}
+	if h.String() != s {
+		t.Errorf("表示用の文字列が一致しません: %s", h)
+	}
+	if _, err := NewHashFromString(s[2:]); err == nil {
+		t.Errorf("長さが不正な文字列からハッシュが生成できました")
+	}
+}
+
+func TestInvVect(t *testing.T) {
+	if InvTypeWitnessTx.String() != "MSG_WITNESS_TX" {
+		t.Errorf("InvTypeの名前が一致しません: %s", InvTypeWitnessTx)
+	}
+
+	msg := NewMsgInv()
+	for i := 0; i < MaxInvPerMsg; i++ {
+		if err := msg.AddInvVect(NewInvVect(InvTypeTx, Hash{})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := msg.AddInvVect(NewInvVect(InvTypeTx, Hash{})); err == nil {
+		t.Errorf("上限を超えてインベントリが追加できました")
+	}
+
+	// 上限を超えたインベントリはデシリアライズできない
+	buf := &bytes.Buffer{}
+	if err := BulkSerialize(buf, VarUint(MaxInvPerMsg+1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewMsgGetData().Deserialize(buf, CurrentVersion); err == nil {
+		t.Errorf("上限を超えたインベントリがデシリアライズできました")
+	}
+}
@@ -0,0 +1,126 @@
+package protocol
+
+import (
+	"github.com/pkg/errors"
+)
+
+// 部分マークルツリー
+// ブロックの一部のトランザクションだけがマークルルートに含まれることを証明します
+// https://github.com/bitcoin/bips/blob/master/bip-0037.mediawiki#partial-merkle-branch-format
+
+// maxMerkleBlockTxs はmerkleblockのブロックに含められるトランザクションの最大数
+// 最小のトランザクションのweightでブロックを埋めた数
+const maxMerkleBlockTxs = MaxBlockWeight / (minTxSize * 4)
+
+// partialMerkleTree は部分マークルツリーを辿るための状態
+type partialMerkleTree struct {
+	txCount int
+	txids   []Hash
+	matches []bool
+
+	hashes   []Hash
+	flags    []bool
+	bitsUsed int
+	hashUsed int
+}
+
+// width は指定した高さの段のノードの数を返す
+func (t *partialMerkleTree) width(height uint) int {
+	return (t.txCount + (1 << height) - 1) >> height
+}
+
+// height はマークルツリーの高さを返す
+func (t *partialMerkleTree) height() uint {
+	var height uint
+	for 1 < t.width(height) {
+		height++
+	}
+	return height
+}
+
+// calcHash は指定した位置のノードのハッシュを計算する
+func (t *partialMerkleTree) calcHash(height uint, pos int) Hash {
+	if height == 0 {
+		return t.txids[pos]
+	}
+	left := t.calcHash(height-1, pos*2)
+	right := left
+	if pos*2+1 < t.width(height-1) {
+		right = t.calcHash(height-1, pos*2+1)
+	}
+	return hashMerkleBranches(left, right)
+}
+
+// build は一致したトランザクションへの経路を深さ優先で辿りフラグとハッシュを記録する
+func (t *partialMerkleTree) build(height uint, pos int) {
+	parentOfMatch := false
+	for p := pos << height; p < (pos+1)<<height && p < t.txCount; p++ {
+		parentOfMatch = parentOfMatch || t.matches[p]
+	}
+	t.flags = append(t.flags, parentOfMatch)
+	if height == 0 || !parentOfMatch {
+		t.hashes = append(t.hashes, t.calcHash(height, pos))
+		return
+	}
+	t.build(height-1, pos*2)
+	if pos*2+1 < t.width(height-1) {
+		t.build(height-1, pos*2+1)
+	}
+}
+
+// extract はフラグとハッシュからノードのハッシュを計算し、一致したトランザクションを集める
+func (t *partialMerkleTree) extract(height uint, pos int, matched *[]Hash, indexes *[]uint32) (Hash, error) {
+	if len(t.flags) <= t.bitsUsed {
+		return Hash{}, errors.New("フラグが足りません")
+	}
+	parentOfMatch := t.flags[t.bitsUsed]
+	t.bitsUsed++
+	if height == 0 || !parentOfMatch {
+		if len(t.hashes) <= t.hashUsed {
+			return Hash{}, errors.New("ハッシュが足りません")
+		}
+		h := t.hashes[t.hashUsed]
+		t.hashUsed++
+		if height == 0 && parentOfMatch {
+			*matched = append(*matched, h)
+			*indexes = append(*indexes, uint32(pos))
+		}
+		return h, nil
+	}
+
+	left, err := t.extract(height-1, pos*2, matched, indexes)
+	if err != nil {
+		return Hash{}, err
+	}
+	right := left
+	if pos*2+1 < t.width(height-1) {
+		if right, err = t.extract(height-1, pos*2+1, matched, indexes); err != nil {
+			return Hash{}, err
+		}
+		// 同じハッシュを並べてトランザクションを複製したように見せる攻撃を防ぐ(CVE-2012-2459)
+		if right == left {
+			return Hash{}, errors.New("左右のノードのハッシュが同じです")
+		}
+	}
+	return hashMerkleBranches(left, right), nil
+}
+
+// packFlags はフラグをビット列に詰める
+func packFlags(flags []bool) []byte {
+	b := make([]byte, (len(flags)+7)/8)
+	for i, f := range flags {
+		if f {
+			b[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return b
+}
+
+// unpackFlags はビット列をフラグに展開する
+func unpackFlags(b []byte) []bool {
+	flags := make([]bool, len(b)*8)
+	for i := range flags {
+		flags[i] = b[i/8]&(1<<uint(i%8)) != 0
+	}
+	return flags
+}
@@ -0,0 +1,83 @@
+package protocol
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMerkleBlock(t *testing.T) {
+	for _, count := range []int{1, 2, 3, 7, 16, 33} {
+		block := testCompactBlock(t, count-1)
+		for _, pattern := range [][]int{{}, {0}, {count - 1}, {0, count / 2, count - 1}} {
+			matches := make([]bool, count)
+			var expected []uint32
+			for _, i := range pattern {
+				if !matches[i] {
+					expected = append(expected, uint32(i))
+				}
+				matches[i] = true
+			}
+
+			msg, err := NewMsgMerkleBlock(block, matches)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf := &bytes.Buffer{}
+			if err := msg.Serialize(buf, CurrentVersion); err != nil {
+				t.Fatal(err)
+			}
+			decoded := &MsgMerkleBlock{}
+			if err := decoded.Deserialize(buf, CurrentVersion); err != nil {
+				t.Fatal(err)
+			}
+
+			matched, indexes, err := decoded.ExtractMatches()
+			if err != nil {
+				t.Fatalf("部分マークルツリーの検証に失敗しました: count=%d, %v", count, err)
+			}
+			if !reflect.DeepEqual(indexes, expected) {
+				t.Errorf("一致したインデックスが一致しません: count=%d, %v != %v", count, indexes, expected)
+			}
+			for i, index := range indexes {
+				if matched[i] != block.Transactions[index].TxHash() {
+					t.Errorf("一致したtxidが一致しません: count=%d, index=%d", count, index)
+				}
+			}
+		}
+	}
+}
+
+func TestMerkleBlockInvalid(t *testing.T) {
+	block := testCompactBlock(t, 6)
+	matches := []bool{false, true, false, false, true, false, false}
+	msg, err := NewMsgMerkleBlock(block, matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := *msg
+	tampered.Hashes = append([]Hash{}, msg.Hashes...)
+	tampered.Hashes[0][0] ^= 1
+	if _, _, err := tampered.ExtractMatches(); err == nil {
+		t.Errorf("改ざんされたハッシュが検出されませんでした")
+	}
+
+	tampered = *msg
+	tampered.Hashes = msg.Hashes[:len(msg.Hashes)-1]
+	if _, _, err := tampered.ExtractMatches(); err == nil {
+		t.Errorf("足りないハッシュが検出されませんでした")
+	}
+
+	tampered = *msg
+	tampered.Flags = append(append([]byte{}, msg.Flags...), 0)
+	if _, _, err := tampered.ExtractMatches(); err == nil {
+		t.Errorf("余分なフラグが検出されませんでした")
+	}
+
+	tampered = *msg
+	tampered.Transactions = 0
+	if _, _, err := tampered.ExtractMatches(); err == nil {
+		t.Errorf("トランザクションの数が0でも検証できました")
+	}
+}
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"io"
 	"reflect"
 
@@ -15,10 +16,10 @@ import (
 type Message interface {
 	// Messageのコマンド名を返す
 	Command() string
-	// MessageのPayloadをシリアライズする
-	Serialize(w io.Writer) error
-	// MessageのPayloadをデシリアライズする
-	Deserialize(r io.Reader) error
+	// MessageのPayloadをピアと合意したプロトコルのバージョンでシリアライズする
+	Serialize(w io.Writer, pver Version) error
+	// MessageのPayloadをピアと合意したプロトコルのバージョンでデシリアライズする
+	Deserialize(r io.Reader, pver Version) error
 }
 
 // メッセージのデータ構造
@@ -35,6 +36,23 @@ const messageChecksumSize = 4
 // https://github.com/bitcoin/bitcoin/blob/0.17/src/serialize.h#L27
 const messageMaxSize = 0x02000000
 
+// maxMagicResyncBytes はマジックが一致しない場合に次のメッセージを探して読み飛ばすバイト数の上限
+const maxMagicResyncBytes = 0x10000
+
+// p2p層が切断やBANを判断できるように、メッセージの受信で起きるエラーを区別します
+// errors.Causeで取り出して比較します
+var (
+	// ErrBadMagic はメッセージのマジックがネットワークと一致しない場合のエラー
+	ErrBadMagic = errors.New("メッセージのマジックが一致しません")
+	// ErrChecksum はPayloadのチェックサムが一致しない場合のエラー
+	ErrChecksum = errors.New("Payloadのチェックサムが一致しません")
+	// ErrOversize はPayloadのサイズが上限を超えている場合のエラー
+	ErrOversize = errors.New("MessageのPayloadのサイズが規定値より大きいです")
+	// ErrUnknownCommand は知らないコマンドのメッセージを生成しようとした場合のエラー
+	// Receiveでは知らないコマンドはエラーにせずUnknownMessageとして返します
+	ErrUnknownCommand = errors.New("メッセージコマンドが見つかりませんでした")
+)
+
 // ビットコインノードへ送信するメッセージのヘッダー
 type messageHeader struct {
 	magic    MessageMagic
@@ -43,9 +61,46 @@ type messageHeader struct {
 	checksum [messageChecksumSize]byte
 }
 
+// commandName は0で埋められたコマンドからコマンド名を取り出します
+func (h *messageHeader) commandName() string {
+	return string(bytes.TrimRight(h.command[:], "\x00"))
+}
+
 // メッセージコマンドの一覧
 var messages = []Message{
 	&MsgVersion{},
+	&MsgVerAck{},
+	&MsgPing{},
+	&MsgPong{},
+	&MsgSendHeaders{},
+	&MsgWTxIDRelay{},
+	&MsgSendAddrV2{},
+	&MsgFeeFilter{},
+	&MsgAddr{},
+	&MsgGetAddr{},
+	&MsgAddrV2{},
+	&MsgInv{},
+	&MsgGetData{},
+	&MsgNotFound{},
+	&MsgGetHeaders{},
+	&MsgGetBlocks{},
+	&MsgHeaders{},
+	&MsgTx{},
+	&MsgBlock{},
+	&MsgSendCmpct{},
+	&MsgCmpctBlock{},
+	&MsgGetBlockTxn{},
+	&MsgBlockTxn{},
+	&MsgFilterLoad{},
+	&MsgFilterAdd{},
+	&MsgFilterClear{},
+	&MsgMerkleBlock{},
+	&MsgGetCFilters{},
+	&MsgCFilter{},
+	&MsgGetCFHeaders{},
+	&MsgCFHeaders{},
+	&MsgGetCFCheckpt{},
+	&MsgCFCheckpt{},
 }
 
 // コマンド名とMessageTypeのマップ、ちょっとでも早くアクセスするため
@@ -58,7 +113,8 @@ func init() {
 }
 
 // Send はメッセージをネットワークに送信します
-func Send(w io.Writer, netType core.NetworkType, msg Message) (err error) {
+// pverにはピアと合意したプロトコルのバージョンを指定します
+func Send(w io.Writer, netType core.NetworkType, pver Version, msg Message) (err error) {
 	h := messageHeader{}
 
 	// NetTypeからmagicを取得
@@ -74,24 +130,20 @@ func Send(w io.Writer, netType core.NetworkType, msg Message) (err error) {
 	copy(h.command[:], msg.Command())
 
 	// コマンド本体のバイト列を取得
-	payload := &bytes.Buffer{}
-	if err := msg.Serialize(payload); err != nil {
+	payload, err := EncodePayload(msg, pver)
+	if err != nil {
 		return err
 	}
+	h.length = uint32(len(payload))
 
-	h.length = uint32(len(payload.Bytes()))
-	if messageMaxSize < h.length {
-		return errors.Errorf("MessageのPayloadのサイズが規定値より大きいです: command=%s, size=%d", msg.Command(), h.length)
-	}
-
-	copy(h.checksum[:], hash.Sha256x2(payload.Bytes())[0:messageChecksumSize])
+	copy(h.checksum[:], hash.Sha256x2(payload)[0:messageChecksumSize])
 
 	// メッセージヘッダーを送信
 	if err := BulkSerialize(w, h.magic, h.command, h.length, h.checksum); err != nil {
 		return err
 	}
 	// Payloadを送信
-	if _, err := w.Write(payload.Bytes()); err != nil {
+	if _, err := w.Write(payload); err != nil {
 		return errors.Wrapf(err, "Payloadの送信に失敗しました")
 	}
 
@@ -99,32 +151,79 @@ func Send(w io.Writer, netType core.NetworkType, msg Message) (err error) {
 }
 
 // Receive はネットワークからメッセージを受信します
-func Receive(r io.Reader) (Message, error) {
-	h, err := readMessageHeader(r)
+// Payloadは一旦バッファせずにチェックサムを計算しながらメッセージへ直接デシリアライズします
+// pverにはピアと合意したプロトコルのバージョンを指定します
+// 知らないコマンドのメッセージはエラーにせずUnknownMessageとして返します
+func Receive(r io.Reader, netType core.NetworkType, pver Version) (Message, error) {
+	magic, err := NetworkTypeMessageMagic(netType)
+	if err != nil {
+		return nil, err
+	}
+	h, err := readMessageHeader(r, magic)
 	if err != nil {
 		return nil, err
 	}
 
-	payload := make([]byte, h.length)
-	if _, err := io.ReadFull(r, payload); err != nil {
-		return nil, errors.Wrapf(err, "Payloadの読み込みに失敗しました: command=%s", h.command)
+	payload := &io.LimitedReader{R: r, N: int64(h.length)}
+	hasher := sha256.New()
+
+	msg, err := newMessage(h.commandName())
+	if errors.Cause(err) == ErrUnknownCommand {
+		msg = &UnknownMessage{CommandName: h.commandName()}
+	} else if err != nil {
+		return nil, err
 	}
 
-	checksum := hash.Sha256x2(payload)
+	deserializeErr := msg.Deserialize(io.TeeReader(payload, hasher), pver)
+
+	// デシリアライズで読み残したPayloadもチェックサムに含める
+	if _, err := io.Copy(hasher, payload); err != nil {
+		return nil, errors.Wrapf(err, "Payloadの読み込みに失敗しました: command=%s", h.commandName())
+	}
+	if payload.N != 0 {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "Payloadの読み込みに失敗しました: command=%s", h.commandName())
+	}
+
+	checksum := hash.Sha256(hasher.Sum(nil))[:messageChecksumSize]
 	if !bytes.Equal(checksum, h.checksum[:]) {
-		return nil, errors.Errorf("Payloadのチェックサムが一致しません: command=%s", h.command)
+		return nil, errors.Wrapf(ErrChecksum, "command=%s", h.commandName())
+	}
 
+	if deserializeErr != nil {
+		return nil, errors.Wrapf(deserializeErr, "Payloadのデシリアライズに失敗しました: command=%s", msg.Command())
 	}
 
-	msg, err := newMessage(string(h.command[:]))
-	if err != nil {
+	return msg, nil
+}
+
+// EncodePayload はメッセージのPayloadをバイト列にシリアライズします
+// メッセージヘッダーを使わないv2の通信でも使います
+func EncodePayload(msg Message, pver Version) ([]byte, error) {
+	payload := &bytes.Buffer{}
+	if err := msg.Serialize(payload, pver); err != nil {
 		return nil, err
 	}
+	if messageMaxSize < payload.Len() {
+		return nil, errors.Wrapf(ErrOversize, "command=%s, size=%d", msg.Command(), payload.Len())
+	}
+	return payload.Bytes(), nil
+}
 
-	if err := msg.Deserialize(r); err != nil {
+// DecodePayload はコマンド名とPayloadのバイト列からメッセージをデシリアライズします
+// Receiveと同じく知らないコマンドのメッセージはUnknownMessageとして返します
+func DecodePayload(command string, payload []byte, pver Version) (Message, error) {
+	if messageMaxSize < len(payload) {
+		return nil, errors.Wrapf(ErrOversize, "command=%s, size=%d", command, len(payload))
+	}
+	msg, err := newMessage(command)
+	if errors.Cause(err) == ErrUnknownCommand {
+		msg = &UnknownMessage{CommandName: command}
+	} else if err != nil {
 		return nil, err
 	}
-
+	if err := msg.Deserialize(bytes.NewReader(payload), pver); err != nil {
+		return nil, errors.Wrapf(err, "Payloadのデシリアライズに失敗しました: command=%s", command)
+	}
 	return msg, nil
 }
 
@@ -132,7 +231,7 @@ func Receive(r io.Reader) (Message, error) {
 func newMessage(command string) (Message, error) {
 	t, ok := messageMap[command]
 	if !ok {
-		return nil, errors.Errorf("メッセージコマンドが見つかりませんでした: %s", command)
+		return nil, errors.Wrapf(ErrUnknownCommand, "command=%s", command)
 	}
 	i, ok := reflect.New(t).Interface().(Message)
 	if !ok {
@@ -142,15 +241,31 @@ func newMessage(command string) (Message, error) {
 }
 
 // readMessageHeader はMessageHeaderをネットワークから読み込みます
-func readMessageHeader(r io.Reader) (*messageHeader, error) {
+// マジックが一致しない場合はmaxMagicResyncBytesまで1バイトずつずらして次のメッセージの先頭を探します
+func readMessageHeader(r io.Reader, magic MessageMagic) (*messageHeader, error) {
 	h := &messageHeader{}
 
-	if err := BulkDeserialize(r, &h.magic, &h.command, &h.length, &h.checksum); err != nil {
+	var window [4]byte
+	if _, err := io.ReadFull(r, window[:]); err != nil {
+		return nil, errors.Wrap(err, "マジックの読み込みに失敗しました")
+	}
+	for skipped := 0; MessageMagic(defaultByteOrder.Uint32(window[:])) != magic; skipped++ {
+		if maxMagicResyncBytes <= skipped {
+			return nil, errors.Wrapf(ErrBadMagic, "skipped=%d", skipped)
+		}
+		copy(window[:], window[1:])
+		if _, err := io.ReadFull(r, window[3:]); err != nil {
+			return nil, errors.Wrap(err, "マジックの読み込みに失敗しました")
+		}
+	}
+	h.magic = magic
+
+	if err := BulkDeserialize(r, &h.command, &h.length, &h.checksum); err != nil {
 		return nil, err
 	}
 
 	if messageMaxSize < h.length {
-		return nil, errors.Errorf("MessageのPayloadのサイズが規定値より大きいです: command=%s, size=%d", h.command, h.length)
+		return nil, errors.Wrapf(ErrOversize, "command=%s, size=%d", h.commandName(), h.length)
 	}
 
 	return h, nil
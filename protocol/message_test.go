@@ -1,12 +1,20 @@
 package protocol
 
 import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/pkg/errors"
 )
 
 func TestMessage(t *testing.T) {
 
-	msgVersion, err := CreateMessage("version")
+	msgVersion, err := newMessage("version")
 	if err != nil {
 		t.Error(err)
 	}
@@ -14,3 +22,186 @@ func TestMessage(t *testing.T) {
 		t.Errorf("生成したメッセージのコマンド名が一致しません")
 	}
 }
+
+func TestSendReceive(t *testing.T) {
+	tests := []Message{
+		&MsgVerAck{},
+		NewMsgPing(0x0123456789abcdef),
+		NewMsgPong(0x0123456789abcdef),
+		&MsgSendHeaders{},
+		&MsgWTxIDRelay{},
+		&MsgSendAddrV2{},
+		NewMsgFeeFilter(1000),
+		&MsgInv{InvList: []*InvVect{NewInvVect(InvTypeWitnessTx, Hash{0x01}), NewInvVect(InvTypeBlock, Hash{0x02})}},
+		&MsgGetData{InvList: []*InvVect{NewInvVect(InvTypeWitnessBlock, Hash{0x03})}},
+		&MsgNotFound{InvList: []*InvVect{NewInvVect(InvTypeWTx, Hash{0x04})}},
+		NewMsgGetHeaders(BlockLocator{Hash{0x05}, Hash{0x06}}, Hash{}),
+		NewMsgGetBlocks(BlockLocator{Hash{0x07}}, Hash{0x08}),
+		&MsgHeaders{Headers: []*BlockHeader{{Version: 1, PrevBlock: Hash{0x09}, Timestamp: Uint32Time(time.Unix(1231006505, 0)), Bits: 0x1d00ffff}}},
+		&MsgGetAddr{},
+		&MsgAddr{AddrList: []*TimestampedNetAddress{{Timestamp: Uint32Time(time.Unix(1700000000, 0)), NetAddress: NetAddress{Services: NodeNetwork, IP: net.ParseIP("192.0.2.1"), Port: 8333}}}},
+		&MsgAddrV2{AddrList: []*NetAddressV2{
+			{Timestamp: Uint32Time(time.Unix(1700000000, 0)), Services: NodeNetwork | NodeWitness, NetworkID: NetworkIPv4, Addr: []byte{192, 0, 2, 1}, Port: 8333},
+			{Timestamp: Uint32Time(time.Unix(1700000000, 0)), NetworkID: NetworkTorV3, Addr: bytes.Repeat([]byte{0xab}, 32), Port: 8333},
+		}},
+		NewMsgSendCmpct(true, CompactBlockVersion2),
+		NewMsgGetBlockTxn(Hash{0x0a}, []uint32{0, 3, 4, 300}),
+		NewMsgFilterLoad([]byte{0x61, 0x4e, 0x9b}, 5, 0x80000001, BloomUpdateAll),
+		NewMsgFilterAdd(bytes.Repeat([]byte{0x99}, 20)),
+		&MsgFilterClear{},
+		NewMsgGetCFilters(FilterTypeBasic, 100, Hash{0x0c}),
+		NewMsgCFilter(FilterTypeBasic, Hash{0x0d}, []byte{0x01, 0x9d, 0xfc, 0xa8}),
+		NewMsgGetCFHeaders(FilterTypeBasic, 100, Hash{0x0e}),
+		NewMsgCFHeaders(FilterTypeBasic, Hash{0x0f}, Hash{0x10}, []Hash{{0x11}, {0x12}}),
+		NewMsgGetCFCheckpt(FilterTypeBasic, Hash{0x13}),
+		NewMsgCFCheckpt(FilterTypeBasic, Hash{0x14}, []Hash{{0x15}}),
+		NewMsgBlockTxn(Hash{0x0b}, []*Tx{{Version: 2, TxIn: []*TxIn{{SignatureScript: []byte{}, Sequence: MaxTxInSequenceNum}}, TxOut: []*TxOut{{Value: 1000, PkScript: []byte{0x51}}}}}, WitnessEncoding),
+	}
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		if err := Send(buf, core.MainNetwork, CurrentVersion, test); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		msg, err := Receive(buf, core.MainNetwork, CurrentVersion)
+		if err != nil {
+			t.Errorf("メッセージの受信に失敗しました: command=%s, %v", test.Command(), err)
+			continue
+		}
+		if !reflect.DeepEqual(test, msg) {
+			t.Errorf("送信したメッセージと受信したメッセージが一致しません: %#v != %#v", test, msg)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("読み込まれていないデータがあります: command=%s, %d", test.Command(), buf.Len())
+		}
+	}
+}
+
+func TestReceiveChecksumError(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := Send(buf, core.MainNetwork, CurrentVersion, NewMsgPing(1)); err != nil {
+		t.Fatal(err)
+	}
+	// Payloadを書き換えてチェックサムを不一致にする
+	buf.Bytes()[buf.Len()-1] ^= 0xff
+	if err := Send(buf, core.MainNetwork, CurrentVersion, NewMsgPing(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Receive(buf, core.MainNetwork, CurrentVersion); errors.Cause(err) != ErrChecksum {
+		t.Errorf("チェックサムのエラーになりません: %v", err)
+	}
+	// Payloadは読み捨てられているので次のメッセージは受信できる
+	msg, err := Receive(buf, core.MainNetwork, CurrentVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ping, ok := msg.(*MsgPing); !ok || ping.Nonce != 2 {
+		t.Errorf("次のメッセージが受信できません: %#v", msg)
+	}
+}
+
+func TestReceiveUnknownMessage(t *testing.T) {
+	unknown := &UnknownMessage{CommandName: "futurecmd", Payload: []byte{0x01, 0x02, 0x03}}
+	buf := &bytes.Buffer{}
+	if err := Send(buf, core.MainNetwork, CurrentVersion, unknown); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := Receive(buf, core.MainNetwork, CurrentVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(msg, unknown) {
+		t.Errorf("知らないコマンドのメッセージが一致しません: %#v", msg)
+	}
+	if _, err := newMessage("futurecmd"); errors.Cause(err) != ErrUnknownCommand {
+		t.Errorf("知らないコマンドのエラーになりません: %v", err)
+	}
+}
+
+func TestReceiveResync(t *testing.T) {
+	// 途中に壊れたデータがあってもマジックを探して次のメッセージを読む
+	buf := &bytes.Buffer{}
+	buf.Write([]byte{0xf9, 0xbe, 0x00, 0x01, 0x02})
+	if err := Send(buf, core.MainNetwork, CurrentVersion, NewMsgPing(1)); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := Receive(buf, core.MainNetwork, CurrentVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ping, ok := msg.(*MsgPing); !ok || ping.Nonce != 1 {
+		t.Errorf("読み飛ばした後のメッセージが一致しません: %#v", msg)
+	}
+
+	// 別のネットワークのメッセージは上限まで読み飛ばしてエラーにする
+	buf.Reset()
+	buf.Write(make([]byte, maxMagicResyncBytes))
+	if err := Send(buf, core.TestNetwork, CurrentVersion, NewMsgPing(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Receive(buf, core.MainNetwork, CurrentVersion); errors.Cause(err) != ErrBadMagic {
+		t.Errorf("マジックのエラーになりません: %v", err)
+	}
+
+	// Payloadのサイズが上限を超えるヘッダー
+	buf.Reset()
+	if err := BulkSerialize(buf, MainNetMessageMagic, [messageCommandSize]byte{'p', 'i', 'n', 'g'}, uint32(messageMaxSize+1), [messageChecksumSize]byte{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Receive(buf, core.MainNetwork, CurrentVersion); errors.Cause(err) != ErrOversize {
+		t.Errorf("サイズのエラーになりません: %v", err)
+	}
+}
+
+func TestVersionDependentEncoding(t *testing.T) {
+	addr := &NetAddress{IP: net.ParseIP("127.0.0.1"), Port: 8333}
+	msg := NewMsgVersion(addr, addr, 1, 100)
+	msg.ProtocolVersion = 209
+	buf := &bytes.Buffer{}
+	if err := msg.Serialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	raw := buf.Bytes()
+
+	// 古いノードが省略した後ろのフィールドは読み込まない
+	// version(4) + services(8) + timestamp(8) + addr_recv(26)
+	decoded := &MsgVersion{}
+	if err := decoded.Deserialize(bytes.NewReader(raw[:46]), CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ProtocolVersion != 209 || decoded.Nonce != 0 || decoded.StartHeight != 0 {
+		t.Errorf("省略されたフィールドが不正です: %#v", decoded)
+	}
+	// フィールドの途中で終わっている場合はエラー
+	if err := (&MsgVersion{}).Deserialize(bytes.NewReader(raw[:46+26+4]), CurrentVersion); err == nil {
+		t.Errorf("途中で終わっているメッセージが読み込めました")
+	}
+
+	// AddrTimeVersionより前はaddrにタイムスタンプが付かない
+	addrMsg := &MsgAddr{AddrList: []*TimestampedNetAddress{{Timestamp: Uint32Time(time.Unix(1700000000, 0)), NetAddress: *addr}}}
+	for _, test := range []struct {
+		pver Version
+		size int
+	}{
+		{AddrTimeVersion - 1, 1 + 26},
+		{AddrTimeVersion, 1 + 30},
+	} {
+		buf.Reset()
+		if err := addrMsg.Serialize(buf, test.pver); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() != test.size {
+			t.Errorf("addrのサイズが一致しません: version=%d, %d != %d", test.pver, buf.Len(), test.size)
+		}
+	}
+
+	// 対応していないバージョンのピアには送れない
+	if err := Send(ioutil.Discard, core.MainNetwork, SendHeadersVersion, NewMsgFeeFilter(1000)); err == nil {
+		t.Errorf("FeeFilterVersionより前のバージョンでfeefilterが送れました")
+	}
+	if NegotiateVersion(CurrentVersion, FeeFilterVersion) != FeeFilterVersion {
+		t.Errorf("低い方のバージョンが選ばれていません")
+	}
+}
@@ -0,0 +1,43 @@
+package protocol
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// MsgAddr は接続できるノードのアドレスを通知するメッセージ
+// https://en.bitcoin.it/wiki/Protocol_documentation#addr
+type MsgAddr struct {
+	AddrList []*TimestampedNetAddress `btc:"max=1000"`
+}
+
+// NewMsgAddr はMsgAddrメッセージを生成します
+func NewMsgAddr() *MsgAddr {
+	return &MsgAddr{}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgAddr) Command() string {
+	return "addr"
+}
+
+// AddAddress はアドレスを追加します
+// MaxAddrPerMsgを超える場合はエラーになります
+func (v *MsgAddr) AddAddress(addr *TimestampedNetAddress) error {
+	if MaxAddrPerMsg <= len(v.AddrList) {
+		return errors.Errorf("アドレスの数が多すぎます: max=%d", MaxAddrPerMsg)
+	}
+	v.AddrList = append(v.AddrList, addr)
+	return nil
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgAddr) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgAddr) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
@@ -0,0 +1,87 @@
+package protocol
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// MsgAddrV2 はBIP155の形式で接続できるノードのアドレスを通知するメッセージ
+// sendaddrv2を送ってきたピアにだけ送ることができます
+// https://github.com/bitcoin/bips/blob/master/bip-0155.mediawiki
+type MsgAddrV2 struct {
+	AddrList []*NetAddressV2
+}
+
+// NewMsgAddrV2 はMsgAddrV2メッセージを生成します
+func NewMsgAddrV2() *MsgAddrV2 {
+	return &MsgAddrV2{}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgAddrV2) Command() string {
+	return "addrv2"
+}
+
+// AddAddress はアドレスを追加します
+// 不正なアドレスやMaxAddrPerMsgを超える場合はエラーになります
+func (v *MsgAddrV2) AddAddress(addr *NetAddressV2) error {
+	if MaxAddrPerMsg <= len(v.AddrList) {
+		return errors.Errorf("アドレスの数が多すぎます: max=%d", MaxAddrPerMsg)
+	}
+	if err := addr.Validate(); err != nil {
+		return err
+	}
+	v.AddrList = append(v.AddrList, addr)
+	return nil
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+// アドレスの長さがネットワークごとに変わり、不正なアドレスは読み飛ばすため、タグを使わずに書いています
+func (v *MsgAddrV2) Serialize(w io.Writer, pver Version) error {
+	if MaxAddrPerMsg < len(v.AddrList) {
+		return errors.Errorf("アドレスの数が多すぎます: count=%d", len(v.AddrList))
+	}
+	if err := Serialize(w, VarUint(len(v.AddrList))); err != nil {
+		return err
+	}
+	for _, addr := range v.AddrList {
+		if err := serializeNetAddressV2(w, addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+// 知らないネットワークのアドレスとValidateで拒否されるアドレスは読み飛ばし、
+// 既知のネットワークで長さが不正な場合はエラーになります
+func (v *MsgAddrV2) Deserialize(r io.Reader, pver Version) error {
+	var count VarUint
+	if err := Deserialize(r, &count); err != nil {
+		return err
+	}
+	if MaxAddrPerMsg < count {
+		return errors.Errorf("アドレスの数が多すぎます: count=%d", count)
+	}
+	v.AddrList = make([]*NetAddressV2, 0, count)
+	for i := VarUint(0); i < count; i++ {
+		addr := &NetAddressV2{}
+		if err := deserializeNetAddressV2(r, addr); err != nil {
+			return err
+		}
+		size, ok := networkAddrSizes[addr.NetworkID]
+		if !ok {
+			continue
+		}
+		if len(addr.Addr) != size {
+			return errors.Errorf("アドレスの長さが不正です: network=%d, length=%d", addr.NetworkID, len(addr.Addr))
+		}
+		// Tor v2のような廃止されたネットワークなど、使えないアドレスも読み飛ばす
+		if addr.Validate() != nil {
+			continue
+		}
+		v.AddrList = append(v.AddrList, addr)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgBlock はブロックを送るメッセージ
+// https://en.bitcoin.it/wiki/Protocol_documentation#block
+type MsgBlock struct {
+	Block *Block
+	// シリアライズ形式、NodeWitnessを提供していないピアにはBaseEncodingで送る
+	Encoding MessageEncoding
+}
+
+// NewMsgBlock はMsgBlockメッセージを生成します
+func NewMsgBlock(block *Block, encoding MessageEncoding) *MsgBlock {
+	return &MsgBlock{Block: block, Encoding: encoding}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgBlock) Command() string {
+	return "block"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgBlock) Serialize(w io.Writer, pver Version) error {
+	return v.Block.serialize(w, v.Encoding)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgBlock) Deserialize(r io.Reader, pver Version) error {
+	v.Block = &Block{}
+	return v.Block.Deserialize(r)
+}
@@ -0,0 +1,73 @@
+package protocol
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// MsgBlockTxn はgetblocktxnで要求されたトランザクションを返すメッセージ(BIP152)
+// https://github.com/bitcoin/bips/blob/master/bip-0152.mediawiki#blocktxn
+type MsgBlockTxn struct {
+	BlockHash Hash
+	// getblocktxnのインデックスの順に並べたトランザクション
+	Txs []*Tx
+	// シリアライズ形式、バージョン1のピアにはBaseEncodingで送る
+	Encoding MessageEncoding
+}
+
+// NewMsgBlockTxn はMsgBlockTxnメッセージを生成します
+func NewMsgBlockTxn(blockHash Hash, txs []*Tx, encoding MessageEncoding) *MsgBlockTxn {
+	return &MsgBlockTxn{BlockHash: blockHash, Txs: txs, Encoding: encoding}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgBlockTxn) Command() string {
+	return "blocktxn"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+// トランザクションはEncodingでウィットネスの有無が変わるため、タグを使わずに書いています
+func (v *MsgBlockTxn) Serialize(w io.Writer, pver Version) error {
+	if err := checkVersion(v.Command(), pver, CompactBlocksVersion); err != nil {
+		return err
+	}
+	if err := BulkSerialize(w, v.BlockHash, VarUint(len(v.Txs))); err != nil {
+		return err
+	}
+	for _, tx := range v.Txs {
+		var err error
+		if v.Encoding == BaseEncoding {
+			err = tx.SerializeNoWitness(w)
+		} else {
+			err = tx.Serialize(w)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgBlockTxn) Deserialize(r io.Reader, pver Version) error {
+	if err := checkVersion(v.Command(), pver, CompactBlocksVersion); err != nil {
+		return err
+	}
+	var count VarUint
+	if err := BulkDeserialize(r, &v.BlockHash, &count); err != nil {
+		return err
+	}
+	if maxCompactBlockTxs < count {
+		return errors.Errorf("トランザクションの数が多すぎます: count=%d", count)
+	}
+	v.Txs = make([]*Tx, count)
+	for i := range v.Txs {
+		tx := &Tx{}
+		if err := tx.Deserialize(r); err != nil {
+			return errors.Wrapf(err, "トランザクションの読み込みに失敗しました: index=%d", i)
+		}
+		v.Txs[i] = tx
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package protocol
+
+import (
+	"io"
+)
+
+// CFCheckptInterval はcfcheckptで返すフィルタヘッダーのブロックの間隔
+const CFCheckptInterval = 1000
+
+// MsgCFCheckpt はgetcfcheckptに対してCFCheckptInterval毎のフィルタヘッダーを返すメッセージ(BIP157)
+// i番目のフィルタヘッダーは高さ(i+1)*CFCheckptIntervalのブロックのものになります
+// https://github.com/bitcoin/bips/blob/master/bip-0157.mediawiki#cfcheckpt
+type MsgCFCheckpt struct {
+	FilterType    FilterType
+	StopHash      Hash
+	FilterHeaders []Hash `btc:"max=50000"`
+}
+
+// NewMsgCFCheckpt はMsgCFCheckptメッセージを生成します
+func NewMsgCFCheckpt(filterType FilterType, stopHash Hash, filterHeaders []Hash) *MsgCFCheckpt {
+	return &MsgCFCheckpt{FilterType: filterType, StopHash: stopHash, FilterHeaders: filterHeaders}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgCFCheckpt) Command() string {
+	return "cfcheckpt"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgCFCheckpt) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgCFCheckpt) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
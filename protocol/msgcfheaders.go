@@ -0,0 +1,50 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MaxCFHeadersPerMsg は1つのcfheadersに含められるフィルタのハッシュの最大数
+const MaxCFHeadersPerMsg = 2000
+
+// MsgCFHeaders はgetcfheadersに対してフィルタのハッシュを返すメッセージ(BIP157)
+// 帯域を節約するためフィルタヘッダーではなくフィルタのハッシュを送り、受信側でヘッダーを計算します
+// https://github.com/bitcoin/bips/blob/master/bip-0157.mediawiki#cfheaders
+type MsgCFHeaders struct {
+	FilterType FilterType
+	StopHash   Hash
+	// StartHeightの1つ前のブロックのフィルタヘッダー
+	PrevFilterHeader Hash
+	FilterHashes     []Hash `btc:"max=2000"`
+}
+
+// NewMsgCFHeaders はMsgCFHeadersメッセージを生成します
+func NewMsgCFHeaders(filterType FilterType, stopHash, prevFilterHeader Hash, filterHashes []Hash) *MsgCFHeaders {
+	return &MsgCFHeaders{FilterType: filterType, StopHash: stopHash, PrevFilterHeader: prevFilterHeader, FilterHashes: filterHashes}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgCFHeaders) Command() string {
+	return "cfheaders"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgCFHeaders) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgCFHeaders) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
+
+// FilterHeaders はフィルタのハッシュを前のフィルタヘッダーから順に繋げてフィルタヘッダーを計算します
+func (v *MsgCFHeaders) FilterHeaders() []Hash {
+	headers := make([]Hash, len(v.FilterHashes))
+	prev := v.PrevFilterHeader
+	for i, filterHash := range v.FilterHashes {
+		prev = FilterHeader(filterHash, prev)
+		headers[i] = prev
+	}
+	return headers
+}
@@ -0,0 +1,39 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgCFilter はgetcfiltersに対して1つのブロックのフィルタを返すメッセージ(BIP157)
+// https://github.com/bitcoin/bips/blob/master/bip-0157.mediawiki#cfilter
+type MsgCFilter struct {
+	FilterType FilterType
+	BlockHash  Hash
+	// 要素数を付けてシリアライズしたフィルタ
+	Filter []byte
+}
+
+// NewMsgCFilter はMsgCFilterメッセージを生成します
+func NewMsgCFilter(filterType FilterType, blockHash Hash, filter []byte) *MsgCFilter {
+	return &MsgCFilter{FilterType: filterType, BlockHash: blockHash, Filter: filter}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgCFilter) Command() string {
+	return "cfilter"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgCFilter) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgCFilter) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
+
+// GCSFilter は基本フィルタとして読み込みます
+func (v *MsgCFilter) GCSFilter() (*GCSFilter, error) {
+	return LoadGCSFilter(BasicFilterP, BasicFilterM, v.Filter)
+}
@@ -0,0 +1,40 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgCmpctBlock はブロックをショートトランザクションIDで送るメッセージ(BIP152)
+// https://github.com/bitcoin/bips/blob/master/bip-0152.mediawiki#cmpctblock
+type MsgCmpctBlock struct {
+	Block *HeaderAndShortIDs
+	// PrefilledTxsのシリアライズ形式、バージョン1のピアにはBaseEncodingで送る
+	Encoding MessageEncoding
+}
+
+// NewMsgCmpctBlock はMsgCmpctBlockメッセージを生成します
+func NewMsgCmpctBlock(block *HeaderAndShortIDs, encoding MessageEncoding) *MsgCmpctBlock {
+	return &MsgCmpctBlock{Block: block, Encoding: encoding}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgCmpctBlock) Command() string {
+	return "cmpctblock"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgCmpctBlock) Serialize(w io.Writer, pver Version) error {
+	if err := checkVersion(v.Command(), pver, CompactBlocksVersion); err != nil {
+		return err
+	}
+	return v.Block.serialize(w, v.Encoding)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgCmpctBlock) Deserialize(r io.Reader, pver Version) error {
+	if err := checkVersion(v.Command(), pver, CompactBlocksVersion); err != nil {
+		return err
+	}
+	v.Block = &HeaderAndShortIDs{}
+	return v.Block.deserialize(r)
+}
@@ -0,0 +1,46 @@
+package protocol
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// MsgFeeFilter は指定した手数料率未満のトランザクションを通知しないよう要求するメッセージ(BIP133)
+// https://github.com/bitcoin/bips/blob/master/bip-0133.mediawiki
+type MsgFeeFilter struct {
+	// 手数料率(satoshi/kvB)
+	MinFeeRate int64
+}
+
+// NewMsgFeeFilter はMsgFeeFilterメッセージを生成します
+func NewMsgFeeFilter(minFeeRate int64) *MsgFeeFilter {
+	return &MsgFeeFilter{MinFeeRate: minFeeRate}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgFeeFilter) Command() string {
+	return "feefilter"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgFeeFilter) Serialize(w io.Writer, pver Version) error {
+	if err := checkVersion(v.Command(), pver, FeeFilterVersion); err != nil {
+		return err
+	}
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgFeeFilter) Deserialize(r io.Reader, pver Version) error {
+	if err := checkVersion(v.Command(), pver, FeeFilterVersion); err != nil {
+		return err
+	}
+	if err := DeserializeStruct(r, pver, v); err != nil {
+		return err
+	}
+	if v.MinFeeRate < 0 {
+		return errors.Errorf("手数料率が負の値です: %d", v.MinFeeRate)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package protocol
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// MsgFilterAdd はピアに設定したブルームフィルタにデータを追加するメッセージ(BIP37)
+// https://github.com/bitcoin/bips/blob/master/bip-0037.mediawiki#new-messages
+type MsgFilterAdd struct {
+	Data []byte
+}
+
+// NewMsgFilterAdd はMsgFilterAddメッセージを生成します
+func NewMsgFilterAdd(data []byte) *MsgFilterAdd {
+	return &MsgFilterAdd{Data: data}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgFilterAdd) Command() string {
+	return "filteradd"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgFilterAdd) Serialize(w io.Writer, pver Version) error {
+	if err := checkVersion(v.Command(), pver, BIP37Version); err != nil {
+		return err
+	}
+	if MaxFilterAddDataSize < len(v.Data) {
+		return errors.Errorf("追加するデータのサイズが上限を超えています: size=%d", len(v.Data))
+	}
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgFilterAdd) Deserialize(r io.Reader, pver Version) error {
+	if err := checkVersion(v.Command(), pver, BIP37Version); err != nil {
+		return err
+	}
+	if err := DeserializeStruct(r, pver, v); err != nil {
+		return err
+	}
+	if MaxFilterAddDataSize < len(v.Data) {
+		return errors.Errorf("追加するデータのサイズが上限を超えています: size=%d", len(v.Data))
+	}
+	return nil
+}
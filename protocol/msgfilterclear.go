@@ -0,0 +1,25 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgFilterClear はピアに設定したブルームフィルタを解除するメッセージ(BIP37)
+// Payloadを持たない
+// https://github.com/bitcoin/bips/blob/master/bip-0037.mediawiki#new-messages
+type MsgFilterClear struct{}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgFilterClear) Command() string {
+	return "filterclear"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgFilterClear) Serialize(w io.Writer, pver Version) error {
+	return checkVersion(v.Command(), pver, BIP37Version)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgFilterClear) Deserialize(r io.Reader, pver Version) error {
+	return checkVersion(v.Command(), pver, BIP37Version)
+}
@@ -0,0 +1,59 @@
+package protocol
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// MsgFilterLoad はピアにブルームフィルタを設定するメッセージ(BIP37)
+// 以降のinvやmerkleblockはフィルタに一致するトランザクションだけになります
+// https://github.com/bitcoin/bips/blob/master/bip-0037.mediawiki#new-messages
+type MsgFilterLoad struct {
+	Filter    []byte
+	HashFuncs uint32
+	Tweak     uint32
+	Flags     BloomUpdateType
+}
+
+// NewMsgFilterLoad はMsgFilterLoadメッセージを生成します
+func NewMsgFilterLoad(filter []byte, hashFuncs, tweak uint32, flags BloomUpdateType) *MsgFilterLoad {
+	return &MsgFilterLoad{Filter: filter, HashFuncs: hashFuncs, Tweak: tweak, Flags: flags}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgFilterLoad) Command() string {
+	return "filterload"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgFilterLoad) Serialize(w io.Writer, pver Version) error {
+	if err := checkVersion(v.Command(), pver, BIP37Version); err != nil {
+		return err
+	}
+	if err := v.validate(); err != nil {
+		return err
+	}
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgFilterLoad) Deserialize(r io.Reader, pver Version) error {
+	if err := checkVersion(v.Command(), pver, BIP37Version); err != nil {
+		return err
+	}
+	if err := DeserializeStruct(r, pver, v); err != nil {
+		return err
+	}
+	return v.validate()
+}
+
+func (v *MsgFilterLoad) validate() error {
+	if MaxFilterLoadFilterSize < len(v.Filter) {
+		return errors.Errorf("フィルタのサイズが上限を超えています: size=%d", len(v.Filter))
+	}
+	if MaxFilterLoadHashFuncs < v.HashFuncs {
+		return errors.Errorf("ハッシュ関数の数が上限を超えています: count=%d", v.HashFuncs)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgGetAddr は接続できるノードのアドレスを要求するメッセージ
+// 受け取ったノードはaddrかaddrv2でアドレスを返します
+type MsgGetAddr struct{}
+
+// NewMsgGetAddr はMsgGetAddrメッセージを生成します
+func NewMsgGetAddr() *MsgGetAddr {
+	return &MsgGetAddr{}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgGetAddr) Command() string {
+	return "getaddr"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgGetAddr) Serialize(w io.Writer, pver Version) error {
+	return nil
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgGetAddr) Deserialize(r io.Reader, pver Version) error {
+	return nil
+}
@@ -0,0 +1,41 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgGetBlocks はブロックロケーター以降のブロックのインベントリを要求するメッセージ
+// invで最大500個のブロックが通知されます
+// https://en.bitcoin.it/wiki/Protocol_documentation#getblocks
+type MsgGetBlocks struct {
+	// プロトコルのバージョン
+	ProtocolVersion Version
+	// 自身が持っているチェーンのブロックロケーター
+	BlockLocator BlockLocator `btc:"max=101"`
+	// このハッシュのブロックまでで止める、0の場合は上限まで
+	HashStop Hash
+}
+
+// NewMsgGetBlocks はMsgGetBlocksメッセージを生成します
+func NewMsgGetBlocks(locator BlockLocator, hashStop Hash) *MsgGetBlocks {
+	return &MsgGetBlocks{
+		ProtocolVersion: CurrentVersion,
+		BlockLocator:    locator,
+		HashStop:        hashStop,
+	}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgGetBlocks) Command() string {
+	return "getblocks"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgGetBlocks) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgGetBlocks) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
@@ -0,0 +1,76 @@
+package protocol
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// MsgGetBlockTxn はコンパクトブロックで足りないトランザクションを要求するメッセージ(BIP152)
+// インデックスは直前のインデックスとの差分で送ります
+// https://github.com/bitcoin/bips/blob/master/bip-0152.mediawiki#getblocktxn
+type MsgGetBlockTxn struct {
+	BlockHash Hash
+	// 昇順に並べたブロック内のトランザクションのインデックス
+	Indexes []uint32
+}
+
+// NewMsgGetBlockTxn はMsgGetBlockTxnメッセージを生成します
+func NewMsgGetBlockTxn(blockHash Hash, indexes []uint32) *MsgGetBlockTxn {
+	return &MsgGetBlockTxn{BlockHash: blockHash, Indexes: indexes}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgGetBlockTxn) Command() string {
+	return "getblocktxn"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+// インデックスを直前との差分で送るため、タグを使わずに書いています
+func (v *MsgGetBlockTxn) Serialize(w io.Writer, pver Version) error {
+	if err := checkVersion(v.Command(), pver, CompactBlocksVersion); err != nil {
+		return err
+	}
+	diffs, err := differentialIndexes(v.Indexes)
+	if err != nil {
+		return err
+	}
+	if err := BulkSerialize(w, v.BlockHash, VarUint(len(diffs))); err != nil {
+		return err
+	}
+	for _, diff := range diffs {
+		if err := Serialize(w, VarUint(diff)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgGetBlockTxn) Deserialize(r io.Reader, pver Version) error {
+	if err := checkVersion(v.Command(), pver, CompactBlocksVersion); err != nil {
+		return err
+	}
+	var count VarUint
+	if err := BulkDeserialize(r, &v.BlockHash, &count); err != nil {
+		return err
+	}
+	if maxCompactBlockTxs < count {
+		return errors.Errorf("インデックスの数が多すぎます: count=%d", count)
+	}
+	v.Indexes = make([]uint32, count)
+	last := -1
+	for i := range v.Indexes {
+		var diff VarUint
+		if err := Deserialize(r, &diff); err != nil {
+			return err
+		}
+		index, err := nextIndex(last, diff)
+		if err != nil {
+			return err
+		}
+		v.Indexes[i] = uint32(index)
+		last = index
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgGetCFCheckpt は一定間隔のブロックのフィルタヘッダーを要求するメッセージ(BIP157)
+// https://github.com/bitcoin/bips/blob/master/bip-0157.mediawiki#getcfcheckpt
+type MsgGetCFCheckpt struct {
+	FilterType FilterType
+	StopHash   Hash
+}
+
+// NewMsgGetCFCheckpt はMsgGetCFCheckptメッセージを生成します
+func NewMsgGetCFCheckpt(filterType FilterType, stopHash Hash) *MsgGetCFCheckpt {
+	return &MsgGetCFCheckpt{FilterType: filterType, StopHash: stopHash}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgGetCFCheckpt) Command() string {
+	return "getcfcheckpt"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgGetCFCheckpt) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgGetCFCheckpt) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
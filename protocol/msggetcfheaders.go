@@ -0,0 +1,33 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgGetCFHeaders はブロックの範囲のフィルタヘッダーを要求するメッセージ(BIP157)
+// https://github.com/bitcoin/bips/blob/master/bip-0157.mediawiki#getcfheaders
+type MsgGetCFHeaders struct {
+	FilterType  FilterType
+	StartHeight uint32
+	StopHash    Hash
+}
+
+// NewMsgGetCFHeaders はMsgGetCFHeadersメッセージを生成します
+func NewMsgGetCFHeaders(filterType FilterType, startHeight uint32, stopHash Hash) *MsgGetCFHeaders {
+	return &MsgGetCFHeaders{FilterType: filterType, StartHeight: startHeight, StopHash: stopHash}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgGetCFHeaders) Command() string {
+	return "getcfheaders"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgGetCFHeaders) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgGetCFHeaders) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
@@ -0,0 +1,37 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MaxGetCFiltersReqRange は1つのgetcfiltersで要求できるブロックの最大数
+const MaxGetCFiltersReqRange = 1000
+
+// MsgGetCFilters はブロックの範囲のコンパクトブロックフィルタを要求するメッセージ(BIP157)
+// StartHeightからStopHashのブロックまでのフィルタがcfilterで1つずつ返ってきます
+// https://github.com/bitcoin/bips/blob/master/bip-0157.mediawiki#getcfilters
+type MsgGetCFilters struct {
+	FilterType  FilterType
+	StartHeight uint32
+	StopHash    Hash
+}
+
+// NewMsgGetCFilters はMsgGetCFiltersメッセージを生成します
+func NewMsgGetCFilters(filterType FilterType, startHeight uint32, stopHash Hash) *MsgGetCFilters {
+	return &MsgGetCFilters{FilterType: filterType, StartHeight: startHeight, StopHash: stopHash}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgGetCFilters) Command() string {
+	return "getcfilters"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgGetCFilters) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgGetCFilters) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
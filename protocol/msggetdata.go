@@ -0,0 +1,38 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgGetData はインベントリで指定したトランザクションやブロックを要求するメッセージ
+// https://en.bitcoin.it/wiki/Protocol_documentation#getdata
+type MsgGetData struct {
+	InvList []*InvVect `btc:"max=50000"`
+}
+
+// NewMsgGetData はMsgGetDataメッセージを生成します
+func NewMsgGetData() *MsgGetData {
+	return &MsgGetData{}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgGetData) Command() string {
+	return "getdata"
+}
+
+// AddInvVect はインベントリを追加します
+// MaxInvPerMsgを超える場合はエラーになります
+func (v *MsgGetData) AddInvVect(iv *InvVect) (err error) {
+	v.InvList, err = addInvVect(v.InvList, iv)
+	return err
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgGetData) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgGetData) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
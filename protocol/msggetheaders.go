@@ -0,0 +1,41 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgGetHeaders はブロックロケーター以降のブロックヘッダーを要求するメッセージ
+// headersで最大2000個のブロックヘッダーが返されます
+// https://en.bitcoin.it/wiki/Protocol_documentation#getheaders
+type MsgGetHeaders struct {
+	// プロトコルのバージョン
+	ProtocolVersion Version
+	// 自身が持っているチェーンのブロックロケーター
+	BlockLocator BlockLocator `btc:"max=101"`
+	// このハッシュのブロックまでで止める、0の場合は上限まで
+	HashStop Hash
+}
+
+// NewMsgGetHeaders はMsgGetHeadersメッセージを生成します
+func NewMsgGetHeaders(locator BlockLocator, hashStop Hash) *MsgGetHeaders {
+	return &MsgGetHeaders{
+		ProtocolVersion: CurrentVersion,
+		BlockLocator:    locator,
+		HashStop:        hashStop,
+	}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgGetHeaders) Command() string {
+	return "getheaders"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgGetHeaders) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgGetHeaders) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
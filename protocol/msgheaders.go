@@ -0,0 +1,84 @@
+package protocol
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// MaxHeadersPerMsg は1つのheadersメッセージに含められるブロックヘッダーの最大数
+const MaxHeadersPerMsg = 2000
+
+// MsgHeaders はgetheadersに対してブロックヘッダーを返すメッセージ
+// 各ブロックヘッダーの後にトランザクション数が続くが、常に0になっています
+// https://en.bitcoin.it/wiki/Protocol_documentation#headers
+type MsgHeaders struct {
+	Headers []*BlockHeader
+}
+
+// NewMsgHeaders はMsgHeadersメッセージを生成します
+func NewMsgHeaders() *MsgHeaders {
+	return &MsgHeaders{}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgHeaders) Command() string {
+	return "headers"
+}
+
+// AddBlockHeader はブロックヘッダーを追加します
+func (v *MsgHeaders) AddBlockHeader(h *BlockHeader) error {
+	if MaxHeadersPerMsg <= len(v.Headers) {
+		return errors.Errorf("ブロックヘッダーの数が上限を超えます: max=%d", MaxHeadersPerMsg)
+	}
+	v.Headers = append(v.Headers, h)
+	return nil
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+// 各ブロックヘッダーの後に常に0のトランザクション数が続くため、タグを使わずに書いています
+func (v *MsgHeaders) Serialize(w io.Writer, pver Version) error {
+	if MaxHeadersPerMsg < len(v.Headers) {
+		return errors.Errorf("ブロックヘッダーの数が上限を超えています: count=%d", len(v.Headers))
+	}
+	if err := Serialize(w, VarUint(len(v.Headers))); err != nil {
+		return err
+	}
+	for _, h := range v.Headers {
+		if err := h.Serialize(w); err != nil {
+			return err
+		}
+		// トランザクション数
+		if err := Serialize(w, VarUint(0)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgHeaders) Deserialize(r io.Reader, pver Version) error {
+	var count VarUint
+	if err := Deserialize(r, &count); err != nil {
+		return err
+	}
+	if MaxHeadersPerMsg < count {
+		return errors.Errorf("ブロックヘッダーの数が上限を超えています: count=%d", count)
+	}
+	v.Headers = make([]*BlockHeader, count)
+	for i := range v.Headers {
+		h := &BlockHeader{}
+		if err := h.Deserialize(r); err != nil {
+			return err
+		}
+		var txCount VarUint
+		if err := Deserialize(r, &txCount); err != nil {
+			return err
+		}
+		if txCount != 0 {
+			return errors.Errorf("ブロックヘッダーのトランザクション数が0ではありません: count=%d", txCount)
+		}
+		v.Headers[i] = h
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgInv は持っているトランザクションやブロックを通知するメッセージ
+// https://en.bitcoin.it/wiki/Protocol_documentation#inv
+type MsgInv struct {
+	InvList []*InvVect `btc:"max=50000"`
+}
+
+// NewMsgInv はMsgInvメッセージを生成します
+func NewMsgInv() *MsgInv {
+	return &MsgInv{}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgInv) Command() string {
+	return "inv"
+}
+
+// AddInvVect はインベントリを追加します
+// MaxInvPerMsgを超える場合はエラーになります
+func (v *MsgInv) AddInvVect(iv *InvVect) (err error) {
+	v.InvList, err = addInvVect(v.InvList, iv)
+	return err
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgInv) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgInv) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
@@ -0,0 +1,101 @@
+package protocol
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// MsgMerkleBlock はブルームフィルタに一致したトランザクションの部分マークルツリーを送るメッセージ(BIP37)
+// 一致したトランザクション自体はこのメッセージの後にtxで送られてきます
+// https://github.com/bitcoin/bips/blob/master/bip-0037.mediawiki#merkleblock
+type MsgMerkleBlock struct {
+	Header BlockHeader
+	// ブロックに含まれるトランザクションの数
+	Transactions uint32
+	// ハッシュの数はトランザクションの数(maxMerkleBlockTxs)以下になる
+	Hashes []Hash `btc:"max=16666"`
+	Flags  []byte
+}
+
+// NewMsgMerkleBlock はブロックとフィルタに一致したトランザクションからMsgMerkleBlockを生成します
+// matchesはブロックのトランザクションと同じ順番で一致したかどうかを表します
+func NewMsgMerkleBlock(block *Block, matches []bool) (*MsgMerkleBlock, error) {
+	if len(matches) != len(block.Transactions) {
+		return nil, errors.Errorf("一致したかどうかの数がトランザクションの数と一致しません: %d != %d", len(matches), len(block.Transactions))
+	}
+	t := &partialMerkleTree{
+		txCount: len(block.Transactions),
+		txids:   make([]Hash, len(block.Transactions)),
+		matches: matches,
+	}
+	for i, tx := range block.Transactions {
+		t.txids[i] = tx.TxHash()
+	}
+	if t.txCount == 0 {
+		return nil, errors.New("トランザクションがありません")
+	}
+	t.build(t.height(), 0)
+	return &MsgMerkleBlock{
+		Header:       block.Header,
+		Transactions: uint32(t.txCount),
+		Hashes:       t.hashes,
+		Flags:        packFlags(t.flags),
+	}, nil
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgMerkleBlock) Command() string {
+	return "merkleblock"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgMerkleBlock) Serialize(w io.Writer, pver Version) error {
+	if err := checkVersion(v.Command(), pver, BIP37Version); err != nil {
+		return err
+	}
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgMerkleBlock) Deserialize(r io.Reader, pver Version) error {
+	if err := checkVersion(v.Command(), pver, BIP37Version); err != nil {
+		return err
+	}
+	return DeserializeStruct(r, pver, v)
+}
+
+// ExtractMatches は部分マークルツリーを検証して一致したトランザクションのtxidとブロック内のインデックスを返します
+// 計算したマークルルートがブロックヘッダーと一致しない場合はエラーになります
+func (v *MsgMerkleBlock) ExtractMatches() (matched []Hash, indexes []uint32, err error) {
+	if v.Transactions == 0 {
+		return nil, nil, errors.New("トランザクションがありません")
+	}
+	if maxMerkleBlockTxs < v.Transactions {
+		return nil, nil, errors.Errorf("トランザクションの数が多すぎます: count=%d", v.Transactions)
+	}
+	if int(v.Transactions) < len(v.Hashes) {
+		return nil, nil, errors.Errorf("ハッシュの数がトランザクションの数より多いです: %d", len(v.Hashes))
+	}
+	if len(v.Flags)*8 < len(v.Hashes) {
+		return nil, nil, errors.Errorf("フラグの数がハッシュの数より少ないです: %d", len(v.Flags)*8)
+	}
+
+	t := &partialMerkleTree{
+		txCount: int(v.Transactions),
+		hashes:  v.Hashes,
+		flags:   unpackFlags(v.Flags),
+	}
+	root, err := t.extract(t.height(), 0, &matched, &indexes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "部分マークルツリーが不正です")
+	}
+	// 全てのフラグ(バイト単位)とハッシュを使い切っている必要がある
+	if (t.bitsUsed+7)/8 != len(v.Flags) || t.hashUsed != len(v.Hashes) {
+		return nil, nil, errors.New("部分マークルツリーに使われていないデータがあります")
+	}
+	if root != v.Header.MerkleRoot {
+		return nil, nil, errors.Errorf("マークルルートが一致しません: %s != %s", root, v.Header.MerkleRoot)
+	}
+	return matched, indexes, nil
+}
@@ -0,0 +1,38 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgNotFound はgetdataで要求されたデータが見つからなかったことを通知するメッセージ
+// https://en.bitcoin.it/wiki/Protocol_documentation#notfound
+type MsgNotFound struct {
+	InvList []*InvVect `btc:"max=50000"`
+}
+
+// NewMsgNotFound はMsgNotFoundメッセージを生成します
+func NewMsgNotFound() *MsgNotFound {
+	return &MsgNotFound{}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgNotFound) Command() string {
+	return "notfound"
+}
+
+// AddInvVect はインベントリを追加します
+// MaxInvPerMsgを超える場合はエラーになります
+func (v *MsgNotFound) AddInvVect(iv *InvVect) (err error) {
+	v.InvList, err = addInvVect(v.InvList, iv)
+	return err
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgNotFound) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgNotFound) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
@@ -0,0 +1,32 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgPing は接続が生きているか確認するためのメッセージ
+// 受け取ったノードは同じNonceのpongを返します
+type MsgPing struct {
+	// pongと対応付けるためのランダムな値
+	Nonce uint64
+}
+
+// NewMsgPing はMsgPingメッセージを生成します
+func NewMsgPing(nonce uint64) *MsgPing {
+	return &MsgPing{Nonce: nonce}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgPing) Command() string {
+	return "ping"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgPing) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgPing) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
@@ -0,0 +1,31 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgPong はpingに対する応答のメッセージ
+type MsgPong struct {
+	// 受け取ったpingのNonce
+	Nonce uint64
+}
+
+// NewMsgPong はMsgPongメッセージを生成します
+func NewMsgPong(nonce uint64) *MsgPong {
+	return &MsgPong{Nonce: nonce}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgPong) Command() string {
+	return "pong"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgPong) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgPong) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
+}
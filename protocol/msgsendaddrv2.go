@@ -0,0 +1,25 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgSendAddrV2 はアドレスをaddrv2で通知するよう要求するメッセージ(BIP155)
+// verackより前に送る必要があり、Payloadを持たない
+// https://github.com/bitcoin/bips/blob/master/bip-0155.mediawiki
+type MsgSendAddrV2 struct{}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgSendAddrV2) Command() string {
+	return "sendaddrv2"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgSendAddrV2) Serialize(w io.Writer, pver Version) error {
+	return checkVersion(v.Command(), pver, WTxIDRelayVersion)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgSendAddrV2) Deserialize(r io.Reader, pver Version) error {
+	return checkVersion(v.Command(), pver, WTxIDRelayVersion)
+}
@@ -0,0 +1,56 @@
+package protocol
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// コンパクトブロックのバージョン
+const (
+	// CompactBlockVersion1 はtxidでショートIDを計算し、ウィットネスを含めないバージョン
+	CompactBlockVersion1 uint64 = 1
+	// CompactBlockVersion2 はwtxidでショートIDを計算し、ウィットネスを含めるバージョン
+	CompactBlockVersion2 uint64 = 2
+)
+
+// MsgSendCmpct はコンパクトブロックでブロックを受け取れることを通知するメッセージ(BIP152)
+// https://github.com/bitcoin/bips/blob/master/bip-0152.mediawiki#sendcmpct
+type MsgSendCmpct struct {
+	// trueの場合はinvやheadersを待たずにcmpctblockで新しいブロックを通知するよう要求する
+	Announce bool
+	// コンパクトブロックのバージョン
+	Version uint64
+}
+
+// NewMsgSendCmpct はMsgSendCmpctメッセージを生成します
+func NewMsgSendCmpct(announce bool, version uint64) *MsgSendCmpct {
+	return &MsgSendCmpct{Announce: announce, Version: version}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgSendCmpct) Command() string {
+	return "sendcmpct"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgSendCmpct) Serialize(w io.Writer, pver Version) error {
+	if err := checkVersion(v.Command(), pver, CompactBlocksVersion); err != nil {
+		return err
+	}
+	return SerializeStruct(w, pver, v)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgSendCmpct) Deserialize(r io.Reader, pver Version) error {
+	if err := checkVersion(v.Command(), pver, CompactBlocksVersion); err != nil {
+		return err
+	}
+	if err := DeserializeStruct(r, pver, v); err != nil {
+		return err
+	}
+	if v.Version == 0 {
+		return errors.New("コンパクトブロックのバージョンが不正です")
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgSendHeaders は新しいブロックをinvではなくheadersで通知するよう要求するメッセージ(BIP130)
+// Payloadを持たない
+// https://github.com/bitcoin/bips/blob/master/bip-0130.mediawiki
+type MsgSendHeaders struct{}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgSendHeaders) Command() string {
+	return "sendheaders"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgSendHeaders) Serialize(w io.Writer, pver Version) error {
+	return checkVersion(v.Command(), pver, SendHeadersVersion)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgSendHeaders) Deserialize(r io.Reader, pver Version) error {
+	return checkVersion(v.Command(), pver, SendHeadersVersion)
+}
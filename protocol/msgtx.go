@@ -0,0 +1,37 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgTx はトランザクションを送るメッセージ
+// https://en.bitcoin.it/wiki/Protocol_documentation#tx
+type MsgTx struct {
+	Tx *Tx
+	// シリアライズ形式、NodeWitnessを提供していないピアにはBaseEncodingで送る
+	Encoding MessageEncoding
+}
+
+// NewMsgTx はMsgTxメッセージを生成します
+func NewMsgTx(tx *Tx, encoding MessageEncoding) *MsgTx {
+	return &MsgTx{Tx: tx, Encoding: encoding}
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgTx) Command() string {
+	return "tx"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgTx) Serialize(w io.Writer, pver Version) error {
+	if v.Encoding == BaseEncoding {
+		return v.Tx.SerializeNoWitness(w)
+	}
+	return v.Tx.Serialize(w)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgTx) Deserialize(r io.Reader, pver Version) error {
+	v.Tx = &Tx{}
+	return v.Tx.Deserialize(r)
+}
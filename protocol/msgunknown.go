@@ -0,0 +1,40 @@
+package protocol
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// UnknownMessage はこのクライアントが知らないコマンドのメッセージ
+// Payloadは解釈せずにそのまま保持します
+type UnknownMessage struct {
+	// 受信したコマンド名
+	CommandName string
+	// 解釈していないPayload
+	Payload []byte
+}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *UnknownMessage) Command() string {
+	return v.CommandName
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *UnknownMessage) Serialize(w io.Writer, pver Version) error {
+	if _, err := w.Write(v.Payload); err != nil {
+		return errors.Wrap(err, "Payloadの書き込みに失敗しました")
+	}
+	return nil
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *UnknownMessage) Deserialize(r io.Reader, pver Version) error {
+	payload, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Wrap(err, "Payloadの読み込みに失敗しました")
+	}
+	v.Payload = payload
+	return nil
+}
@@ -0,0 +1,24 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgVerAck はバージョンメッセージを受け入れたことを通知するメッセージ
+// Payloadを持たない
+type MsgVerAck struct{}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgVerAck) Command() string {
+	return "verack"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgVerAck) Serialize(w io.Writer, pver Version) error {
+	return nil
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgVerAck) Deserialize(r io.Reader, pver Version) error {
+	return nil
+}
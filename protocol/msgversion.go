@@ -8,7 +8,7 @@ import (
 // MsgVersion はノードへの接続時に通信するためのバージョンメッセージを表す型
 type MsgVersion struct {
 	// プロトコルのバージョン
-	ProtocolVersion Version
+	ProtocolVersion Version `btc:"pver"`
 	// ノードが提供するサービス一覧
 	Services ServiceFlags
 	// メッセージが作られた時刻
@@ -16,15 +16,15 @@ type MsgVersion struct {
 	// 受けてのネットワークアドレス
 	AddrRerv NetAddress
 	// ノードのネットワークアドレス
-	AddrFrom NetAddress
+	AddrFrom NetAddress `btc:"optional"`
 	// 送信時にランダムに生成される値
-	Nonce uint64
+	Nonce uint64 `btc:"optional"`
 	// ユーザーエージェント名
-	UserAgent UserAgentName
+	UserAgent UserAgentName `btc:"optional"`
 	// 自身のノードが持っているブロックの高さ
-	StartHeight int32
-	// INVを送られないようにする設定
-	Relay bool
+	StartHeight int32 `btc:"optional"`
+	// INVを送られないようにする設定、BIP37に対応したバージョン70001から追加されました
+	Relay bool `btc:"minver=70001,optional"`
 }
 
 // Command はこのメッセージのコマンド名を返します
@@ -49,33 +49,13 @@ func NewMsgVersion(rervAddr, fromAddr *NetAddress, nonce uint64, blockHeight int
 }
 
 // Serialize はMessageのPayloadをシリアライズする
-func (v *MsgVersion) Serialize(w io.Writer) error {
-	return BulkSerialize(
-		w,
-		v.ProtocolVersion,
-		v.Services,
-		v.Timestamp,
-		v.AddrRerv,
-		v.AddrFrom,
-		v.Nonce,
-		v.UserAgent,
-		v.StartHeight,
-		v.Relay,
-	)
+func (v *MsgVersion) Serialize(w io.Writer, pver Version) error {
+	return SerializeStruct(w, v.ProtocolVersion, v)
 }
 
 // Deserialize はMessageのPayloadをデシリアライズする
-func (v *MsgVersion) Deserialize(r io.Reader) error {
-	return BulkDeserialize(
-		r,
-		&v.ProtocolVersion,
-		&v.Services,
-		&v.Timestamp,
-		&v.AddrRerv,
-		&v.AddrFrom,
-		&v.Nonce,
-		&v.UserAgent,
-		&v.StartHeight,
-		&v.Relay,
-	)
+// Relayは送信元のバージョンがBIP37Version以上の場合だけ読み込みます
+// 古いノードが後ろのフィールドを省略している場合はゼロ値のままにします
+func (v *MsgVersion) Deserialize(r io.Reader, pver Version) error {
+	return DeserializeStruct(r, pver, v)
 }
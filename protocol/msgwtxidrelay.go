@@ -0,0 +1,25 @@
+package protocol
+
+import (
+	"io"
+)
+
+// MsgWTxIDRelay はトランザクションをwtxidで通知するよう要求するメッセージ(BIP339)
+// verackより前に送る必要があり、Payloadを持たない
+// https://github.com/bitcoin/bips/blob/master/bip-0339.mediawiki
+type MsgWTxIDRelay struct{}
+
+// Command はこのメッセージのコマンド名を返します
+func (v *MsgWTxIDRelay) Command() string {
+	return "wtxidrelay"
+}
+
+// Serialize はMessageのPayloadをシリアライズする
+func (v *MsgWTxIDRelay) Serialize(w io.Writer, pver Version) error {
+	return checkVersion(v.Command(), pver, WTxIDRelayVersion)
+}
+
+// Deserialize はMessageのPayloadをデシリアライズする
+func (v *MsgWTxIDRelay) Deserialize(r io.Reader, pver Version) error {
+	return checkVersion(v.Command(), pver, WTxIDRelayVersion)
+}
@@ -0,0 +1,287 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/base32"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+// BIP155のアドレス形式
+// https://github.com/bitcoin/bips/blob/master/bip-0155.mediawiki
+
+// MaxAddrPerMsg は1つのaddrメッセージに含められるアドレスの最大数
+const MaxAddrPerMsg = 1000
+
+// maxAddrV2Size はaddrv2のアドレスのバイトサイズの最大値
+const maxAddrV2Size = 512
+
+// NetworkID はaddrv2のアドレスのネットワークの種類を表す型
+type NetworkID uint8
+
+const (
+	// NetworkIPv4 はIPv4アドレス
+	NetworkIPv4 NetworkID = 1
+	// NetworkIPv6 はIPv6アドレス
+	NetworkIPv6 NetworkID = 2
+	// NetworkTorV2 は廃止されたTor v2のonionアドレス
+	NetworkTorV2 NetworkID = 3
+	// NetworkTorV3 はTor v3のonionアドレス
+	NetworkTorV3 NetworkID = 4
+	// NetworkI2P はI2Pのアドレス
+	NetworkI2P NetworkID = 5
+	// NetworkCJDNS はCJDNSのアドレス
+	NetworkCJDNS NetworkID = 6
+)
+
+// networkAddrSizes はネットワークごとのアドレスのバイトサイズ
+var networkAddrSizes = map[NetworkID]int{
+	NetworkIPv4:  net.IPv4len,
+	NetworkIPv6:  net.IPv6len,
+	NetworkTorV2: 10,
+	NetworkTorV3: 32,
+	NetworkI2P:   32,
+	NetworkCJDNS: net.IPv6len,
+}
+
+const (
+	// torV3Version はTor v3のonionアドレスのバージョン
+	torV3Version byte = 0x03
+	// torV3Suffix はTorのアドレスのサフィックス
+	torV3Suffix = ".onion"
+	// i2pSuffix はI2Pのアドレスのサフィックス
+	i2pSuffix = ".b32.i2p"
+	// cjdnsPrefix はCJDNSのアドレスの先頭のバイト
+	cjdnsPrefix byte = 0xfc
+)
+
+// onionCatPrefix はTor v2のアドレスをIPv6に埋め込むためのプレフィックス
+var onionCatPrefix = []byte{0xfd, 0x87, 0xd8, 0x7e, 0xeb, 0x43}
+
+// addrBase32 はTorとI2Pのアドレスで使う小文字のBase32
+var addrBase32 = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// TimestampedNetAddress は最後に接続できた時刻を持つネットワークアドレス
+// addrメッセージで使います
+type TimestampedNetAddress struct {
+	// AddrTimeVersionより前のバージョンでは含まれません
+	Timestamp Uint32Time `btc:"minver=31402"`
+	NetAddress
+}
+
+// NetAddressV2 はBIP155のaddrv2で使う、IP以外のネットワークも表せるアドレス
+type NetAddressV2 struct {
+	// 最後に接続できた時刻
+	Timestamp Uint32Time
+	// ノードが提供するサービス一覧
+	Services ServiceFlags
+	// アドレスのネットワークの種類
+	NetworkID NetworkID
+	// ネットワークごとの形式のアドレス
+	Addr []byte
+	Port NetPort
+}
+
+// NewNetAddressV2FromIP はIPアドレスからNetAddressV2を生成します
+func NewNetAddressV2FromIP(ip net.IP, port NetPort, services ServiceFlags) *NetAddressV2 {
+	a := &NetAddressV2{Services: services, Port: port}
+	if ip4 := ip.To4(); ip4 != nil {
+		a.NetworkID = NetworkIPv4
+		a.Addr = []byte(ip4)
+	} else if ip16 := ip.To16(); ip16 != nil && ip16[0] == cjdnsPrefix {
+		a.NetworkID = NetworkCJDNS
+		a.Addr = []byte(ip16)
+	} else {
+		a.NetworkID = NetworkIPv6
+		a.Addr = []byte(ip.To16())
+	}
+	return a
+}
+
+// NewNetAddressV2FromString は「xyz.onion:8333」や「[::1]:8333」の形式の文字列からNetAddressV2を生成します
+// fc00::/8のIPv6アドレスはCJDNSのアドレスとして扱います
+func NewNetAddressV2FromString(hostport string, services ServiceFlags) (*NetAddressV2, error) {
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return nil, errors.Wrapf(err, "アドレスの形式が不正です: %q", hostport)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, errors.Wrapf(err, "ポート番号が不正です: %q", hostport)
+	}
+
+	a := &NetAddressV2{Services: services, Port: NetPort(port)}
+	host = strings.ToLower(host)
+	switch {
+	case strings.HasSuffix(host, torV3Suffix):
+		a.NetworkID = NetworkTorV3
+		if a.Addr, err = decodeTorV3(strings.TrimSuffix(host, torV3Suffix)); err != nil {
+			return nil, err
+		}
+	case strings.HasSuffix(host, i2pSuffix):
+		a.NetworkID = NetworkI2P
+		if a.Addr, err = addrBase32.DecodeString(strings.TrimSuffix(host, i2pSuffix)); err != nil {
+			return nil, errors.Wrapf(err, "I2Pのアドレスのデコードに失敗しました: %q", host)
+		}
+	default:
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return nil, errors.Errorf("IPアドレスの形式が不正です: %q", host)
+		}
+		a = NewNetAddressV2FromIP(ip, NetPort(port), services)
+	}
+
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Validate はネットワークの種類に対してアドレスが正しいか検証します
+func (a *NetAddressV2) Validate() error {
+	size, ok := networkAddrSizes[a.NetworkID]
+	if !ok {
+		return errors.Errorf("未対応のネットワークです: %d", a.NetworkID)
+	}
+	if len(a.Addr) != size {
+		return errors.Errorf("アドレスの長さが不正です: network=%d, length=%d", a.NetworkID, len(a.Addr))
+	}
+	switch a.NetworkID {
+	case NetworkTorV2:
+		return errors.New("Tor v2のアドレスは廃止されています")
+	case NetworkIPv6:
+		// IPv4やTor v2を埋め込んだIPv6アドレスは専用のネットワークで送る必要がある
+		if net.IP(a.Addr).To4() != nil || bytes.HasPrefix(a.Addr, onionCatPrefix) {
+			return errors.Errorf("IPv6として送れないアドレスです: %v", net.IP(a.Addr))
+		}
+	case NetworkCJDNS:
+		if a.Addr[0] != cjdnsPrefix {
+			return errors.Errorf("CJDNSのアドレスが不正です: %v", net.IP(a.Addr))
+		}
+	}
+	return nil
+}
+
+// IP はIPv4、IPv6、CJDNSのアドレスをnet.IPで返します
+// それ以外のネットワークの場合はnilを返します
+func (a *NetAddressV2) IP() net.IP {
+	switch a.NetworkID {
+	case NetworkIPv4, NetworkIPv6, NetworkCJDNS:
+		return net.IP(a.Addr)
+	default:
+		return nil
+	}
+}
+
+// Host はアドレスのホスト部分の文字列を返します
+func (a *NetAddressV2) Host() string {
+	switch a.NetworkID {
+	case NetworkTorV3:
+		return encodeTorV3(a.Addr) + torV3Suffix
+	case NetworkI2P:
+		return addrBase32.EncodeToString(a.Addr) + i2pSuffix
+	default:
+		if ip := a.IP(); ip != nil {
+			return ip.String()
+		}
+		return ""
+	}
+}
+
+// String は「xyz.onion:8333」の形式のアドレスの文字列を返します
+func (a *NetAddressV2) String() string {
+	return net.JoinHostPort(a.Host(), strconv.Itoa(int(a.Port)))
+}
+
+// NetAddress はaddrメッセージで送るためのNetAddressを返します
+// IPv4とIPv6以外のアドレスはaddrメッセージでは送れません
+func (a *NetAddressV2) NetAddress() (*NetAddress, error) {
+	switch a.NetworkID {
+	case NetworkIPv4, NetworkIPv6:
+		return &NetAddress{Services: a.Services, IP: a.IP(), Port: a.Port}, nil
+	default:
+		return nil, errors.Errorf("addrで送れないネットワークのアドレスです: %s", a)
+	}
+}
+
+// encodeTorV3 はTor v3の公開鍵からonionアドレスのホスト名を生成する
+// https://gitweb.torproject.org/torspec.git/tree/rend-spec-v3.txt
+func encodeTorV3(pubKey []byte) string {
+	buf := make([]byte, 0, len(pubKey)+3)
+	buf = append(buf, pubKey...)
+	buf = append(buf, torV3Checksum(pubKey)...)
+	buf = append(buf, torV3Version)
+	return addrBase32.EncodeToString(buf)
+}
+
+// decodeTorV3 はonionアドレスのホスト名からTor v3の公開鍵を取り出す
+func decodeTorV3(host string) ([]byte, error) {
+	raw, err := addrBase32.DecodeString(host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "onionアドレスのデコードに失敗しました: %q", host)
+	}
+	size := networkAddrSizes[NetworkTorV3]
+	if len(raw) != size+3 {
+		return nil, errors.Errorf("onionアドレスの長さが不正です: %q", host)
+	}
+	pubKey, checksum, version := raw[:size], raw[size:size+2], raw[size+2]
+	if version != torV3Version {
+		return nil, errors.Errorf("onionアドレスのバージョンが不正です: %d", version)
+	}
+	if !bytes.Equal(checksum, torV3Checksum(pubKey)) {
+		return nil, errors.Errorf("onionアドレスのチェックサムが一致しません: %q", host)
+	}
+	return pubKey, nil
+}
+
+// torV3Checksum はonionアドレスのチェックサムを計算する
+// CHECKSUM = SHA3_256(".onion checksum" | PUBKEY | VERSION)[:2]
+func torV3Checksum(pubKey []byte) []byte {
+	h := sha3.New256()
+	h.Write([]byte(".onion checksum"))
+	h.Write(pubKey)
+	h.Write([]byte{torV3Version})
+	return h.Sum(nil)[:2]
+}
+
+// serializeNetAddressV2 はaddrv2のアドレスをシリアライズする
+// Servicesは可変長の数値になります
+func serializeNetAddressV2(w io.Writer, a *NetAddressV2) error {
+	if maxAddrV2Size < len(a.Addr) {
+		return errors.Errorf("アドレスが大きすぎます: length=%d", len(a.Addr))
+	}
+	return BulkSerialize(w, a.Timestamp, VarUint(a.Services), uint8(a.NetworkID), a.Addr, a.Port)
+}
+
+// deserializeNetAddressV2 はaddrv2のアドレスをデシリアライズする
+func deserializeNetAddressV2(r io.Reader, a *NetAddressV2) error {
+	var services VarUint
+	var networkID uint8
+	if err := BulkDeserialize(r, &a.Timestamp, &services, &networkID); err != nil {
+		return err
+	}
+
+	var length VarUint
+	if err := Deserialize(r, &length); err != nil {
+		return err
+	}
+	if maxAddrV2Size < length {
+		return errors.Errorf("アドレスが大きすぎます: length=%d", length)
+	}
+	a.Addr = make([]byte, length)
+	if _, err := io.ReadFull(r, a.Addr); err != nil {
+		return errors.Wrap(err, "アドレスの読み込みに失敗しました")
+	}
+	if err := Deserialize(r, &a.Port); err != nil {
+		return err
+	}
+
+	a.Services = ServiceFlags(services)
+	a.NetworkID = NetworkID(networkID)
+	return nil
+}
@@ -0,0 +1,119 @@
+package protocol
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestNetAddressV2String(t *testing.T) {
+	tests := []struct {
+		s         string
+		networkID NetworkID
+	}{
+		{"192.0.2.1:8333", NetworkIPv4},
+		{"[2001:db8::1]:8333", NetworkIPv6},
+		{"pg6mmjiyjmcrsslvykfwnntlaru7p5svn6y2ymmju6nubxndf4pscryd.onion:8333", NetworkTorV3},
+		{"ukeu3k5oycgaauneqgtnvselmt4yemvoilkln7jpvamvfx7dnkdq.b32.i2p:0", NetworkI2P},
+		{"[fc00:1:2:3:4:5:6:7]:8333", NetworkCJDNS},
+	}
+	for _, test := range tests {
+		addr, err := NewNetAddressV2FromString(test.s, NodeNetwork)
+		if err != nil {
+			t.Errorf("アドレスの変換に失敗しました: %s, %v", test.s, err)
+			continue
+		}
+		if addr.NetworkID != test.networkID {
+			t.Errorf("ネットワークが一致しません: %s, %d != %d", test.s, addr.NetworkID, test.networkID)
+		}
+		if addr.String() != test.s {
+			t.Errorf("文字列に戻したアドレスが一致しません: %s != %s", addr, test.s)
+		}
+	}
+
+	invalids := []string{
+		// チェックサムが不正
+		"pg6mmjiyjmcrsslvykfwnntlaru7p5svn6y2ymmju6nubxndf4pscryb.onion:8333",
+		// Tor v2は廃止
+		"expyuzz4wqqyqhjn.onion:8333",
+		"example.com:8333",
+		"192.0.2.1",
+	}
+	for _, s := range invalids {
+		if _, err := NewNetAddressV2FromString(s, NodeNetwork); err == nil {
+			t.Errorf("不正なアドレスが変換できました: %s", s)
+		}
+	}
+}
+
+func TestNetAddressV2Validate(t *testing.T) {
+	tests := []struct {
+		addr *NetAddressV2
+		ok   bool
+	}{
+		{&NetAddressV2{NetworkID: NetworkIPv4, Addr: make([]byte, 4)}, true},
+		{&NetAddressV2{NetworkID: NetworkIPv4, Addr: make([]byte, 16)}, false},
+		{&NetAddressV2{NetworkID: NetworkTorV3, Addr: make([]byte, 31)}, false},
+		{&NetAddressV2{NetworkID: NetworkTorV2, Addr: make([]byte, 10)}, false},
+		{&NetAddressV2{NetworkID: NetworkCJDNS, Addr: make([]byte, 16)}, false},
+		{&NetAddressV2{NetworkID: NetworkID(0x42), Addr: make([]byte, 4)}, false},
+	}
+	for i, test := range tests {
+		if err := test.addr.Validate(); (err == nil) != test.ok {
+			t.Errorf("検証結果が一致しません: %d, %v", i, err)
+		}
+	}
+}
+
+func TestMsgAddrV2UnknownNetwork(t *testing.T) {
+	known := &NetAddressV2{NetworkID: NetworkI2P, Addr: bytes.Repeat([]byte{0x01}, 32), Port: 0}
+	unknown := &NetAddressV2{NetworkID: NetworkID(0x42), Addr: []byte{0x01, 0x02, 0x03}, Port: 8333}
+
+	buf := &bytes.Buffer{}
+	msg := &MsgAddrV2{AddrList: []*NetAddressV2{unknown, known}}
+	if err := msg.Serialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	// 知らないネットワークのアドレスは読み飛ばされる
+	received := &MsgAddrV2{}
+	if err := received.Deserialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	if len(received.AddrList) != 1 || received.AddrList[0].NetworkID != NetworkI2P {
+		t.Errorf("知らないネットワークのアドレスが読み飛ばされていません: %v", received.AddrList)
+	}
+
+	// 既知のネットワークで長さが不正な場合はエラー
+	buf.Reset()
+	msg = &MsgAddrV2{AddrList: []*NetAddressV2{{NetworkID: NetworkIPv4, Addr: make([]byte, 5)}}}
+	if err := msg.Serialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	if err := received.Deserialize(buf, CurrentVersion); err == nil {
+		t.Errorf("長さが不正なアドレスを受け入れました")
+	}
+}
+
+func TestMsgAddrV2InvalidAddress(t *testing.T) {
+	valid := &NetAddressV2{NetworkID: NetworkIPv4, Addr: []byte{1, 2, 3, 4}, Port: 8333}
+	invalids := []*NetAddressV2{
+		// 廃止されたTor v2
+		{NetworkID: NetworkTorV2, Addr: bytes.Repeat([]byte{0x01}, 10), Port: 8333},
+		// IPv4射影アドレスをIPv6として送ったもの
+		{NetworkID: NetworkIPv6, Addr: net.ParseIP("1.2.3.4").To16(), Port: 8333},
+	}
+
+	buf := &bytes.Buffer{}
+	msg := &MsgAddrV2{AddrList: append(invalids, valid)}
+	if err := msg.Serialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	// Validateで拒否されるアドレスは読み飛ばされる
+	received := &MsgAddrV2{}
+	if err := received.Deserialize(buf, CurrentVersion); err != nil {
+		t.Fatal(err)
+	}
+	if len(received.AddrList) != 1 || received.AddrList[0].NetworkID != NetworkIPv4 {
+		t.Errorf("使えないアドレスが読み飛ばされていません: %v", received.AddrList)
+	}
+}
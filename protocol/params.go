@@ -0,0 +1,68 @@
+package protocol
+
+import (
+	"github.com/keiji0/btcwallet/core"
+	"github.com/pkg/errors"
+)
+
+// DNSSeed はノードのアドレスを返すDNSシードを表す型
+type DNSSeed struct {
+	// シードのホスト名
+	Host string
+	// 「x9.」のようなサブドメインで提供するサービスで絞り込めるかどうか
+	HasFiltering bool
+}
+
+// NetParams はネットワークごとの接続に必要な値
+type NetParams struct {
+	Net         core.NetworkType
+	Magic       MessageMagic
+	DefaultPort NetPort
+	// 最初に接続先を探すためのDNSシード、Bitcoin Coreのchainparams.cppと同じもの
+	DNSSeeds []DNSSeed
+	// DNSシードから接続先が見つからない場合に使う「host:port」の形式のアドレス、Bitcoin Coreの固定のシードノードと同じもの
+	SeedNodes []string
+}
+
+// MainNetParams はメインネットの値
+var MainNetParams = NetParams{
+	Net:         core.MainNetwork,
+	Magic:       MainNetMessageMagic,
+	DefaultPort: 8333,
+	DNSSeeds: []DNSSeed{
+		{"dnsseed.bluematt.me", true},
+		{"seed.bitcoin.jonasschnelli.ch", true},
+		{"seed.btc.petertodd.net", true},
+		{"seed.bitcoin.sprovoost.nl", true},
+		{"dnsseed.emzy.de", true},
+		{"seed.bitcoin.wiz.biz", true},
+		{"seed.mainnet.achownodes.xyz", true},
+	},
+	SeedNodes: mainNetSeedNodes,
+}
+
+// TestNet3Params はテストネットの値
+var TestNet3Params = NetParams{
+	Net:         core.TestNetwork,
+	Magic:       TestNet3MessageMagic,
+	DefaultPort: 18333,
+	DNSSeeds: []DNSSeed{
+		{"testnet-seed.bitcoin.jonasschnelli.ch", true},
+		{"seed.tbtc.petertodd.net", true},
+		{"testnet-seed.bluematt.me", true},
+		{"seed.testnet.achownodes.xyz", true},
+	},
+	SeedNodes: testNet3SeedNodes,
+}
+
+// NetworkParams はNetworkTypeからNetParamsを取得します
+func NetworkParams(netType core.NetworkType) (*NetParams, error) {
+	switch netType {
+	case core.MainNetwork:
+		return &MainNetParams, nil
+	case core.TestNetwork:
+		return &TestNet3Params, nil
+	default:
+		return nil, errors.Errorf("invalid NetworkType: %v", netType)
+	}
+}
@@ -0,0 +1,36 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/keiji0/btcwallet/core"
+)
+
+func TestNetworkParams(t *testing.T) {
+	for _, netType := range []core.NetworkType{core.MainNetwork, core.TestNetwork} {
+		params, err := NetworkParams(netType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(params.DNSSeeds) == 0 || len(params.SeedNodes) == 0 {
+			t.Errorf("シードがありません: %v", netType)
+		}
+		ipNodes := 0
+		for _, node := range params.SeedNodes {
+			addr, err := NewNetAddressV2FromString(node, NodeNetwork)
+			if err != nil {
+				t.Errorf("固定のシードノードの形式が不正です: %v", err)
+				continue
+			}
+			if addr.IP() != nil && addr.NetworkID != NetworkCJDNS {
+				ipNodes++
+				if addr.Port != params.DefaultPort {
+					t.Errorf("ポートが一致しません: %s", node)
+				}
+			}
+		}
+		if ipNodes == 0 {
+			t.Errorf("IPの固定のシードノードがありません: %v", netType)
+		}
+	}
+}
@@ -0,0 +1,2253 @@
+package protocol
+
+// 固定のシードノード
+// DNSシードからアドレスが見つからない場合に使うノードのアドレスで、Bitcoin Coreのsrc/chainparamsseeds.hから生成しています
+// https://github.com/bitcoin/bitcoin/blob/master/contrib/seeds/README.md
+
+// mainNetSeedNodes はメインネットの固定のシードノード
+var mainNetSeedNodes = []string{
+	"[fc11:f769:16e6:3611:58ae:1d4a:fcf7:57a4]:8333",
+	"[fc17:4369:5414:4b1f:5689:d3ed:4039:335c]:8333",
+	"[fc1f:22c3:95dc:a3af:4a93:8251:beb9:1858]:8333",
+	"[fc34:e655:39da:169f:adcd:b1e8:534:3920]:8333",
+	"[fc55:6715:db3d:3f44:ec62:ea3d:ada9:cccb]:8333",
+	"[fc57:be98:b90c:89a2:6807:69a0:827:947e]:8333",
+	"[fc5c:b3fc:84b4:1c7f:a5d8:4a91:dea5:3e19]:8333",
+	"[fc71:12bd:f5e7:71b1:5b4e:4e3:6228:ac0]:8333",
+	"[fc95:6edb:af65:9ea3:cd27:21ef:f5e2:29c6]:8333",
+	"[fca0:151:79ac:8992:b51e:bdc4:6ed9:41be]:8333",
+	"[fcc7:be49:ccd1:dc91:3125:f0da:457d:8ce]:8333",
+	"22pis7zmm4r466tciqekpwjwzf2qi3a536bow7k5tu5kxgmbvrkq.b32.i2p:0",
+	"233v26hmxol4qifttvckfvake7elrwulqylb5ktmqwi4xyyv4cuq.b32.i2p:0",
+	"23w2u3vg3nqipk4wcqghtrn54g34qex46hzttlg2a5hjmuojge7q.b32.i2p:0",
+	"24yhz3lx3z57r3ict75xfmafhkiveyya67vv2mhycg3ixtwer2iq.b32.i2p:0",
+	"252txiwgy2vzc5rna3iv2kyrswgdt2zsaptoaxqqua5ftatv3bda.b32.i2p:0",
+	"27gljvnqgcofy47cuxbala5mi5kkr6fs66tkqlm5sdrk6x3aafwa.b32.i2p:0",
+	"2bh2wjb42o67o3knf7l7627xlysjzhhu67dit62s7cef7p75dwrq.b32.i2p:0",
+	"2iom4hu2zavdbrx7gk7r5yozqeamhu5d7rmgd7athb632bgjx5fq.b32.i2p:0",
+	"2l6u4jruj4yzmxfcy2ndbtpp7chv76hbd3zxf4eygjj5bfewcjca.b32.i2p:0",
+	"2lb3bqwdhkmroxe5iouzas4ecooeco2zrgfwfy3erva6dxz4tdza.b32.i2p:0",
+	"2lhswjq25vwj6tn6ppuarbt63oyaspyug4c5xiijogj3u63xnoaq.b32.i2p:0",
+	"2loxq5qbaqurh7d5kcdkwu47ofeak5eexpk3sotrrcax2tblgphq.b32.i2p:0",
+	"2lz5vvifoytt6sudlif2embjtybpcvyzub6ynx67lce6pbpm4viq.b32.i2p:0",
+	"2m4gt7qurnr5kw3r2zyhafwmdzeftfnd44vbbrfiakos6mxshnfq.b32.i2p:0",
+	"2pjcuy7evjb6omowgrwwx5st7ybsb5scubnp7utelx3oeypdilzq.b32.i2p:0",
+	"2q5hlwqkflmsghoj4meiit2ji3kcg4fsmhpx3la4sem5izzdolpa.b32.i2p:0",
+	"2vtootk5igm2reb6obgypzi7kffgveorxibaloz4bw7raeurzvaq.b32.i2p:0",
+	"2wvq5am4tsc3ejeeunhhofp6tni244zkbqz7lbqjycjwcw7v6tva.b32.i2p:0",
+	"33xkez7ouhxcsny6qm2pov22vndbpbmz5fnax56krtg3gydxtzaq.b32.i2p:0",
+	"36vtqxsu2qeoqcq3dkb7apu4knau6i5ydw2hcry4varel6vg6via.b32.i2p:0",
+	"3au55qlkceywkayqrxeqmgb4qsgdvt646howeikg2jjlcw7z5ria.b32.i2p:0",
+	"3bne2vt7s2pdja4775j5pzqmlltz6agt4zu6wd4r2bo56bxo7uyq.b32.i2p:0",
+	"3covklglzlod2sxmjtyq3rlzqd57kcfjricj27yyexg3rc2hroqa.b32.i2p:0",
+	"3gocb7wc4zvbmmebktet7gujccuux4ifk3kqilnxnj5wpdpqx2hq.b32.i2p:0",
+	"3hziidrpzqvns4jwfqhzb7nydw4mtnnkawperfvnaru3zpup2ihq.b32.i2p:0",
+	"3itqaciamkeljje57copslizbknvpgttr43ybv5jm4jtp6h2fyeq.b32.i2p:0",
+	"3oa5fycx3tyechdfgootxcpap7sx7p3sawenrbzyyxrty74rt7zq.b32.i2p:0",
+	"3rgwk2zwdo343z5gjxzddvig2ivr2htncvmqxmvkvlta27ceroca.b32.i2p:0",
+	"3ryxvptrtzjug2ksk7dbwin4s67l3ucmjdqk5etd2p23mtb7tssq.b32.i2p:0",
+	"3uhk3ls4egoh4tyb5iye5d3nzeh6mhmz6c3akwrxoocm4teqdnsa.b32.i2p:0",
+	"3vsva6vx2ch7aeulh4c6ou442h3reoleube3nbbmi6yq4v72ynna.b32.i2p:0",
+	"3w6g24wbzey4nxdfsdfzkemrqtzajy5kdrogj3tdjhgor5jgqpnq.b32.i2p:0",
+	"42guqsvdee4tmq3qa5mjw5vjkzkkuk5eainecsrsdfex3wyf7twq.b32.i2p:0",
+	"42t3dewqxyxe3ypfj6oxdw4xkowulubfnnrjev2yoflglxefndea.b32.i2p:0",
+	"4443aa3oendla6crb3scqqvaod5izmeljvk4kz55vg3lnrntfu4a.b32.i2p:0",
+	"44feqyup3qou5jhd345jpdduj4hi75j6qz3nogspexxh2bou4nua.b32.i2p:0",
+	"44or6q4rt24pem2eyur2l3wbu4jt7jk3s6wt2a6ihqxlei42gyha.b32.i2p:0",
+	"44qgfpibsi7w7ulbg76yt5hyi33t6unleq7yifhbhdqdy5ihljyq.b32.i2p:0",
+	"45eh6rxbfli4ju26cqaf63vfs5xx6zgt7hn3uzzz3nem45d4ps6q.b32.i2p:0",
+	"46ldtgnx4zabq6dyplaabcwogdcqa6krbnhtjwtphzg74ulfawsa.b32.i2p:0",
+	"4as3nuxjhhi3ffodbo3bypswt32244rkwboheap5a7rdwgdvz6dq.b32.i2p:0",
+	"4ddofz5emee6dsb2qv5nyaedx2o6za655bvgvbv4kii27qd6ljla.b32.i2p:0",
+	"4gxfgqetkztm4nwlxdxauluaeq4yekbhxndbqdym3h4cyadephba.b32.i2p:0",
+	"4gzp3p25luwqqvtvlw7uc73k65fgi3l2x3657x66lsyanwsa5jka.b32.i2p:0",
+	"4lmhn2xqfineeyquxxv5ixozqkn4eyuzcclk626q67impx5hnnhq.b32.i2p:0",
+	"4osyqeknhx5qf3a73jeimexwclmt42cju6xdp7icja4ixxguu2hq.b32.i2p:0",
+	"4q73cealtcn7lwb52kduztw4f4ricyyxcfh3qja7pxjryqlkmtya.b32.i2p:0",
+	"4raeuz4b3iztmvin57lqtqgkisjc4h23fqdulqa3pv2cmcqgapga.b32.i2p:0",
+	"4unxgzwe2zlrfgivsl72b4z5he6jawedzuceu3usvlp7bcoj3c7q.b32.i2p:0",
+	"4v2k7bxwwgmc2slsm4pcnzfhqworcoqpbrjeq7p2nldoih43nzqq.b32.i2p:0",
+	"4w7fa64xd4jylaxdtsgfx7atipgskprcwwocdi4uftdln62mvvuq.b32.i2p:0",
+	"4zysjndkcf4ejkr4lx2q4gg2tyncwmzxcdzqw3xxwxbxfnganyhq.b32.i2p:0",
+	"565zbckmimt3f6b36abcat6ef66yfh4sqdebt6zpjqmchcc3af6a.b32.i2p:0",
+	"5bvmqumoadvgoorphz3ud37uhvx6ff24ecy2jp7cpi4kmu3ikqka.b32.i2p:0",
+	"5c43zsaqwfurtrn5bxvber5lscaxyp7zuvwpj26ma2ggj7xvgf6q.b32.i2p:0",
+	"5kisb24hpv2fzfdsd5t6bxv7hb4gnrlrkk3xxpcjut5uroq4qjpq.b32.i2p:0",
+	"5l2pwxzyjsogfuek74l3cqfm2ab2mwa2l2rg3cphl3gmprbqf4aq.b32.i2p:0",
+	"5ogbkjldmlv6ygcvoz4jzatbpqpl725h7k24pzefyukc76ewfmfa.b32.i2p:0",
+	"5ovwsnx4lf2b5rxdkfrsmykmt4a5wjo7ohvh7fw4ccivgfp2rpxq.b32.i2p:0",
+	"5pcdrykqq4dewrtm4ngeduhivszcmzs5rvt5icxnjmazn4c3yxta.b32.i2p:0",
+	"5rd5qsrsbrhm4ff7pbtfxv2p4yevihpuomxhdzfqp4iymbrk6qta.b32.i2p:0",
+	"5u4sqx4okplgoh5cmlg2lbsbg6hxlzjuzj672telz4mxtohmpapq.b32.i2p:0",
+	"5vskqmjnrg22ph2nl6jy6v5eiwnahi5psbo5y5ihvehlvrg7qzea.b32.i2p:0",
+	"5w4mpjcejvt4udeht24yuww6ybda46r7zdnqknikv3u6q5ewqcoa.b32.i2p:0",
+	"63g7f42z6bm5hgtco5ze7rei43vgy63pjzw42pbnjbkcacitnvpq.b32.i2p:0",
+	"6cb5bbpnsnr4uxlkwtekxbg5qrwpvdqfpgncl46xndg3np4yfita.b32.i2p:0",
+	"6lzl6d7wxh2ft662unzjxchhikumefg2aqvlr7qpp3sjzg6cm5ea.b32.i2p:0",
+	"6oyjgcu7zsernyuavzhqluhjhct5aqphrdkfojejt56sjeg7vjxq.b32.i2p:0",
+	"6pxsoyh467crb7gipvphd63zsvhcbyc6rkci5q33gcwx7boj4njq.b32.i2p:0",
+	"6sf2n3fkr7slsrkyp5w2rq6cwaz6ygsib5vtlux56t6o2w7d6s6a.b32.i2p:0",
+	"6v6eemo3bg47ptogebf6bgw56ek45fl5x7dy6m54gonxkkr4tj7q.b32.i2p:0",
+	"6wtsedonta5yojby77ateqk2opazkduz7bur7loo7ds3r2u6xvaq.b32.i2p:0",
+	"77n5nsielc2dfxv5msgtjw53m2bh2lbf53y3zfxj4c4f7w7ofylq.b32.i2p:0",
+	"7cwmnymdmodgv7lcwadcsja6i4wvvbnyouu7l5yrqy6w5vvtczmq.b32.i2p:0",
+	"7gecxhs6zl65dumpkbn6ovk54doktkn5fimd5tj2rg3wk4evhwra.b32.i2p:0",
+	"7icwc26x3vs5wmi4xwboky6gqodh5meyx2wb2i3qfnbvl7yjlouq.b32.i2p:0",
+	"7l7n33ldlul3vb75qvwjcu5k7ttsn3rxznrlbl2zz3phk34dktrq.b32.i2p:0",
+	"7mpyrh4fcv532owy4s3hmmesfm6nylkimgba3aaom4f6e73mi2za.b32.i2p:0",
+	"7njv33sw7bi27i25nqhi6w7nqmwel7yxiim7u52fqrbvwjbdwj5q.b32.i2p:0",
+	"7nua4kkqzm6iivasfjiawlcqskbuhwdo2jnfase4u2q5mxxdd3eq.b32.i2p:0",
+	"7pmzb44ybjrq26wq24pdjz6tkktgwkyvvrpatmsbsx2xs3f6x43a.b32.i2p:0",
+	"7r4ri53lby2i3xqbgpw3idvhzeku7ubhftlf72ldqkg5kde6dauq.b32.i2p:0",
+	"7rbqtt4zauewbonbcvaiimflvxjkipb5t7gusyp7mfdqlepnkbdq.b32.i2p:0",
+	"7rqgahqbbnzuel6aibxjuhif7ubsqmabgk3rjaj7gjme25cismya.b32.i2p:0",
+	"7sdrfqmpk6sbg4hsgqxmjmju2ra6kof2xckxfuyaaedy7up5ryiq.b32.i2p:0",
+	"7sl3dq4djziftruvbxr75owesuia6mdjer2ppm7gs36ey3ipjt2a.b32.i2p:0",
+	"7tb3vukohkbdmohepeczd3hmc2wcnwv76qopaqgk53ikp4mqekya.b32.i2p:0",
+	"7ttjllhoemvcjwg4qzgrcrq2uy3c43ungndomb4eahcsjy25euyq.b32.i2p:0",
+	"7vjxkhzimrmsn2hsyhipr4ljamk5qfpb5leng7idhlczwf5n26eq.b32.i2p:0",
+	"7vtyfx7sojbqwudx5pu4yht7rqobel2lhcw4npir4swhrbybrfpa.b32.i2p:0",
+	"7vvhwgyhkdjr3bhsiniw7i2bjbtk4zaoryvfbfwix6l6ubsjh2za.b32.i2p:0",
+	"7wgrywhskd24kqqjvodx6bxnjnv374xlnrwssy7zqd4wza5drqmq.b32.i2p:0",
+	"7wzqg7mx25qdzz45grw6afrrut7guzgwtns7zucx45lm2eariqfa.b32.i2p:0",
+	"a2zioocy4z3jijn6qzl2grifqd7hvuk6kagjv5maoqrnxwoucdoq.b32.i2p:0",
+	"a6b7eslvht2jdr7xmsxkhchywfoyboyl2vvnipa4vkumfquaufea.b32.i2p:0",
+	"a6ljala36heefeiuks2vkgpyg4aavtsxp6rge4fkq5t6anu4lh4q.b32.i2p:0",
+	"aa4i2cn2kxk3zvzwxyhrzfo54dztsy5fgfdlu6bk3spsgdxi6sda.b32.i2p:0",
+	"ac6lub66mkfhq6fceeeclttgm5yvq4fiajbpklvffprzev36q2la.b32.i2p:0",
+	"ackrnxcfkel3hzebq4vc2fooz3n4fgwsyjun67dm3fdrktfkxvyq.b32.i2p:0",
+	"affs4rqb4vntm3cg5dkzas46i243u2mvbq5rorktuaglw35zw27a.b32.i2p:0",
+	"agvxq6qmctpzloyef7tgjdshzncnncl4gdus24lb7issae3hjteq.b32.i2p:0",
+	"ajol74c46igadlezendhvwt56gcmrdcahu4jdabt7o6n4vn2xv6q.b32.i2p:0",
+	"aoop5n4khqbob6fvc2nxyyoleenefrykudtrbtjsh3acsqjjkdqa.b32.i2p:0",
+	"aorbiqlyf3gwukti7w2cpe3msp2ak6d4qelya2umhph2gfa4d53a.b32.i2p:0",
+	"apdgfzupvtzyvpzmo7b7q6zl4laa25s46yu74svekpkysuherm7q.b32.i2p:0",
+	"ar7qkzt2awtbiumczcjbcnckg3gowsehs57x524rhnjxyzco6n4a.b32.i2p:0",
+	"at5wgtdk563uuhnhrpm5v6gkiolq3vgut67fydiwzfrbitgojvfa.b32.i2p:0",
+	"au2lms5c5toscangaxqkkrjwczjwf2tuozfi7j2eeoz5q3xpvg2a.b32.i2p:0",
+	"au6tn4iz5pmpmrlltkyeobzwlzgfl4z3two4kujysclqbc5flmlq.b32.i2p:0",
+	"ayn7r3jdijhcl6t6gexmq45dw2tap2l67rn7xgoisaenkel74wha.b32.i2p:0",
+	"azftalqowzsrodgik4xc3hjfglff5gqxzc5midgvweyv4pruyk3a.b32.i2p:0",
+	"b62tayy53lftamfhshxpem5ynt24ccoexmloe4kcehztscvzrjeq.b32.i2p:0",
+	"b6dp33kabap2qnpyo4mgbjit4qzspuj5f2kvccu4ft7r4tuf22na.b32.i2p:0",
+	"b7zqbsl2wl65ni2dlj2nkfy5fzx6wjjxwfzv4rnsstawrjgwsvpa.b32.i2p:0",
+	"bcteutyksedbco4wfirhe7qpdqpg2r25fxs7bqtzdgv3yc5qkhyq.b32.i2p:0",
+	"bfpeg5qpkber4dz5lttdbyto76nsgfno3keozwgm6tozazktaroq.b32.i2p:0",
+	"biaehvw42nqyyeeifcenwint77lhc6r47bvqovzhriaivyiikz3q.b32.i2p:0",
+	"blfl6i7utkvxrkh5jex3czphul7tnmoqpee5g6a3fdpgbqo4jkra.b32.i2p:0",
+	"bmpvfwbkqkvwwguvdhpl4h2osszp6bt5nryexocwcoh5zew4c3sq.b32.i2p:0",
+	"bnm4tb2vslom4isho6i4bx7ik62w2zp3zez3ljczr6qlayw6u6dq.b32.i2p:0",
+	"bnmbmavh7g4v3kjkncrjeqvrffkh3vhopkmubqwctqvkw43zz6nq.b32.i2p:0",
+	"bqdj5ch7s6ws2zajlrggtzldjop3tbpnec3lfmk2chhvk262llvq.b32.i2p:0",
+	"brifkruhlkgrj65hffybrjrjqcgdgqs2r7siizb5b2232nruik3a.b32.i2p:0",
+	"brqjrxca2y3u3phxjqwq7ugmzcvo5qlokl5ohkt4p6tgaxqwfiga.b32.i2p:0",
+	"bvxrprscym5odbiimsbzs74736nexgg57dr2keqamur4xzdaajbq.b32.i2p:0",
+	"bwq6y5u7jcx77ok4iqpjtiz5re4qagvhhlr3skhe3s4nphnrfhaa.b32.i2p:0",
+	"c3hvmk2meiccwlz77kx764rvvgeg4dnahvk3k47tp2ioo3tlrseq.b32.i2p:0",
+	"ca6ckpjbl4s6uwyvzirfn63jyrwvswonrb3ffi34dfwiydpgje7q.b32.i2p:0",
+	"celqthe6zrxaprhhcxqg3uewppwxisjola7dpniwvstpsu3r5xea.b32.i2p:0",
+	"cfn6ow2sesjrkxlnahytym56upd3zw7l5z3hmgeuv7cnbo2tiiaa.b32.i2p:0",
+	"cirb34a7vogem6k6trlp7dyy6bk646mugfvwz7nzjvkjelfzwdiq.b32.i2p:0",
+	"ciwvimts7zzoyrknhlowmzh35mzx4cluq7uycw3ffcckuov4tjjq.b32.i2p:0",
+	"cjapty7kllm3vxqr7pmgvmybw5oihwpg4wu76ugwr5qulzcpx5ja.b32.i2p:0",
+	"cjxlugrb3qn4pubcsdqbnxwjsqv5cskunnp6ttenw7osylowswea.b32.i2p:0",
+	"ckqlcye627fn3yznuicizxrlfvjbwympl4iw2c6ipeiv2qvedp7q.b32.i2p:0",
+	"cmnpbzyfif37aq2bncdtp2wankepsaxpvpexjpydwm6etufqseha.b32.i2p:0",
+	"cuqskmhnvxk3ugeb6s3iy2hzd2xtf2uhkmtia4m52w2xgfg3ji7a.b32.i2p:0",
+	"cv6s3cbuggzspsw6zesen5e6yhfvnt6xvcwqnmtckcktrwo3suqa.b32.i2p:0",
+	"cwxu32wuggkparz76hfz7rtcsoy7ggn3qfmcsgqqczqrm5ch2uda.b32.i2p:0",
+	"cxr2kyyd5wz27aozozyhj7a6baapky4f7ctskefufqrt7qn3feza.b32.i2p:0",
+	"cybsc47bfo2yjutwdcrbarq3xamugdmo2hdbygmuy4qjgtagvuyq.b32.i2p:0",
+	"cyc2ienvwkdm6p5xgrizstp5hvbovasvkmquxckfv263bwxs7d5a.b32.i2p:0",
+	"czbtpqrn2e3lbjwtzazcf6gskxnlkyarpqpjbmstoi6tzfty6aoa.b32.i2p:0",
+	"dclij7rdlmxclsasycgjtwczkksmjykzcqf5z4yvyhofichqyh5a.b32.i2p:0",
+	"diglhiu7vylpmy6smez76zbk34cnemiq3xdgr5bm2cfo5d3ioexa.b32.i2p:0",
+	"dvvynu2ouzrajdw65qjw7gzaeri7pvvc4hy7wiqru5krgt6gbvoq.b32.i2p:0",
+	"dwlv7cifc555gv6igg4myhjxg3qyz5jovl6pgk7ponzaccog77uq.b32.i2p:0",
+	"dwp6j5ib2fu7kjqnmlxm6fikjjwuwgcmv43dkttvjn7s2ezzv3ka.b32.i2p:0",
+	"dx6yedfz3pdi545jksxpcz3rsoe4flgnis5o2y6tjjnumxmgjzka.b32.i2p:0",
+	"e2guyrj2pf7syhkcdrphxvt72ovtj6lyqfdx3j22my3vsx3si6la.b32.i2p:0",
+	"e5l67lvatozzmpj57yzawbibkgtjofhowyuinbfqtq7ouglts7qa.b32.i2p:0",
+	"e7kbnliifrenf5nyh3ux3tqdqy5iyt75mbkgtw7qolhpcg4dkeca.b32.i2p:0",
+	"ea57r6dh5gjumzc466khizfymzl4j6mxuq62t7kb7lnbp64lxpcq.b32.i2p:0",
+	"ebwlzqwtxmwahrw3hx6nyjymzrnwllgif5d57mi3dcb6b4awio5q.b32.i2p:0",
+	"ehbvb4cb42poyjufhtpzsnf4bimblgjg6lcc2mocwhz6tvr6pyiq.b32.i2p:0",
+	"ej4bwwdwvkp5yp4jigtgcagrdc4mu7n4flnmtggngoz3tcuvd23q.b32.i2p:0",
+	"enpzroq3qmpec5gwmh2dqw7japvnco65s2jxys6izxur4s6ic56q.b32.i2p:0",
+	"etehks5xyh32nyjldpyeckk3nwpanivqhrzhsoracwqjxtk5apgq.b32.i2p:0",
+	"eurazcoegwsscsl2g4ns3syjrafn73n222skuuhphc56io2o7nrq.b32.i2p:0",
+	"exjmbcigv426obymmiffif6g5cxpzzrfusguwshzuf32ou3x5siq.b32.i2p:0",
+	"exuglxfz6qd24lkyicdzuf4pn7xutrzeomfy3zmasjsrrh2vj6tq.b32.i2p:0",
+	"ey3pmomjcdxweq5ikcnpqtx57ipu7buwhm5jjcka7ekbox5qke3a.b32.i2p:0",
+	"eyycyserygq46wtl7fyw5p7jvtciwwjqdyt5guwstl4she54igtq.b32.i2p:0",
+	"f56adwm55adbiaexf6vv57ekmsvjjavie3vxrvhgldvyoyfiwlza.b32.i2p:0",
+	"ffjl7wxuzm72ohwbhcl6lk5ei2k3vkodpuyc5hem525e4ncjsita.b32.i2p:0",
+	"ffkhznforoidqbpoa5arw4ct6pct4275rkcfzferloidnjphyjyq.b32.i2p:0",
+	"fgaeynvf7m3o6mveq3lslrxxr7hbudjwueycglhys3olhlvchyna.b32.i2p:0",
+	"firhrd5wyweo5takk625uhhxqpsrj7upk4qhhsnw73a7ukom5xxa.b32.i2p:0",
+	"fk26lfbo2hpfhmzkn3gm6kxjsd2wcszx6arbzv3z2f7c4hs4nkea.b32.i2p:0",
+	"fkdypj2mjusyqft7e24k4al6uqqgawoyenne3hrrzyagutcs6ebq.b32.i2p:0",
+	"fluicx56y5kovkchz5lnehg4nb7aa6og372btdls4lj2a3psehga.b32.i2p:0",
+	"foc3ole3bfsxzz6sxufnwzhx72cijco6oefwggh6a7mx7kytbk3a.b32.i2p:0",
+	"fs45tkbnlgka775aawxx2isfhvjfog7pt3da4ou36n4w6ucdarwa.b32.i2p:0",
+	"ftltitdrz4475ptfmlhnuug3wa6oh5rsewbyzgsup6kaa56l7bkq.b32.i2p:0",
+	"fvejht6lwxw764ea4llydn6y6rzatb7awg7iueiqfa3zm7mus3lq.b32.i2p:0",
+	"fz65c6nttdhbsevkmsvo4nn7na7v33zvhavvgrtjdc33rfglnkka.b32.i2p:0",
+	"g25cv5z3wgffkttyedjwcwrljzbo4vy6rcmhvzzi7d7olpcxiqmq.b32.i2p:0",
+	"g5yzfhaelbngq3zqfpufdrpntpqr5urnoteaxmnzqkcsfuqs5svq.b32.i2p:0",
+	"gbczdsnw3t3vghziskbit3cjkxk6lcrqrc4blsmiy55wpcdc4fqa.b32.i2p:0",
+	"gdzh3z7ozjaq2guj6s5vp2tyh3plm6a5qhq2sxwwe2mumlgyfuhq.b32.i2p:0",
+	"ges5lypkv3putbid4zen6nw4mcwcrv76eitzvq72qxt7ooyakqkq.b32.i2p:0",
+	"gevhyfmgocdedifop2e6chs3xesb3dufjpwwsqyhjh7mf6qx22jq.b32.i2p:0",
+	"gh4ecmv7vi7f56ngfuyytstv2x7tpwjc5h3bdaeidfvqcurovinq.b32.i2p:0",
+	"gh5mqkowbaf5oyue3ez2ymdj3zsmryvwyruedqibbe5ecl3yj7wq.b32.i2p:0",
+	"ghcrujpqonfh4goeyanbawasccfeiphb4wstolj24h3fsfbtyd6a.b32.i2p:0",
+	"ghj446n553pe4yevg7qzj34lobecq2n5jcjkhp4pgpm2p54hcvfq.b32.i2p:0",
+	"gkwql5srazajtr72v4sjwtcnqujewtfpluzbpnqzr6brau5sbvqq.b32.i2p:0",
+	"glwyhl6dppeql23rid7qck2ap4mnamcv2qsrgcvhge5lf42gcnka.b32.i2p:0",
+	"gojsrfeozpznuie5lfhaix46tthraeprtatcvmztsgqz7eaaimda.b32.i2p:0",
+	"grmul26cq3di4qtg65v3mrrfhdzf5vpx6cjtxsso5xjh7bqakeea.b32.i2p:0",
+	"gs7mhfwdkncwnehqwy7tvuon3sqk5olqrxadonosdlbtyj3sd5ha.b32.i2p:0",
+	"gx35bcselp5p3fu5cgrmmgbvsxwfvjr7wdtt6jy2r7h77uuqr6eq.b32.i2p:0",
+	"gyjpsbhunt2ndvup3cmjb4lr27l2nsdodgo35qyqagyr2lenwmnq.b32.i2p:0",
+	"gzumuwld5l7lbh6vnedsyja757j7pfmk6xxpfqhsgpoa564ctqwa.b32.i2p:0",
+	"h2sfgfsgifzwojhvg25agu2jcoxh57ev4keh3odn4wcvoacueaiq.b32.i2p:0",
+	"h6noxw2lwglqs5pslk5ixhq4i67gc6ht5zzpgyjsnkbfvzc7y43q.b32.i2p:0",
+	"h7c5qtv7key5orp7jx2hjhdzo6csvcovnumhdqfekkquby57hawq.b32.i2p:0",
+	"hbpiodtoo2sqfby76m3egq2mczuyl6edvoimmzt5ltptfv4nsvgq.b32.i2p:0",
+	"hglrt74vwbajhbaykjwkbjrdtptjxgnpg3w2crfphv2jss6of5ia.b32.i2p:0",
+	"hgwzyhyq7og5ow5o7vbwkiff6yvjjietuqk5y4376tgnpgzhi7ba.b32.i2p:0",
+	"hllmuc4hakeitjrz7e45gyxowkyzzakyozjaii3uudvbypbcaviq.b32.i2p:0",
+	"hnmrabzioiwxj64ih237rxf2zbdttfndiepp7vikt2jko7ovvrkq.b32.i2p:0",
+	"hnxxsx7stxzesijof4fgr4xxysunipnivnxc3lqj2lefs7k7dqfa.b32.i2p:0",
+	"hoclepz72rcrwjxzsgl4o7uvxih5uzyqkin77iovccxwyek45eyq.b32.i2p:0",
+	"hwbx5ihd7osqjiqpvsph2em7zzyb3fpsxkruyv4qnefrpul2ct2a.b32.i2p:0",
+	"hxc67npyd2naeuefy5wnjiqz4ythsirlzqq3cnd2jsmddyyjvxga.b32.i2p:0",
+	"hzemiw6ljh65bsam4tqt6m7kurk42jqfgu6hbdzpnxbq6js4osza.b32.i2p:0",
+	"i2k32sb3fmkhemu2gzm7h4m24ubcgqdohhgq44qya7zbkrgw6mua.b32.i2p:0",
+	"i3sfmgdxr3u5styrbnndbtr2butxbm25g4joxzpbezmjfngzdzma.b32.i2p:0",
+	"i4272slcxu5o3vtf4ujgfuzdkgxk6ua5wywtljxr3nlevgum3u3q.b32.i2p:0",
+	"ia3si6icpsdplgc4o737tpevkdi5zmzigb5kaw5mwzwup3i6evtq.b32.i2p:0",
+	"iaaogkpawuxmlkd2awzcaktx2su65zsvtzqmuzc5i4ifwzz47mjq.b32.i2p:0",
+	"ihl7mb66yovgdw6654oe6dhldhpdio3daiif23lt6fvbywadhcfq.b32.i2p:0",
+	"ijrvrmepueqps77tuagwcbvz3sm4vwba23saygf6j26a3jle4kqq.b32.i2p:0",
+	"iqjaqu4e26t7xs5il64n7v3drklktbqb7zcafcoe3xglhafxzw4q.b32.i2p:0",
+	"ivy2dnfyudtulpve7b7p4i5yd4d2t5dckjm2ji43rguldwflqiga.b32.i2p:0",
+	"ixgaqkwxrmdwkfbpgwobhrynkd2uq7zposymff2ph3zc6vfyybra.b32.i2p:0",
+	"ixifhk3ht7zhpnhmofln633ewayx4r4gh6i5aej7jqhs2pqzasoa.b32.i2p:0",
+	"j2pw5pvqu5eg2rq2hvc6ty56vhsebl3ysc4ah2glqqtzdlnijmqq.b32.i2p:0",
+	"j5bcvyhg3m7kxmzo33j36bnc6wayuntcvc3cxlg4vqgfyjl5mdoa.b32.i2p:0",
+	"jd63whebd5yuls7r34mi3lnnuuhqxxr4lns672tzliru4vk7hwrq.b32.i2p:0",
+	"jidr7ovev34fu7pfmalekqwwp6nlydfbvcvizpompw2wf2hnqrda.b32.i2p:0",
+	"jiins2brur6m6dt35rr2rj7giaggvwcffz6lsvxcrcc5nfv2a56a.b32.i2p:0",
+	"jkfuajo4ayvo2rbv5qdj443q6adqmnormbhsf2f7rlp5t24xomda.b32.i2p:0",
+	"jlfcwczxlca64xoot4rvari4drscuhh5j2mhtresf4xblc5lonla.b32.i2p:0",
+	"jlzspspnf57uok3hwl2ydfyotszcavg5v65bt6lwzdb746pv6sya.b32.i2p:0",
+	"jn6hxgyugvcnvqrw2y5jro6543gvv5rrsy75v6etgft25cw7o5ta.b32.i2p:0",
+	"jpihvidz2mzd3ft7juu5nesj6hhgn6nldstt6zik7ubfc5mvzuaq.b32.i2p:0",
+	"jrvg22xvbmjyrkqg7mr622zhnda3ijtua65cqngwrven7sf5zd7q.b32.i2p:0",
+	"jumd3pr6a5mhkb6mxg6voejsorpndv7yxsjsg2rexjt47tsy2vna.b32.i2p:0",
+	"jxg77w22thadw3mepv7nj3tmcxr3pboixuii6zreugbeowubitjq.b32.i2p:0",
+	"jxlsgo5tsrdnzjeap2wqdadh3a2nyymholx35o7xo5gtfinb3v5q.b32.i2p:0",
+	"jxpbqwmtoo6ueqhdvqiylu6giu3nq5gk52vaut23crpzbtyoe5pa.b32.i2p:0",
+	"jyvqqb4u5j7xb5f72gjmtq2svri25edf3vpr7qxuyuihpbcqtokq.b32.i2p:0",
+	"jyydhlgkxnjly7i63h5iaev76knwvhylghuuccv534ccklrtnkaq.b32.i2p:0",
+	"jyz3mva6sdqgqpi7gexzgadr7ueel4lc5bmh2dc6yk4xuhameoeq.b32.i2p:0",
+	"jzzgntmuothvudgy4txfr54lctkgcwbbodvt6orrftxr34lajl4q.b32.i2p:0",
+	"k35hf77iihw7edsz2iiwy5y3gdwcpkbamh3xmkga36ysibbzitka.b32.i2p:0",
+	"kbawcieyelwwitrjow537zpmdkncwiq42rhjgayw5o7u562mk23a.b32.i2p:0",
+	"kcqkothplemakipfpeajxmu4xsszpaxpprgtuv5tgfdaqejg2sqq.b32.i2p:0",
+	"kglpwqxdeicyfsg627nzdjmevdtgnavrxtzjnsmp5mldppo3hnnq.b32.i2p:0",
+	"kolgqziy54bpfvpzoirzve7cimy7dhmpzhdezkv2ug7kcv5csbka.b32.i2p:0",
+	"ksakhied4hipxxpfiriud2adow4rlw2k2lryi5yvbwb5x7xvqk7q.b32.i2p:0",
+	"l2lf7xucmxyq75o7bjviwyhoo45bpyno6m4kzmb277ckjhqvolcq.b32.i2p:0",
+	"l3ach5wbdx3n5nq53sv6tagijrbse3nwa2gkqz5oj7aifyaizpcq.b32.i2p:0",
+	"l7j3gpfyicterbchswmaasozruckfohtrnqssqttsxggjomlccwq.b32.i2p:0",
+	"lbpdojroscwfgv5brrxnosturpwlac2qz43ltbg2ksgmuab5smxq.b32.i2p:0",
+	"lddkbvkn5phiuoaqeyv2qusio3kxvfzeppomfjs3ke3ql265o7pa.b32.i2p:0",
+	"ldh752digg7j55zj57tbihitghfndvbaewon3xfryyce6kbt4fka.b32.i2p:0",
+	"leqwfxlwaxetasszd5woqd3kiuvr3322gelerxoyksq4tit7qvdq.b32.i2p:0",
+	"lfp552rqoegsbnpn3ukxfchfw2mh2ecz2hfmyforedb7lwjdzs4a.b32.i2p:0",
+	"lfuvzzzceuik5u5pnd2i67amegel5ua2rrnncxkyyc7bhteq73aa.b32.i2p:0",
+	"lg5h76b5n4r3ne7bwpf7be5byyncrapo7thhkuzaktblk63qvjsa.b32.i2p:0",
+	"lgtowdqcec2uard3xs2s72ujh3empchb43qg5swxbj26hgl27xva.b32.i2p:0",
+	"lh7qjombcmiekvv6niz5eflr55cnd3oxx7nuus6vmehqfodr67za.b32.i2p:0",
+	"lhqtdpyolrrrstor6gb7rxghcpbiho7ovvsdzb5qqoxvd5octk7q.b32.i2p:0",
+	"lihodnfzhy2mtizfi2odfvg43l7pctxjmxwa3jzflosgfa7hp7aq.b32.i2p:0",
+	"liu75cvktv4icbctg72w7nxbk4eibt7wamizfdii4omz7gcke5vq.b32.i2p:0",
+	"lm2fgku7dsikpapnpbri6vx7lthl4qiznkkdermrb6s3tulypwqq.b32.i2p:0",
+	"lnevapn2fxln6sfqnstmcvjar6e6gp7y7s36b3c2rjq3xobohhea.b32.i2p:0",
+	"lvaybaigrbma2qi5ifqpvugnkpxhyyi5l64xfyi6q2pcyk3hlc7q.b32.i2p:0",
+	"lvbrphipxggunmueutazdbkdel5lmtfkg6odochvqh32njfapxmq.b32.i2p:0",
+	"lvdbqavgdom5h5denwkmdwslfzxckf6eddwflelbog7tgo2m7usa.b32.i2p:0",
+	"lvia2zpnxzpd4qvzmv5dw2mlywjhj3rultwtyuz4ylqx7nhww6da.b32.i2p:0",
+	"lwjrapkfexjyjqf2rrdr4ghhxmlr3jdygsonetgtheglvmru5x6q.b32.i2p:0",
+	"lxejplhih33qcllkudj6zewpn547swjm2xd7rgqb5iqqwlxdosea.b32.i2p:0",
+	"lzanccluo7lzsg5zzlyomj44eabqvopb27vlbsargetjbib7shdq.b32.i2p:0",
+	"m2k7ajij2wvgfpsbg32zuorbsjt72iye3ozz6dbyxtj57fx7xsqq.b32.i2p:0",
+	"m4avxo6o6wlnmwnsxcs4iuee5xjah46q6etn2azhuykrtzchwtua.b32.i2p:0",
+	"m6ac7toommgpgefhrwnsszxhfzlxwdu7rvyvzixjdjhtzrwracgq.b32.i2p:0",
+	"mb4smi3lx53kqa5smyx6uingkquc6o4cfogwd2gmew2crk6aeuka.b32.i2p:0",
+	"mdezy2cyqrerulrc6hbxbnqxvti32iiypirkzb7tamuzos2vzygq.b32.i2p:0",
+	"mf6uqxwllgevm4flbzoimx7ybpm4acvsyq2au22qoe5glwhw573a.b32.i2p:0",
+	"mfbtztzkc3t542n4umpfns3bgog4nexxf4nuzrcj5pan5uvbgc7a.b32.i2p:0",
+	"mgroyad5pq5ojiz74bmsxzu3a4fot3luqhkqwip377r3hanx5eza.b32.i2p:0",
+	"mhmsqlcjwvyqnk6fjkl27wfzjpwwaubay2xfhjvi4cihnx33t2hq.b32.i2p:0",
+	"mjpulaafdyuanouslfpjcsvumi4edtckfu3ffn3ipabkxj4sn35q.b32.i2p:0",
+	"mlohh7kltblyxzl4xdaxmqvjahj7aw5iuwegoj7ot3t5sbee5slq.b32.i2p:0",
+	"mournhoyvdcgv4pplfsj5x3ucf26a7rkkzthc7wotxsfiobf7vcq.b32.i2p:0",
+	"mskcsizrzlosspwsnct2pe42io5bup263pj7k64al34cbvlrxufq.b32.i2p:0",
+	"mtlznxqya5nbuyorybzul4cpdlrnvlrhalx2eogwjce5j32js5wa.b32.i2p:0",
+	"mujp3zt3w4firbfuscq2g5pmmb5js7a7eryhifyzcgiebexcbsqa.b32.i2p:0",
+	"mvyaixfajnz4zcs7gchrta4ls3fjnnbfl3cjgpumgl4x4xgacdqq.b32.i2p:0",
+	"mw6hwsafdhe7gvxb3ntr2w3qo42hgcf3j774h2g6yjztt2bgtuga.b32.i2p:0",
+	"n2f5gbldldyovgkyo4helppdgyavuqd7leyp7p6emotnpa3bbosa.b32.i2p:0",
+	"n6fm3r4pxdj72k4tgxvoxojiafh523sl4nlgssthcv3krthxod7q.b32.i2p:0",
+	"nazasriqoa6qoxdlgzjwsggubxl6i4nge5q7om7nijaqi24ulgua.b32.i2p:0",
+	"ne5oyfsg2re2q3xzlldwxt2yl3qa2goo33wpqnrxyqedrhim77ga.b32.i2p:0",
+	"nfoo3o3fqq6ibzthlzgrwvebmlxuvwipgonughkv4q5lfzdy5wuq.b32.i2p:0",
+	"nfw57zulhd54pveswzwou7tmuzkxkam3vurjngnduslwqwvkbmla.b32.i2p:0",
+	"nh4fvjpnisehdr7ipspwy2pa4zchtoxuhhkzmkqsurii4uesynzq.b32.i2p:0",
+	"njsuywygktmrewbcl5vx5rjvntnh43nxvcq7lttbludkhczvkzlq.b32.i2p:0",
+	"nkrhjcpxakmiar4xh4vslxgu636i2muez7buc4lr4uqtqssqpvja.b32.i2p:0",
+	"nlopqcwz3mrhjj6xxilxrhosla6i74c52s2pcgz3kblgl6t4ey6a.b32.i2p:0",
+	"nmfhnrzi6epzc6kgvzgea762lentfzhkuesgtd64dhtwibicxuaa.b32.i2p:0",
+	"nshl5u7bieycxkxdahluepyutjt6kzz5nzyu7hvc7u7p2aekxepq.b32.i2p:0",
+	"nss6vg6dhnq6l5z5k5qsc5654nemayfwsjh4lslgubudv6acw6va.b32.i2p:0",
+	"nvcdkxxuu46mnovvsb7eboa4ah7tmhhtipjype4f7noy3ogf4f3q.b32.i2p:0",
+	"nwfdwlblhudom3oqe32yzzuuj2sb37tjvlmimiru4coazi5freeq.b32.i2p:0",
+	"nz65voc6a7e455cxtrjorm7vy5jv3vfdqp4kz2wurdsrjixmzldq.b32.i2p:0",
+	"o22dh76m67utgqz5la6ycdqkrnq4htlruwo2e3watdhtrebbawtq.b32.i2p:0",
+	"o4tkad7wdeyarefuew2d3ytpwc45twkca5vobgidhuv6h74r3sfq.b32.i2p:0",
+	"oa7yiu22tdrllv3t6h5v7faodenzjwrwqlmwxdwqlzrwdv5trxya.b32.i2p:0",
+	"oazy2y5v7vhckx4ykzlfsazcho7ixgr3fwoccm7i7eykbznivl3a.b32.i2p:0",
+	"ofuozxemfy4qirjehiuwhojy4njogjcqdrjqyx5vrhl3to3eanxq.b32.i2p:0",
+	"oggn6qbpmbag224gumagzgno53mgozb65tpzt5lezjbfbbiqky4q.b32.i2p:0",
+	"ogvmmndfgpv535nstfta4a5iflw3yxhogonkwo27qh5c2dtdz73a.b32.i2p:0",
+	"oiusghp3k343ofsyw2ym6iejviznukx5lrhafxzdasmjky24ixba.b32.i2p:0",
+	"oj6ewuw2r2gtrda4fukxarw2ztdnb5i3dyopdqxyxsfmdyvin62q.b32.i2p:0",
+	"ok5utfh6axqmvpxhy7jizmrflxrmudju6bxyvsyhdb7ykrpvgiyq.b32.i2p:0",
+	"okjamsyd4wutvhfhsffejwk6ioru4mmvwagfssoy5hcocg2gj76a.b32.i2p:0",
+	"okqkpzrlxb7zq3wuyicdrxqzdhlaphmlqwjghdcmprzkgcoyokjq.b32.i2p:0",
+	"oksvhpglmcogmgubrk5nzeezprmeuwsow3ql2ut37jhme364tria.b32.i2p:0",
+	"ol4zsxh2mml3wmdl6ix3rtu4fontev4w437kp4rns47keidcz5dq.b32.i2p:0",
+	"omkilp7edzk7fpmml2fylar64pc5okaxuizv7tfqk2qmsfdokp4a.b32.i2p:0",
+	"onna3y6qsahrc6n5um6aflz4v2pcoachqsvhj455pp4d42khvlza.b32.i2p:0",
+	"oogl4tcoubsp5apy3owxndveiwzz3zv22wezfhl472p6fafxwlrq.b32.i2p:0",
+	"oz2ia3flpm3du2tyusulrn7h7e2eo3juzkrmn34bvnrlcrugv7ia.b32.i2p:0",
+	"p3r7qxuqwkzjhbrb6d5vvni2slenfzn26yld2w4mspsahtglpx6a.b32.i2p:0",
+	"p6jzpmsfm6b3dmrofmsmsfjrfc66w3rckc7xzdgbizocbnuk5eta.b32.i2p:0",
+	"p7o46boczrovtvo7ydr3svlcbmwdgw3p3y6yeyghyqs35j2kfatq.b32.i2p:0",
+	"pctogn2qwg2ucfjtv37ezyrrjhesgr3qnvxj7ame25jlr54ew2ya.b32.i2p:0",
+	"pf6ax2pphfjwzfgv3ivgk2x4f6ogenp23gfoobi6fkr5dm42hdnq.b32.i2p:0",
+	"pfcbokwuv5b2ej46qt7hsr5zyng5tscypye5mqvarssqt3xiz2ma.b32.i2p:0",
+	"pifd7fxh7we5mjxhhgssarmhlynvjeo7zxtyga4hc4cmwqs6sm7q.b32.i2p:0",
+	"pjpurot3irr66bfyhwtobl4exbibfpb2hvstyp4pzyqfxilrto6a.b32.i2p:0",
+	"pkxf7rsnyx5sw4uxnsksr4cquk26sbdsk5i2po4qr57kdcsw2n5q.b32.i2p:0",
+	"polrw25kld6siwyvcm56jsdxiveim3ahfniuirzlajcf6darnzca.b32.i2p:0",
+	"ppe2jfsb2xrmgwugbq7agy44ucb6yq4qbufaf2sh76b4kwpa4jcq.b32.i2p:0",
+	"pxktpl32e5qce4z4ngw6x7tk7ophnuaz7suqyomb5i7ctkarbora.b32.i2p:0",
+	"pxtfr5q3k3jou6khfpgnxcp3o6gici2645dfnfjn5uahia5ks2iq.b32.i2p:0",
+	"py5guxsuhi3yxzy454vxgixve4udynbtleja5ayijesh2skhpokq.b32.i2p:0",
+	"pyiboi64hraqhyghlwkq7fyogqdo27jwokasxjhmb6wag36xz7ra.b32.i2p:0",
+	"pyolw5xk7q6x2pn6rh46vswgilgzshtyrv5gfbuiubvqw43g5j6q.b32.i2p:0",
+	"q5uyxic4uruzsqbgwqvu5x4ubr5droqgzyexghevgwchckqboaga.b32.i2p:0",
+	"q6fmuhz4eqghmca4ywgfendvkbdh5jpioxlmn4amn4uerukg7kaa.b32.i2p:0",
+	"qcha5xofpz4amfwknfp5pbtujpbikvurf3mi2nrirrv3utspjlka.b32.i2p:0",
+	"qd2c3uz2r5hc27nvsq7klfd6gn6xi6l4p4fh3av2y7ng7exi56ra.b32.i2p:0",
+	"qddg7myylinn4tw6kdjmmp6fsyetkosnrbp2gsjx77tmkqyqv6ua.b32.i2p:0",
+	"qf3fj25vq2dbp6bvvmcroixohcsk4qfvx3t33kztomgmyu5vdkza.b32.i2p:0",
+	"qgfsf7dogvfregtom4hlaawk2vzt4zlguwxpfakpzcr3ggkyir7q.b32.i2p:0",
+	"qhi33hvcrl6hn4uhipih42sezezqxcxpenz27bbboxtwllplxtta.b32.i2p:0",
+	"qhuk5zq5v7h5p7o62gsxgtqqo7va4i77o5vei6wttxqcgaw47h7a.b32.i2p:0",
+	"qlvvxcxmnmvpsckcu27as67s6pibysr636bumggw7cywnvk44sea.b32.i2p:0",
+	"qlzl2rzwxw62fj4psy74wj4kmlavseuoqwbpp5caewnrn3zv5hha.b32.i2p:0",
+	"qowzdmeleqo3yed3amttdft6b563xuzffy2xypv4llao2xq4cjoq.b32.i2p:0",
+	"qruoqkdc7a4gwe5btziakuahlhlhqnzlbop3xf2yajzl3jjhusja.b32.i2p:0",
+	"qsdji44zbthubhkttjxbb5ukavsydimb62f7s74bcwfew3ejw2tq.b32.i2p:0",
+	"qslso267w6tmd6u7njfoetnuii6kw73qijqspyzet5ths34g2qyq.b32.i2p:0",
+	"qwdrunaogud3v34aod63v4upwcoawqs6hshrye3quktuxziorw5a.b32.i2p:0",
+	"r24awk22xtw3c5kcnoxseuhzconvr34dlu3jaiuvrzaiaq47y34a.b32.i2p:0",
+	"r2p5kqr7xjo4ncz7l6ekdx2ge6su2j22j2tdsoxu4jw3u67z7poa.b32.i2p:0",
+	"r5t3uycr3be3dii2gnqcay3taa34ia4wk4frek7f6v4upnp6p2hq.b32.i2p:0",
+	"rcapj7l7m3ixmv5cdjz4utx6soa24uqjgeudhsiotxiaimec374a.b32.i2p:0",
+	"rei4cjnskoh6ky5njeozweixzi4dgz5obzoqs35z2p5mkmrtss7q.b32.i2p:0",
+	"rglk4wbqkhotkau3ycapk56aktmfqafyijenl435b2ktwwu6shla.b32.i2p:0",
+	"rktuvqi4jkzbspqnuj2vcxd3tjpmgu3ythc7f4ygx7wcrarfuyra.b32.i2p:0",
+	"rliuq4jyviyyns43kpmgyanifrno7zqbfd35bquu4ndn52a5gj3q.b32.i2p:0",
+	"rls5r7d7obcr6pdwzcpwl6i56rq3hz3ycwlibh7ilrwmb2n7sija.b32.i2p:0",
+	"rlvwiiu6emvj46hjukstww4dfknmsqf7xphmdy5tdsftyem5kpva.b32.i2p:0",
+	"rn7ua44l6f3eetzlpqn3hitf7gjwn6si3fnzlg6hzunq6nu5cvia.b32.i2p:0",
+	"rnxi3momdjajrdua5vdqpzd3dw2vt6jtkytsopcsid4z2iounuua.b32.i2p:0",
+	"rs4jgrwusidxkhmw34untf7rsyobqqo7lvgj5cjhin5s3yxvyy2a.b32.i2p:0",
+	"rsosixkef75vygaaevqbezszhybws5lep322wyei2b4a4c3ma7da.b32.i2p:0",
+	"rwjzmaiprk2kvwpww4cloittmarkogrmxgz2t45awymbtr37t5ha.b32.i2p:0",
+	"s2gzg4n3wogl4nowvbbczze2qdhfkuguxd5s6kjf2m5ufmbxuzga.b32.i2p:0",
+	"s3ct7nrzj3w3jpjvnsp4tdlyn4enl7rbf773kiklrdfrh3oj3g2a.b32.i2p:0",
+	"s4qlly4iwzevejk4ex5zfqhb4t666o73mvdmy4gpu47tpb775nja.b32.i2p:0",
+	"sb6lyymnldiq4utr6ih77bgo5lcpocdaqr3gh7agah5jknpql2ga.b32.i2p:0",
+	"sda6psv645pw37tkcgpheori5e56fl3zlg73qsoaxak6vhds4yaq.b32.i2p:0",
+	"se5xqjjjq7u3ufrs5uvqqidsq3eyki52vfmhgw2pawwjykjflufa.b32.i2p:0",
+	"sigez2yrjz2zsg7ktsejuufqgpsujd5wh2nz7i52czcuyaphgkoa.b32.i2p:0",
+	"sj4nzabheiyjpywdsgokx63g5x2d2kj7gkgappdrvxfnawuekhsa.b32.i2p:0",
+	"sm7odhaqkwjva5bedyxtpnucfk4achrkholwfatbruzhyhng2flq.b32.i2p:0",
+	"so6u54vuaeah42653p6eb2zfinhz4xiuyeditgofknd35bcrbd2q.b32.i2p:0",
+	"sp6jw2b6jeots3zckalaczbmpywkn2jfoj6gxvbzezblmtj62sza.b32.i2p:0",
+	"spuaa2y6qsaywypklz7itcb5klesogef2x66m4flws2r574qjc5a.b32.i2p:0",
+	"sqrvl3n4lpx4bkun6d63c673dkljenv7ndyktxaxwgv6oc4t7joa.b32.i2p:0",
+	"sqzjcgsxnt4xv67yyndt5pwqifj24il35zobte2mazcen5ln5ffa.b32.i2p:0",
+	"sszwxree3mlpsnpcjhgqjh2kxltan3xua5pyp3xob6sonphyxgka.b32.i2p:0",
+	"suic45kssfld3cztbp4dc25ggyrv33ehgbyetyiqczmg5p27p5yq.b32.i2p:0",
+	"sxb7p33xjjinvolexb4y3k5kx7uwlzsnzvbv46i3lyorm7gsv6iq.b32.i2p:0",
+	"sxpzpfhep56dxkxpdfk2ntqmm3hkvljjumngdtlxrovotncq4leq.b32.i2p:0",
+	"sz7qnkg24o6gl7mxms456l5x6eny45g6i2pxmvbdkwrn6qhugyxq.b32.i2p:0",
+	"szxron2qoiwvxcgf57jbomifc4aj7vrw6kupndij4zf32k7k5lpq.b32.i2p:0",
+	"szylo7voijiodohl2tdnykqjdnkbxtdpofpesjma4uygwuyltbna.b32.i2p:0",
+	"t2hjccgcc5efycclz6d52qwg3pk7mfc6gvp6zu5xm3gotp5w3lma.b32.i2p:0",
+	"t2pd4ary6vfpevd26ttomoxnjfievkvxmku7736ag3vxfqnwny6q.b32.i2p:0",
+	"t3o2wmsrfyc4rm4lp6jysdlyerjeowvz4ifsd5temdiymxlstama.b32.i2p:0",
+	"t43qqzux7ik7kki2rxtillcgbxrznuhjac7wtqh52sqovk5ay3xq.b32.i2p:0",
+	"t62o5ikry4r7satmtkgqdeg5wjnd2gmvs5h3ic63xpnzidmmqq3q.b32.i2p:0",
+	"t66iyinlubtg4znht2a6gwwcyiftjosuq7xbur4gfekkhwymxoda.b32.i2p:0",
+	"t67i3ywchniwqcwkbiaysnxqlp3kw4erlyxy4kqcni76jrwtdqca.b32.i2p:0",
+	"tagk2wvjhjse5bpxl2hudsesi4fiuatzgr6r7s2ktei6xfqo3qua.b32.i2p:0",
+	"td6ecor7qphgru56xt2ydih6k6taj3tvp77zhimoj42t3lo5u7na.b32.i2p:0",
+	"tetoqjagsf7fpejajiwm4rosqscy5huqbz5hcqgfuha5tdfnlrnq.b32.i2p:0",
+	"tf4tozh5unsgyzpdsmrdcpbgekw2agu7tp5jvyclzcs5kjudwwpa.b32.i2p:0",
+	"tjkksv7i4yfik7dcwxhsqnqin4z6v7kbltz4nqvj4kguahtlcxya.b32.i2p:0",
+	"tk63xbzug7def6esivofwq2h2c53ar3ot7hsezdq3amxqqaoyr5a.b32.i2p:0",
+	"tkwm6xugm42st4mb6v2zse34disfkrp4tq22u7e4ovrorixxqqsq.b32.i2p:0",
+	"tnwgilzjb2hycyoapgvm6ncliunq7owbpefcro5k564uufi7a3oq.b32.i2p:0",
+	"toiuhuecko5mni2ayl7zng5j7lcag5htfmv6avizsaxeoqsszeka.b32.i2p:0",
+	"tpys7fw73bmglnjtitxebprgxthg6ew4gnhp54uc23cneef7si2q.b32.i2p:0",
+	"trtl5btjzkwts3e5zbvmmfhju7loetgfxd6rsqthuzyo6o7lczoa.b32.i2p:0",
+	"tshwpiqws62tsgewxdyopseziu3mfcbanuy6p5godvol56kbpwoa.b32.i2p:0",
+	"tsxwmahei6rt2vwgygdvzh6dyp3boh3ixpwq6l5yw43eq5sfgy2q.b32.i2p:0",
+	"ttj4liggeu7gtchpub6i3caz7zg5627t3ctorl42v4lgdr3suu7q.b32.i2p:0",
+	"twjfyqvdkbtvwy7sslbxjoatl7y3qnurvsoa4h47nzg36xeeofhq.b32.i2p:0",
+	"u2uyjt3m62yptp2mz4t6d4d3frqbctpnno2wkdm2oxzz64bsoyga.b32.i2p:0",
+	"u635477uxqs7z4uvwx224u6ojn3c3ewcb66f3j7qlbzqyrrevxja.b32.i2p:0",
+	"u7adsnioz4cqlxb5vby3k6tdy26whpjlzbdhpvhv32djf7znekbq.b32.i2p:0",
+	"uay77cra6fm5funeyv5rn6aebfzah2rcp3a53fhbeeqqmchtnv6a.b32.i2p:0",
+	"ubnnmmxnazig7m6xfsd2ztg6ssioizelfuhca3d5sb5cyekpy2tq.b32.i2p:0",
+	"uc52rzz4xu5ikx6hl6r6sqxfmiyyxsffpcu5frrtepczidwjwuha.b32.i2p:0",
+	"uf2v6rmwmsu32hql25blkf2kifjrqvas3qt23lwv7qq6oym3lajq.b32.i2p:0",
+	"ufetdfepwvnvxt5sddgqkuxh4pzcwbt3gvyywk2fdbjbtrgoavcq.b32.i2p:0",
+	"uhxvwqshwl7duucazy42gy5pjssfkoch4kdbjmn4rzn4vjcezf5q.b32.i2p:0",
+	"uipi7minrbl75vebdsvuel4rtu446d2ju6tuc4llh4vuwprbprlq.b32.i2p:0",
+	"uls757zxmryrazn36okmcdkzolfohv5ftetsclteibv2dwnuxm7a.b32.i2p:0",
+	"umfuudg5hhpfcinoxacjz6nr42onbfwkn4n33m6m6evg47ogo2dq.b32.i2p:0",
+	"uncalsdoypwylzugunja667sdynbf56f6makgjkygyanuklb7lda.b32.i2p:0",
+	"unuhagzdnahzfsz53benmkvw62xsnxhbboqnme4f3xk5wykzreqq.b32.i2p:0",
+	"uohuxnjd27cftarbf6kh4czmotwvstpon2sgs2vpffqkgmg7guxa.b32.i2p:0",
+	"up2kudwqomqrwvfognnhz2mjwqvkgpknfyscp4ue5ioev3q4jd5q.b32.i2p:0",
+	"upcwcphup3eoiq4oqcing5rplkcl64legnv63rxxr2v26at6i3hq.b32.i2p:0",
+	"urbogusrwtor7xuksnd3zg5hubej7l4eza6v2ml3tqkmax34wusq.b32.i2p:0",
+	"urgyrlrwxiirkbz7zyyvl3l2k2lnr5nv6ma2nibynuud5eyvtdwq.b32.i2p:0",
+	"usdsulgpz3lcfj46ig4arczz7uns2pmmr554bck6pebewvbndqoa.b32.i2p:0",
+	"usgqijrwhtwgoekjcr26yqcgpncwpsescrr3eek35e3rhkrtptoa.b32.i2p:0",
+	"uu2nvtd5jeppiovo7wksv7lrgl56kog3e5js4cxpgik3wwqj3w3a.b32.i2p:0",
+	"uv44mjoqrj3m3gzz5wxlnszt5pvgk3iqlc3pmqfe6un6gxays2cq.b32.i2p:0",
+	"uvblix6lkw33a5ozkjzusjvhtz2bcuqhv4j6bwe3l2ig6hbh4plq.b32.i2p:0",
+	"uvpq425hyrpeuwa2s6x3lqhtvi66zinmygqc2gmyojdlmjnvgcaq.b32.i2p:0",
+	"v3q5ij2nr47tjkxhffpibfgsqa3fnu6b6wnl6elixiy4s7rgjzpa.b32.i2p:0",
+	"v4omqjjxyio7b4m44m37zuqk5r4bnhft56dx7g3bq3udxjqtz6za.b32.i2p:0",
+	"v55az2d75uz4qgbgf6747x24rftoyu33pjbeszbj3ml67tgu2msq.b32.i2p:0",
+	"v562mrxdbaj6ck5q5hmty6bgiupnxn42d4rbdsvomloowiq3cwhq.b32.i2p:0",
+	"v75aoanbn3yyjef7c2k4lu73aklujxdd6lcwnuq3cyo7fim3tzia.b32.i2p:0",
+	"vazlbdzsrqn5tca7xayzlly2skjdk4dythqcmt57gexzz27knuzq.b32.i2p:0",
+	"vghevtytxazyvqvyvor7pd4mo7hgkdgvtgqoclkn7yewnz2abp2a.b32.i2p:0",
+	"vicxbb4ts6jmqj52hhx32mxnwpur5covzxotaiy6zhaansldlodq.b32.i2p:0",
+	"vl3zrbrihzvwof3ivkeoz5vlr4kn7qnqpmmmzickrk7y7rbttw6a.b32.i2p:0",
+	"vmatpbu2vf5p76k2emzg2tjyqi6p6yj3cl4z32ncdhz37ubprkha.b32.i2p:0",
+	"voej5nrurkigwwzwgcxnsxu56l4yvwodnkbn7fvtylyi4mtuieaa.b32.i2p:0",
+	"vraia6zvgpiyifwyrh3ivcqfrwx3fspnrqzay5jl35jm42skpmdq.b32.i2p:0",
+	"vuqk76jvxfk55bkovqcmmkvycmndee7j3tuv7ezdb2slrfpjyvoa.b32.i2p:0",
+	"vvo4rmxuyilsqxva6af2iprm527ty5lavanshgxqigviksennuiq.b32.i2p:0",
+	"vxdz6p4ypcpd3x3etqln2bseti2rxharidd5qz3ith5comdlhzbq.b32.i2p:0",
+	"vxjkydkgnpl4j7pt4qrf3ec3ds4ks3ucb347k5xae5jg6f5offga.b32.i2p:0",
+	"w4k7tqf6xpo2ygoipdjxeutjt573sxssfg26v7oklljb2a4iqhbq.b32.i2p:0",
+	"wbfd5taf4njhetl3jttqsft576zvs3juim6akqpzjcwhiitwxy2a.b32.i2p:0",
+	"wh7soeqeclpnjr6pvng2pruyarp7e76ztsx2k5z6bxoesmi4z2ba.b32.i2p:0",
+	"wn7wc4w2ky4367eyryxyhnoc2wbeu5rltafixp3k6ooxr2iwb54a.b32.i2p:0",
+	"wp5pbyws3uqujf444a7qivw7kufij35nhrqoxko6x6a2l24iuo4a.b32.i2p:0",
+	"wpsjefslqd5xzuq3rhvdpgevple6gt36jgzxcnn4mtodne5kb3qa.b32.i2p:0",
+	"wu3mt2hlkgkg3xx2mw7iucfzvf2cbhujhzodm3ow4id6n5632oba.b32.i2p:0",
+	"wwbw7nqr3ahkqv62cuqfwgtneekvvpnuc4i4f6yo7tpoqjswvcwa.b32.i2p:0",
+	"x2cxjvizyyq4tkopbggi4ubf4zkucvgdle4c3c5snosq55mwd3pa.b32.i2p:0",
+	"x5mat3n57fi4wxlz3aywv3uxjz53oh453cmgxymuxqarhi26crbq.b32.i2p:0",
+	"x7qntsqild2scmcbjnmuofchct5zvbhjemgvr6ut3hvdrnscsk2q.b32.i2p:0",
+	"xa2mb2n73c6i5phowqophylm5ctbtjw2iv36dlbnqk5e6dpnz5jq.b32.i2p:0",
+	"xavyosb5gkzs3og7pc2djq2im6xx53faweoonlg7cmr4byewj7ca.b32.i2p:0",
+	"xd2byhr22rqkawocapbxedovmifyk77qrh6br4ctx7ny26hpmusq.b32.i2p:0",
+	"xf4xjhiry3x57gs73xhcfswulggzazfouszkbg5uo7oakc7cu3oq.b32.i2p:0",
+	"xfrlfngubzadfa4bvqdirlkzlo5uunvcvlwppvxbz5vgf64tm54q.b32.i2p:0",
+	"xfrmw44mlhbd7ztztmogxmyli2ydjn2nf3z7l24wnhy2nh6zt4ma.b32.i2p:0",
+	"xjebyiaydsbb76gqzetsmccgh67oeaxt3c4mkwmt7gjlfym266ea.b32.i2p:0",
+	"xn2wekjcxrhkm66vnvea2lx7yxsu3yx5cbmkjbcdct66wlj2jfta.b32.i2p:0",
+	"xofaehrg3ptjydgglyzkw36cxi6cgogkdt7yc4lmbng6vpnoegxq.b32.i2p:0",
+	"xowhrkn7si6ye73qlrvouyegcn45f3uugyagbq47kq5y4op5z4qq.b32.i2p:0",
+	"xqrdgd5q36hgzrpzgcoukfujp4b5jp5tjotg75hbay76yjtscifq.b32.i2p:0",
+	"xreaavb5qqahi7ijg7lxq2qawwvbpswnpyseqbrqijgp7fg4vw3q.b32.i2p:0",
+	"xtuqjpb5siwo3saf7jayj3bxdnjthzyc4hqdhfs5gh5jysanlzsq.b32.i2p:0",
+	"xuzvxq6f7nksux6w3ojfgxvwndqml7fpyzkfkuozlonazfwvwqfq.b32.i2p:0",
+	"y3kfeon74jarsixk27msrm2iwc6p3buqicy3lvebfv2jl2pcwhmq.b32.i2p:0",
+	"y5k3juy3jf3bq2qdnnmq5lsedhbwk6tv5nhqqrsp6ulalwfuvvha.b32.i2p:0",
+	"yc4xwin5ujenvcr6ynwkz7lnmmq3nmzxvfguele6ovqqpxgjvonq.b32.i2p:0",
+	"ycez7bbakc5mb3lumgm3ib2qxu26sf3htuxsaexim7qt7h2fmcoa.b32.i2p:0",
+	"ycjho7yuwtvwgwmmd5peuakc3vmpr4iif6cibaxs75upcgklm4aq.b32.i2p:0",
+	"ygp4mko3ds43zi6emhgmn2xrg42tgb2bmtn65y6wmvlrzencb3ha.b32.i2p:0",
+	"yhra7s4yldux4fdkicwyijvem7uhlrqdnj2qcuk37vgwl7q6nm6a.b32.i2p:0",
+	"yic5ly5occrt73w2yeqsyvxnv2m2kpcqaeenng5dyr7c2vrfworq.b32.i2p:0",
+	"yn2jqez5khoysc5zxnwbf7ndyusehamkcxg3rqrxzbfksnmqulfq.b32.i2p:0",
+	"yodn52qhw4v5aoaoqfsunvfecbbl6cyk5j4aq2pfm4otu63qlp6a.b32.i2p:0",
+	"youep22rxwhbpefsrhinbnb4avxra2js7235tcfsq3d4lbgpckjq.b32.i2p:0",
+	"yphmy6w3myyy3am5awdksfyhbmmleqmblru3pfoajhjdilktwdca.b32.i2p:0",
+	"ysenhmvz3jo56h57zzzvr45y3t57rvt7vi37p2kdiz6fpfrbmucq.b32.i2p:0",
+	"ywiasjjmqaxihwwxx5yxadxn6aysprfvbbwz64dosewllgtd6ebq.b32.i2p:0",
+	"ywl5wwmnx24x5tuvjj5gf7ecrdjtqbehsw3ao4aou7qnmcp4hhqa.b32.i2p:0",
+	"yz74oxxqpjat47ypv33mg2j3f326xqwsri36eodugv4rqfvd5f5q.b32.i2p:0",
+	"yzpjcs3qrdgkiog22squznqnq6luxtjk2itoykyo6s3pvqe54jba.b32.i2p:0",
+	"z2lhmfha3fcix4ozgqdy4i7qi4cckf7bkeno3jdqgrbmtsushl2q.b32.i2p:0",
+	"z5xurvyb7jtzm32ydvcywsw33d3khlajxxt5fpcm2vfspern4xpq.b32.i2p:0",
+	"z6icgtaovbxrxrzatdiis2vmwr2chj6cxp7otrtzcmkfyul3mp5q.b32.i2p:0",
+	"z7gdaek7dtjjq76x6wlestmw6vopduccltyontnosuuj3bauz6fq.b32.i2p:0",
+	"z7vth2irmdzlz5f7patvv25xfzzvvtu3lnvhctwtowusr4rj4uqa.b32.i2p:0",
+	"za5eqqa2pqyr6p7e7zrhnqwsbafmocdsfyxjsx6jyunelhpe74cq.b32.i2p:0",
+	"zbi2pewn7vhp76cpuwlkrw73bw3wj43ebxfzuinrh75msiwy3f6a.b32.i2p:0",
+	"zckxrkzbo66y5k442djsd3cgffvp5t2us2ewv2i6f6dwpaxxaxpa.b32.i2p:0",
+	"zcrenbrluidv3wbzdsvw6jvkhymp7yric76zcd4dfdcrr2n4kpga.b32.i2p:0",
+	"zh34uj3cwyr7zs2uc4aeyrhffgumxd5uzpfpbmbuyetqkd3xy4ia.b32.i2p:0",
+	"zhyq3ewc5jzrkxbwnhc7xebstaodry6zqvie3egcapjdqxq2betq.b32.i2p:0",
+	"zj65umpcme35sumbo42evlvf6tegif5uckw65eidhrst6npqvg6q.b32.i2p:0",
+	"zmq3tbeqrechbra52pty4gooiddjby2hcn7er77y6j5wn4veyleq.b32.i2p:0",
+	"zpvqqdrcnagad5ayutf7hkp6prtvrbmknhqdul3qweft7oa5ikzq.b32.i2p:0",
+	"zvchlrjuzqdlx37fhibhnym4y6p56vtlymujjuzhh2cp34yqfrtq.b32.i2p:0",
+	"zw5te5naaaxhrmd6ynwslt5wutxcsq74fk2rjdyb2lsmrbdoybea.b32.i2p:0",
+	"zwjwfda2ubqwirdakavoxn6gr64xy3qxegavhpal4lfde3jj3doq.b32.i2p:0",
+	"zwsapqtusnwxc76rixo33k34yq533gqu77ijucmr3dbmqwan5rha.b32.i2p:0",
+	"zxllase3maqjhru3rbxfpsu6wgmsf7afvjyh6obdujtqfl6ju2cq.b32.i2p:0",
+	"zyajiczndflpwsqdtckp2m6vd6osuoqt42zcmpsl5nu6j6pbx4ka.b32.i2p:0",
+	"zzqyvzd6rdxbtontdj6wkajpajhmr67uhk2qgli6jdwcrf7dwqdq.b32.i2p:0",
+	"2.121.116.198:8333",
+	"3.86.179.235:8333",
+	"4.2.51.251:8333",
+	"5.2.23.226:8333",
+	"5.2.222.125:8333",
+	"5.11.92.140:8333",
+	"5.35.15.93:8333",
+	"5.36.230.237:8333",
+	"5.95.152.132:8333",
+	"5.128.87.126:8333",
+	"5.183.173.201:8333",
+	"5.199.144.20:8333",
+	"12.11.29.34:8333",
+	"14.49.142.41:8333",
+	"18.27.125.103:8333",
+	"18.167.227.198:8333",
+	"23.93.18.82:8333",
+	"23.137.57.100:8333",
+	"23.175.0.220:8333",
+	"23.182.128.72:8333",
+	"24.16.202.74:8333",
+	"24.20.157.115:8333",
+	"24.55.147.22:8333",
+	"24.60.237.56:8333",
+	"24.113.59.144:8333",
+	"24.125.98.176:8333",
+	"24.125.218.110:8333",
+	"24.155.115.92:8333",
+	"24.241.102.26:8333",
+	"24.243.114.119:8333",
+	"27.83.109.113:8333",
+	"31.41.23.249:8333",
+	"31.47.202.112:8333",
+	"31.172.68.217:8333",
+	"31.201.57.162:8333",
+	"31.208.21.228:8333",
+	"31.215.74.178:8333",
+	"31.220.96.68:8333",
+	"34.65.45.157:8333",
+	"34.81.187.66:8333",
+	"35.78.97.86:8333",
+	"37.15.61.236:8333",
+	"37.57.13.143:8333",
+	"37.72.175.226:8333",
+	"37.77.150.48:8333",
+	"37.156.45.95:8333",
+	"37.157.192.94:8333",
+	"37.204.171.82:8333",
+	"37.205.14.137:8333",
+	"38.52.3.192:8333",
+	"38.86.135.160:8333",
+	"38.102.85.36:8333",
+	"38.102.86.40:8333",
+	"38.162.172.203:8333",
+	"38.180.15.3:8333",
+	"38.180.242.6:8333",
+	"40.160.1.232:8333",
+	"44.223.26.178:8333",
+	"45.19.130.200:8333",
+	"45.55.212.100:8333",
+	"45.88.106.107:8333",
+	"45.92.45.17:8333",
+	"45.92.217.83:8333",
+	"45.94.168.5:8333",
+	"45.135.180.59:8333",
+	"45.137.89.190:8333",
+	"45.154.252.162:8333",
+	"46.126.216.3:8333",
+	"46.128.89.11:8333",
+	"46.148.235.36:8333",
+	"46.166.142.2:8333",
+	"46.226.18.195:8333",
+	"46.229.165.147:8333",
+	"46.255.123.38:8333",
+	"46.255.124.38:8333",
+	"47.90.137.13:8333",
+	"47.198.209.187:8333",
+	"47.221.66.236:8333",
+	"47.252.14.48:8333",
+	"50.4.123.66:8333",
+	"50.5.142.64:8333",
+	"50.30.36.140:8333",
+	"50.32.70.36:8333",
+	"50.46.236.20:8333",
+	"50.55.10.199:8333",
+	"50.115.188.236:8333",
+	"50.194.102.27:8333",
+	"50.213.123.122:8333",
+	"50.224.61.13:8333",
+	"51.154.0.142:8333",
+	"51.154.62.103:8333",
+	"51.159.34.73:8333",
+	"52.182.185.242:8333",
+	"60.241.1.72:8333",
+	"62.34.57.141:8333",
+	"62.80.166.146:8333",
+	"62.163.118.133:8333",
+	"62.169.17.137:8333",
+	"62.209.210.3:8333",
+	"62.238.237.242:8333",
+	"63.247.147.166:8333",
+	"64.23.97.128:8333",
+	"64.24.185.117:8333",
+	"64.28.46.59:8333",
+	"64.34.84.37:8333",
+	"64.225.29.221:8333",
+	"64.255.148.18:8333",
+	"65.94.134.253:8333",
+	"65.109.53.250:8333",
+	"66.35.84.14:8333",
+	"66.84.82.241:8333",
+	"66.85.228.125:8333",
+	"66.85.236.102:8333",
+	"66.91.103.201:8333",
+	"66.129.161.70:8333",
+	"66.163.223.67:8333",
+	"67.4.139.122:8333",
+	"67.85.175.241:8333",
+	"67.144.241.44:8333",
+	"67.149.47.147:8333",
+	"67.176.35.116:8333",
+	"67.193.238.111:8333",
+	"68.61.69.53:8333",
+	"68.75.195.2:8333",
+	"68.103.63.198:8333",
+	"68.148.76.43:8333",
+	"68.203.5.191:8333",
+	"69.4.94.226:8333",
+	"69.36.52.44:8333",
+	"69.164.252.35:8333",
+	"69.196.152.33:8333",
+	"69.247.117.155:8333",
+	"69.251.182.112:8333",
+	"70.44.20.24:8333",
+	"70.171.5.85:8333",
+	"71.56.178.136:8333",
+	"71.95.147.98:8333",
+	"71.221.75.1:8333",
+	"72.88.192.74:8333",
+	"72.95.92.150:8333",
+	"72.146.11.215:8333",
+	"72.184.80.187:8333",
+	"72.255.188.46:8333",
+	"73.42.33.255:8333",
+	"73.42.44.73:8333",
+	"73.85.226.234:8333",
+	"73.95.180.169:8333",
+	"73.112.110.73:8333",
+	"73.119.152.128:8333",
+	"73.127.198.58:8333",
+	"73.166.191.28:8333",
+	"73.173.116.76:8333",
+	"73.174.114.78:8333",
+	"73.206.240.158:8333",
+	"73.208.251.19:8333",
+	"73.222.242.133:8333",
+	"73.228.63.6:8333",
+	"73.230.2.79:8333",
+	"73.235.73.202:8333",
+	"74.48.195.218:8333",
+	"74.50.72.174:8333",
+	"74.78.38.32:8333",
+	"74.88.231.79:8333",
+	"74.91.112.145:8333",
+	"74.112.115.219:8333",
+	"74.133.65.93:8333",
+	"74.207.235.83:8333",
+	"74.213.175.108:8333",
+	"74.220.255.190:8333",
+	"75.80.3.4:8333",
+	"75.240.60.110:8333",
+	"76.124.35.108:8333",
+	"76.249.147.146:8333",
+	"77.38.72.37:8333",
+	"77.74.80.179:8333",
+	"77.169.255.26:8333",
+	"77.174.133.117:8333",
+	"78.21.161.37:8333",
+	"78.80.34.203:8333",
+	"78.87.87.207:8333",
+	"78.102.55.112:8333",
+	"78.143.211.56:8333",
+	"79.19.180.178:8333",
+	"79.116.84.221:8333",
+	"79.117.128.153:8333",
+	"79.118.113.130:8333",
+	"79.205.255.234:8333",
+	"80.87.196.14:8333",
+	"80.108.31.228:8333",
+	"81.6.11.67:8333",
+	"81.6.36.61:8333",
+	"81.83.45.130:8333",
+	"81.97.77.100:8333",
+	"81.141.148.202:8333",
+	"81.168.83.235:8333",
+	"82.64.135.138:8333",
+	"82.67.102.15:8333",
+	"82.68.63.28:8333",
+	"82.69.62.20:8333",
+	"82.96.96.40:8333",
+	"82.166.165.78:8333",
+	"82.181.245.129:8333",
+	"83.58.186.136:8333",
+	"83.150.61.170:8333",
+	"83.240.78.205:8333",
+	"84.16.39.139:8333",
+	"84.18.229.2:8333",
+	"84.32.32.132:8333",
+	"84.46.117.176:8333",
+	"84.70.184.254:8333",
+	"84.210.213.168:8333",
+	"84.215.3.81:8333",
+	"84.242.84.78:8333",
+	"85.0.91.69:8333",
+	"85.5.255.187:8333",
+	"85.87.25.182:8333",
+	"85.163.23.103:8333",
+	"85.172.205.112:8333",
+	"85.206.173.254:8333",
+	"85.208.69.21:8333",
+	"85.219.56.128:8333",
+	"85.246.5.111:8333",
+	"85.251.67.179:8333",
+	"86.22.20.13:8333",
+	"86.101.92.93:8333",
+	"86.101.155.34:8333",
+	"87.125.52.211:8333",
+	"87.236.195.198:8333",
+	"88.12.147.253:8333",
+	"88.90.77.72:8333",
+	"88.153.226.41:8333",
+	"88.202.252.8:8333",
+	"89.58.10.65:8333",
+	"89.58.60.208:8333",
+	"89.58.70.141:8333",
+	"89.155.141.137:8333",
+	"89.233.246.104:8333",
+	"90.26.202.86:8333",
+	"90.103.132.119:8333",
+	"90.242.36.2:8333",
+	"91.125.172.33:8333",
+	"91.190.198.136:8333",
+	"91.206.17.195:8333",
+	"91.210.109.21:8333",
+	"91.236.251.137:8333",
+	"92.21.186.229:8333",
+	"92.27.11.85:8333",
+	"92.39.195.54:8333",
+	"92.53.84.165:8333",
+	"92.97.104.242:8333",
+	"93.57.81.162:8333",
+	"93.66.217.115:8333",
+	"93.95.88.13:8333",
+	"93.103.13.1:8333",
+	"93.231.226.32:8333",
+	"94.100.70.89:8333",
+	"94.241.71.63:8333",
+	"95.17.109.226:8333",
+	"95.79.32.63:8333",
+	"95.90.138.145:8333",
+	"95.99.66.212:8333",
+	"95.159.237.44:8333",
+	"95.217.41.33:8333",
+	"96.43.142.163:8333",
+	"96.53.170.130:8333",
+	"97.206.193.242:8333",
+	"98.13.77.64:8333",
+	"98.33.114.133:8333",
+	"98.58.14.245:8333",
+	"98.128.230.186:8333",
+	"99.199.133.225:8333",
+	"99.247.63.171:8333",
+	"101.173.70.101:8333",
+	"102.132.147.216:8333",
+	"102.132.172.34:8333",
+	"103.60.111.233:8333",
+	"103.97.241.231:8333",
+	"103.108.231.153:8333",
+	"103.228.171.171:8333",
+	"103.246.186.167:8333",
+	"104.128.64.58:8333",
+	"104.161.4.138:8333",
+	"104.194.35.180:8333",
+	"104.223.21.214:8333",
+	"104.231.105.188:8333",
+	"104.243.35.225:8333",
+	"107.5.153.70:8333",
+	"107.13.97.236:8333",
+	"107.150.46.114:8333",
+	"108.3.147.53:8333",
+	"108.175.176.253:8333",
+	"109.49.177.247:8333",
+	"109.164.111.129:8333",
+	"109.173.57.116:8333",
+	"109.184.218.27:8333",
+	"113.119.24.160:8333",
+	"114.32.171.248:8333",
+	"116.121.193.36:8333",
+	"116.255.5.183:8333",
+	"118.24.37.253:8333",
+	"118.208.149.193:8333",
+	"119.56.188.135:8333",
+	"120.88.48.94:8333",
+	"121.2.37.181:8333",
+	"128.2.12.38:8333",
+	"128.116.210.29:8333",
+	"129.13.189.215:8333",
+	"129.213.39.235:8333",
+	"132.147.192.5:8333",
+	"133.130.102.233:8333",
+	"134.65.193.149:8333",
+	"135.129.154.242:8333",
+	"136.33.76.48:8333",
+	"136.58.52.105:8333",
+	"137.226.34.45:8333",
+	"139.94.114.153:8333",
+	"139.138.123.236:8333",
+	"139.191.2.18:8333",
+	"140.238.156.54:8333",
+	"141.98.219.12:8333",
+	"141.105.125.196:8333",
+	"142.91.158.156:8333",
+	"142.134.28.118:8333",
+	"142.163.162.254:8333",
+	"142.171.84.171:8333",
+	"142.177.121.80:8333",
+	"143.109.159.15:8333",
+	"144.6.121.192:8333",
+	"144.137.29.181:8333",
+	"146.0.75.177:8333",
+	"146.70.137.195:8333",
+	"146.90.189.86:8333",
+	"147.91.80.50:8333",
+	"147.236.213.18:8333",
+	"148.51.196.40:8333",
+	"148.163.68.23:8333",
+	"149.28.116.34:8333",
+	"149.115.192.160:8333",
+	"149.143.123.39:8333",
+	"152.230.180.115:8333",
+	"153.55.138.33:8333",
+	"154.26.137.105:8333",
+	"154.38.180.47:8333",
+	"157.143.59.246:8333",
+	"157.173.24.222:8333",
+	"157.250.201.247:8333",
+	"158.160.127.230:8333",
+	"159.196.224.236:8333",
+	"159.246.25.53:8333",
+	"160.2.132.64:8333",
+	"160.3.1.16:8333",
+	"160.16.205.60:8333",
+	"162.19.102.6:8333",
+	"162.81.160.34:8333",
+	"162.120.19.21:8333",
+	"162.120.69.182:8333",
+	"162.141.92.64:8333",
+	"162.217.203.46:8333",
+	"162.218.223.25:8333",
+	"162.245.196.107:8333",
+	"162.252.198.201:8333",
+	"162.253.155.243:8333",
+	"163.114.159.205:8333",
+	"163.123.157.11:8333",
+	"163.172.88.149:8333",
+	"163.252.155.128:8333",
+	"164.215.67.56:8333",
+	"164.215.119.118:8333",
+	"166.70.69.241:8333",
+	"166.78.241.9:8333",
+	"167.253.34.251:8333",
+	"169.155.170.211:8333",
+	"170.39.191.16:8333",
+	"170.133.3.34:8333",
+	"170.205.178.76:8333",
+	"172.96.141.17:8333",
+	"172.233.211.171:8333",
+	"172.252.71.124:8333",
+	"173.24.24.136:8333",
+	"173.32.219.110:8333",
+	"173.180.162.157:8333",
+	"173.190.200.94:8333",
+	"173.236.10.158:8333",
+	"173.241.227.243:8333",
+	"173.243.43.229:8333",
+	"173.249.205.26:8333",
+	"174.20.110.67:8333",
+	"174.63.171.76:8333",
+	"174.177.47.73:8333",
+	"175.32.117.206:8333",
+	"176.25.88.206:8333",
+	"176.61.165.59:8333",
+	"176.74.136.237:8333",
+	"176.123.10.244:8333",
+	"176.126.167.10:8333",
+	"176.188.234.184:8333",
+	"177.140.143.71:8333",
+	"178.124.214.57:8333",
+	"178.166.3.225:8333",
+	"178.174.128.24:8333",
+	"178.250.232.111:8333",
+	"181.94.213.253:8333",
+	"181.105.99.59:8333",
+	"181.115.88.2:8333",
+	"181.205.6.149:8333",
+	"183.88.223.208:8333",
+	"184.74.240.157:8333",
+	"184.95.13.14:8333",
+	"184.95.32.130:8333",
+	"184.152.77.81:8333",
+	"184.162.218.131:8333",
+	"185.9.0.188:8333",
+	"185.12.15.13:8333",
+	"185.52.93.45:8333",
+	"185.68.251.116:8333",
+	"185.88.248.162:8333",
+	"185.146.157.3:8333",
+	"185.148.146.24:8333",
+	"185.152.138.74:8333",
+	"185.157.162.236:8333",
+	"185.159.20.143:8333",
+	"185.181.221.203:8333",
+	"185.182.194.11:8333",
+	"185.203.41.148:8333",
+	"185.209.12.76:8333",
+	"185.210.125.33:8333",
+	"185.215.167.23:8333",
+	"185.223.30.131:8333",
+	"185.245.145.7:8333",
+	"188.39.33.98:8333",
+	"188.122.17.36:8333",
+	"188.127.226.159:8333",
+	"188.138.39.219:8333",
+	"188.150.76.87:8333",
+	"188.154.159.130:8333",
+	"188.168.51.98:8333",
+	"188.213.94.74:8333",
+	"188.214.129.52:8333",
+	"188.214.129.139:8333",
+	"190.203.111.221:8333",
+	"191.93.156.166:8333",
+	"192.3.11.26:8333",
+	"192.30.243.9:8333",
+	"192.146.137.44:8333",
+	"192.226.179.38:8333",
+	"192.243.215.102:8333",
+	"193.37.255.146:8333",
+	"193.77.81.228:8333",
+	"193.165.169.114:8333",
+	"194.67.95.109:8333",
+	"194.147.140.37:8333",
+	"194.156.188.249:8333",
+	"194.191.232.153:8333",
+	"195.3.222.66:8333",
+	"195.80.233.111:8333",
+	"195.123.217.63:8333",
+	"195.181.193.251:8333",
+	"198.48.148.76:8333",
+	"198.154.93.110:8333",
+	"199.168.201.26:8333",
+	"199.241.26.150:8333",
+	"200.25.7.70:8333",
+	"201.0.21.163:8333",
+	"202.128.122.150:8333",
+	"203.11.72.53:8333",
+	"203.11.72.174:8333",
+	"203.161.35.68:8333",
+	"204.9.27.94:8333",
+	"204.16.244.120:8333",
+	"204.111.88.33:8333",
+	"204.228.151.196:8333",
+	"205.144.209.54:8333",
+	"205.201.77.195:8333",
+	"205.209.118.254:8333",
+	"205.233.47.221:8333",
+	"206.125.169.164:8333",
+	"206.162.29.245:8333",
+	"206.223.211.76:8333",
+	"207.5.60.5:8333",
+	"207.66.71.46:8333",
+	"207.182.146.85:8333",
+	"207.182.146.130:8333",
+	"208.38.246.227:8333",
+	"208.68.4.71:8333",
+	"208.102.114.10:8333",
+	"209.122.243.163:8333",
+	"209.227.228.193:8333",
+	"212.5.157.40:8333",
+	"212.41.28.120:8333",
+	"212.41.106.8:8333",
+	"212.87.158.134:8333",
+	"212.112.65.254:8333",
+	"212.142.98.187:8333",
+	"212.158.133.185:8333",
+	"212.227.150.147:8333",
+	"212.227.211.87:8333",
+	"213.110.208.173:8333",
+	"213.111.156.228:8333",
+	"213.112.57.7:8333",
+	"213.182.250.130:8333",
+	"213.219.166.93:8333",
+	"216.188.233.105:8333",
+	"216.226.128.189:8333",
+	"217.24.162.174:8333",
+	"217.79.247.130:8333",
+	"217.123.85.163:8333",
+	"217.169.20.204:8333",
+	"217.180.221.162:8333",
+	"217.211.131.194:8333",
+	"220.88.50.234:8333",
+	"220.146.214.238:8333",
+	"221.168.36.253:8333",
+	"[2001:1284:f502:9104:419d:b3ea:216:61eb]:8333",
+	"[2001:1284:f502:9104:efbe:8fa2:f6aa:bd51]:8333",
+	"[2001:14f4:240:5900:be24:11ff:feb8:6e54]:8333",
+	"[2001:1620:542c:210::100]:8333",
+	"[2001:1620:5566:100::62c]:8333",
+	"[2001:1670:1a:ac92:b9d2:c836:ce2c:de66]:8333",
+	"[2001:1708:2c16:1d00::1b9]:8333",
+	"[2001:18b8:0:100:0:b00b:420:69]:8333",
+	"[2001:1970:4a9c:5000::5c5f]:8333",
+	"[2001:19f0:6801:6ec:2::1]:8333",
+	"[2001:19f0:7001:160b:3eec:efff:feb9:8994]:8333",
+	"[2001:1ab0:7e1e:d150:be24:11ff:fe2c:302f]:8333",
+	"[2001:1bc0:c1::2000]:8333",
+	"[2001:1c00:da07:9000:a863:dde4:b83e:f696]:8333",
+	"[2001:1c02:105:3500:852c:d422:a612:e394]:8333",
+	"[2001:2043:180e:401::47]:8333",
+	"[2001:250:1001:1621:401a:5c40:322f:9ea3]:8333",
+	"[2001:4060:4419:8001::42]:8333",
+	"[2001:4091:a246:8148:be24:11ff:fef1:5929]:8333",
+	"[2001:41d0:248:ac00::2]:8333",
+	"[2001:41d0:2:bf8f::]:8333",
+	"[2001:41d0:403:20b5::21]:8333",
+	"[2001:41d0:602:f38::1]:8333",
+	"[2001:41d0:8:ed7f::1]:8333",
+	"[2001:41d0:a:69a2::1]:8333",
+	"[2001:470:1f05:43b:2::c]:8333",
+	"[2001:470:1f08:3cc::2]:8333",
+	"[2001:470:1f0a:89a::2]:8333",
+	"[2001:470:1f22:11c::2]:8333",
+	"[2001:470:1f2a:8d::2]:8333",
+	"[2001:470:23:8c::2]:8333",
+	"[2001:470:28:b17::2]:8333",
+	"[2001:470:6c80:3::1]:8333",
+	"[2001:470:75e9:1::10]:8333",
+	"[2001:470:88ff:2e::1]:8333",
+	"[2001:4dd0:3564:0:30b7:1d7b:6fec:4c5c]:8333",
+	"[2001:4dd0:3564:0:88e:b4ff:2ad0:699b]:8333",
+	"[2001:4dd0:3564:0:9c1c:cc31:9fe8:5505]:8333",
+	"[2001:4dd0:3564:0:a0c4:d41f:4c4:1bb0]:8333",
+	"[2001:4dd0:3564:0:fd76:c1d3:1854:5bd9]:8333",
+	"[2001:4dd0:3564:1::7676:8090]:8333",
+	"[2001:4dd0:3564:1:b977:bd71:4612:8e40]:8333",
+	"[2001:4dd0:af0e:3564:0:69:90:8333]:8333",
+	"[2001:4dd0:af0e:3564::69:1]:8333",
+	"[2001:4dd0:af0e:3564::69:90]:8333",
+	"[2001:550:af00:7:0:1:aff9:18]:8333",
+	"[2001:569:5079:abd2::c9]:8333",
+	"[2001:569:713f:4800:465d:c0ae:a13a:4f3e]:8333",
+	"[2001:5a8:4164:7a00::1f8]:8333",
+	"[2001:5a8:4164:7a00:be60:b5aa:22f0:d1cb]:8333",
+	"[2001:5a8:60c0:d500::7840]:8333",
+	"[2001:678:68c:fffb::195]:8333",
+	"[2001:678:bd0::2:191]:8333",
+	"[2001:67c:1220:808::93e5:81f]:8333",
+	"[2001:67c:1254:d2:6b9c::1]:8333",
+	"[2001:67c:26b4:ff00::44]:8333",
+	"[2001:67c:2a0:459::251]:8333",
+	"[2001:67c:440:688:91:236:251:137]:8333",
+	"[2001:67c:440:f887:194:147:140:37]:8333",
+	"[2001:67c:bcc:1f2a::b783]:8333",
+	"[2001:718:604:20::83:33]:8333",
+	"[2001:8003:941b:ca00:f22f:74ff:fe1e:5b33]:8333",
+	"[2001:818:df59:5800:f8a4:ceff:fefd:d63a]:8333",
+	"[2001:861:3400:2e20:be24:11ff:fe57:eec7]:8333",
+	"[2001:871:64:595a:ad4:cff:fe77:a114]:8333",
+	"[2001:8a0:e1d7:8d00:28c:faff:fe95:6ec9]:8333",
+	"[2001:8b0:1301:1000::60]:8333",
+	"[2001:8b0:ba7b:c965::8:85]:8333",
+	"[2001:8b0:fe25:c58b:1266:6aff:fe1f:cfa9]:8333",
+	"[2001:9b1:c261:2401:96c6:91ff:fe1b:e01e]:8333",
+	"[2001:a40:100:e:be24:11ff:feff:b5a3]:8333",
+	"[2001:a61:cac:a501:8aa2:9eff:fe7b:a1aa]:8333",
+	"[2001:b030:2422::208d]:8333",
+	"[2001:b07:6443:723a:70d1:114a:bc13:9504]:8333",
+	"[2001:b07:6443:723a:f316:b9be:e15f:3c6e]:8333",
+	"[2001:b07:6461:7811:489:d2da:e07:1af7]:8333",
+	"[2001:b07:6469:3491:56be:f7ff:fe26:21bb]:8333",
+	"[2001:b07:6472:649d:b9d5:e1b9:2850:beee]:8333",
+	"[2001:b07:6474:51d8:6156:84e7:397a:a847]:8333",
+	"[2001:b07:6474:51d8:c27e:427e:fe37:6356]:8333",
+	"[2001:bc8:1201:715:ca1f:66ff:fec9:5ff0]:8333",
+	"[2001:bc8:1201:71a:2e59:e5ff:fe42:52f4]:8333",
+	"[2001:bc8:1201:722:da5e:d3ff:fe49:9528]:8333",
+	"[2001:bc8:30c8::]:8333",
+	"[2001:bc8:399f:f000::1]:8333",
+	"[2001:bc8:6005:1d:208:a2ff:fe0c:6cc2]:8333",
+	"[2001:bc8:610:9:46a8:42ff:fe0c:d385]:8333",
+	"[2001:bc8:701:40d:ae16:2dff:fea6:e868]:8333",
+	"[2001:bc8:701:706:7ec2:55ff:fe9d:56bc]:8333",
+	"[2001:df1:5840:5010:aa3d:6540:8681:3fa6]:8333",
+	"[2001:ee0:5752:72d0:2e0:4cff:fe08:8998]:8333",
+	"[2001:f40:906:11ff:b134:38e8:e87:91f4]:8333",
+	"[2001:f40:94e:1775:7270:fcff:fe05:3cd]:8333",
+	"[2001:f40:962:ab89:1e1b:dff:fe9c:4f64]:8333",
+	"[2002:3ed2:d97e::3ed2:d97e]:8333",
+	"[2002:5a92:cf43:0:7e6b:b719:d96c:4a09]:8333",
+	"[2003:106:e707:5700:2ff1:5e50:9a78:e6b1]:8333",
+	"[2003:c5:1735:f600:8aa2:9eff:fe05:1f0c]:8333",
+	"[2003:cd:e741:4e01::2000]:8333",
+	"[2003:d1:4707:8b00:62cf:84ff:fe9e:535e]:8333",
+	"[2003:dc:2f19:9300:4ecc:6aff:fe25:c9a3]:8333",
+	"[2003:e1:a700:2b00:5a47:caff:fe73:450c]:8333",
+	"[2003:f0:df11:2102:aaa1:59ff:fe57:7779]:8333",
+	"[2003:f4:9715:9100:24e:1ff:fec5:ae47]:8333",
+	"[2400:2411:a3e1:4900:be53:fbba:4f36:7317]:8333",
+	"[2400:6180:100:d0::2913:a002]:8333",
+	"[2400:6180:100:d0::2913:b001]:8333",
+	"[2400:8901::f03c:92ff:fe4e:95f3]:8333",
+	"[2400:8a20:112:6::2]:8333",
+	"[2401:b140:3::44:120]:8333",
+	"[2401:d002:2103:400:211:32ff:fe9e:7ae3]:8333",
+	"[2401:d002:3303:bc00::3]:8333",
+	"[2401:d002:3902:700:d72c:5e22:4e95:389d]:8333",
+	"[2401:d005:9c01:320a::1267]:8333",
+	"[2403:580c:e4d8:0:c738:d850:449f:1d46]:8333",
+	"[2403:5815:3752:0:250:56ff:fe8e:b971]:8333",
+	"[2403:6200:8858:eeb0:29f:e890:d95c:2577]:8333",
+	"[2403:6200:8858:eeb0:4afb:a02:8643:a3ef]:8333",
+	"[2403:6200:8870:bdc1::1]:8333",
+	"[2403:6200:8870:bdc1:d601:c3ff:fe5d:d33]:8333",
+	"[2403:6200:88a4:8b79:eaff:1eff:fed8:8cb4]:8333",
+	"[2404:4400:416c:f400:4a21:bff:fe32:571]:8333",
+	"[2404:9400:4:0:216:3eff:fee8:1a40]:8333",
+	"[2405:6581:1f40:2f00:83b:c416:36b9:9bd9]:8333",
+	"[2405:9800:bc30:dc7d:5b2c:6a8c:95f6:4d75]:8333",
+	"[2405:9800:bc30:dc7d:ac52:d0f:1804:fbc]:8333",
+	"[2405:d000:100f:1000:365a:60ff:fe3f:3951]:8333",
+	"[2405:e480:2:1a::2]:8333",
+	"[2406:3003:2005:2512:c426:e0b6:4d47:ab3f]:8333",
+	"[2406:3400:217:4be0:4652:356b:f5bf:c332]:8333",
+	"[2406:5900:105d:48f7:21e:6ff:fe53:6aea]:8333",
+	"[2406:5900:5016:15a2:21e:6ff:fe53:67e3]:8333",
+	"[2406:8c00:0:3449:133:18:109:30]:8333",
+	"[2406:da18:9f1:f300:556c:eff4:3a1b:bca7]:8333",
+	"[2407:3640:2107:1278::1]:8333",
+	"[2407:3640:2257:6724::1]:8333",
+	"[2407:3640:2264:9052::1]:8333",
+	"[2407:3640:2291:3183::1]:8333",
+	"[2407:8800:bc61:2202:67f5:1401:54a7:1538]:8333",
+	"[2407:8b00:1170:f700:2ef0:5dff:fed5:5cc8]:8333",
+	"[2409:8a00:2496:880:214:e8b7:18b:41f]:8333",
+	"[240b:10:afe0:8000:39bc:1cff:86e2:beef]:8333",
+	"[240b:11:43a1:bd00:7102:e6d7:2249:620d]:8333",
+	"[240b:251:76a3:b300:b00b:3289:e77d:8b18]:8333",
+	"[240d:2:5650:9500:be24:11ff:fe08:9217]:8333",
+	"[240f:cb:7c3:1:be24:11ff:fe1f:8c79]:8333",
+	"[2600:1002:a013:43a8:5104:21fe:d4e8:f10e]:8333",
+	"[2600:1700:3948:82f:84c8:1aff:fef7:2e5d]:8333",
+	"[2600:1700:3948:82f:9c34:d81c:68ab:61fd]:8333",
+	"[2600:1700:6f20:abc4:6b7c:811e:9fcc:40e7]:8333",
+	"[2600:1700:721:4df:3e19:4743:5df4:d6ac]:8333",
+	"[2600:1700:8e41:4bc3::7]:8333",
+	"[2600:1701:404:4da0::27]:8333",
+	"[2600:1702:5611:a600::47]:8333",
+	"[2600:1702:7aa0:10b0:266e:96ff:fe46:9060]:8333",
+	"[2600:1702:7aa0:10b0:266e:96ff:fe46:9218]:8333",
+	"[2600:1702:7aa0:10b0:be30:5bff:feef:b801]:8333",
+	"[2600:1900:4010:b0:0:1::]:8333",
+	"[2600:1900:4090:5db::]:8333",
+	"[2600:1900:4090:6f9::]:8333",
+	"[2600:1900:40a0:5989:0:2::]:8333",
+	"[2600:1900:4150:41b:0:1::]:8333",
+	"[2600:1900:4160:39d:0:1::]:8333",
+	"[2600:1900:4170:851e::]:8333",
+	"[2600:1900:4181:d3::]:8333",
+	"[2600:1900:41a0:706::]:8333",
+	"[2600:1900:41d0:dace::]:8333",
+	"[2600:1f18:64d9:1603:4436:871e:2bfe:7403]:8333",
+	"[2600:1f18:66fc:d700:496a:a532:8caa:32bd]:8333",
+	"[2600:1f18:66fc:d700:7133:38b3:8b0a:32f]:8333",
+	"[2600:1f18:66fc:d700:781f:8fa8:eb3e:33dc]:8333",
+	"[2600:1f18:66fc:d700:8d26:5104:6108:8691]:8333",
+	"[2600:1f18:66fc:d700:aaac:6672:98be:a0e5]:8333",
+	"[2600:1f18:66fc:d700:be6f:27a6:7449:b1c3]:8333",
+	"[2600:1f18:66fc:d700:fb0f:3b9d:a7c9:84cd]:8333",
+	"[2600:1f18:719a:e302:2758:8042:929f:a384]:8333",
+	"[2600:1f18:719a:e302:4c90:e1e6:2a59:82c4]:8333",
+	"[2600:3c00::f03c:94ff:feb7:4dd7]:8333",
+	"[2600:3c02::f03c:92ff:fe5d:9fb]:8333",
+	"[2600:3c02::f03c:93ff:fe14:a4f2]:8333",
+	"[2600:3c02::f03c:95ff:fe1e:f264]:8333",
+	"[2600:3c02:e004:cb32::1:c8]:8333",
+	"[2600:3c06::2000:15ff:fe75:d414]:8333",
+	"[2600:3c0e::f03c:94ff:fe63:2e90]:8333",
+	"[2600:4040:2024:b800::1:f4ae]:8333",
+	"[2600:4040:51e8:ec06:20c:29ff:fe5d:a967]:8333",
+	"[2600:4040:941e:2c00:f16:8e6:78f4:74ff]:8333",
+	"[2600:4041:2056:3800::17fe]:8333",
+	"[2600:4808:8cb3:8700:a236:9fff:fe32:38b0]:8333",
+	"[2600:4808:a133:f800:b95c:f0ff:6ff5:8bd5]:8333",
+	"[2600:6c48:5b00:423a::2100]:8333",
+	"[2600:8800:3280:4a:2424:990d:cad3:1ba0]:8333",
+	"[2600:8801:47de:6e4:8aa2:9eff:fe07:cedf]:8333",
+	"[2601:147:4c80:c18f:bace:f6ff:fe95:ddd0]:8333",
+	"[2601:18c:8e80:a3c3:219:d1ff:fe75:dc2f]:8333",
+	"[2601:246:4d7f:692e:6562:16aa:ffe5:250b]:8333",
+	"[2601:41:c200:bf0b:92b1:1cff:fe96:b198]:8333",
+	"[2601:41:c300:f109:2e44:fdff:fe0e:68ca]:8333",
+	"[2601:547:c601:14a3::1000]:8333",
+	"[2601:602:8680:ea7:90ad:b369:ce8a:bdfe]:8333",
+	"[2601:602:c484:6cd6:a157:2994:c929:c3bd]:8333",
+	"[2601:603:5000:3309:0:ff:fe00:4209]:8333",
+	"[2601:647:6380:8f6a::962]:8333",
+	"[2601:647:6380:8f6a::da5]:8333",
+	"[2601:681:4a00:51b0:ce08:a2d9:ecf4:47b9]:8333",
+	"[2601:84:c900:1b90:be24:11ff:fe8d:f86f]:8333",
+	"[2601:b015:ff05:e895::39]:8333",
+	"[2602:61:71ea:d501:d0e4:d5ff:fe53:d037]:8333",
+	"[2602:61:737e:1806:be24:11ff:fea9:6ffa]:8333",
+	"[2602:f480:ac:c010::50]:8333",
+	"[2602:f480:ac:c010::71]:8333",
+	"[2602:f480:ac:c010::77]:8333",
+	"[2602:f687:1:c5ab:dc5e:90ff:fe18:1d08]:8333",
+	"[2602:fb57:dc8::36]:8333",
+	"[2602:fd23:3:1::1:213]:8333",
+	"[2602:fec3:101:e::5:73]:8333",
+	"[2602:fec3:101:f::12:73]:8333",
+	"[2602:ffb6:4:1a8b:f816:3eff:fe16:f3eb]:8333",
+	"[2603:3005:549d:2201:20b5:71ff:fedc:bc9a]:8333",
+	"[2603:3007:701:8000:4748:1889:7200:6d2]:8333",
+	"[2603:300a:912:627a:be24:11ff:fe7b:39c3]:8333",
+	"[2603:8081:6c00:4b54:215:5dff:fe4d:1665]:8333",
+	"[2603:8083:8800:501:9ab7:85ff:fe0f:43ba]:8333",
+	"[2603:80a0:700:1886::39]:8333",
+	"[2603:9001:3600:2502::b]:8333",
+	"[2603:c021:4:db01:45f9:66ce:47f0:9b34]:8333",
+	"[2604:4500:6:285::18]:8333",
+	"[2604:5940:0:327::]:8333",
+	"[2604:a00:2df0:5:dae1:14f3:40a5:78b3]:8333",
+	"[2604:a00:50:13e:216:3eff:fe2e:d8c3]:8333",
+	"[2604:a00:50:212:216:3eff:fe2f:37a5]:8333",
+	"[2604:a880:400:d1::6143:1001]:8333",
+	"[2604:a880:4:1d0::1fd3:7000]:8333",
+	"[2604:a880:cad:d0::75b2:4001]:8333",
+	"[2604:a880:cad:d0::75b2:4002]:8333",
+	"[2605:21c0:2000:11:204:194:220:40]:8333",
+	"[2605:3380:422e:1::50]:8333",
+	"[2605:59c8:2400:84c3:590c:cbe0:cd7e:869a]:8333",
+	"[2605:6440:3001:a9:3eec:efff:fe20:9b2c]:8333",
+	"[2605:6440:3001:a9::2]:8333",
+	"[2605:6440:d000:2a0:925a:8ff:fe2e:836f]:8333",
+	"[2605:6441:2001:53:7ec2:55ff:fea8:3062]:8333",
+	"[2605:6441:2001:53::2]:8333",
+	"[2605:a140:2259:8671::1]:8333",
+	"[2605:a140:2278:4192::1]:8333",
+	"[2605:a142:2293:2693::1]:8333",
+	"[2605:a143:2162:7067::1]:8333",
+	"[2605:a143:2268:2414::1]:8333",
+	"[2605:a143:2269:4485::1]:8333",
+	"[2605:a143:2269:8843::1]:8333",
+	"[2605:a601:a61b:6c00:5054:ff:fe66:c0a1]:8333",
+	"[2605:a601:a9ad:8f00::2]:8333",
+	"[2605:a601:acef:2500:4ffa:26d3:6bb2:f3ff]:8333",
+	"[2606:2602:3a01:0:cf65:35c5:116f:953]:8333",
+	"[2606:2602:3a01::14f]:8333",
+	"[2606:8240:8113:fc92:c5ce:43e3:dd73:71c5]:8333",
+	"[2606:9f40:1004:8000:216:3eff:fed2:a05e]:8333",
+	"[2607:5300:203:5b84::1]:8333",
+	"[2607:5300:203:6144::]:8333",
+	"[2607:5300:60:2e54::1]:8333",
+	"[2607:5300:60:614::1]:8333",
+	"[2607:9280:b:73b:250:56ff:fe14:25b5]:8333",
+	"[2607:f178:10:43:756:3a3f:e3bb:e7e3]:8333",
+	"[2607:f2c0:f00e:300::54]:8333",
+	"[2607:fcc8:ffc0:b6:1900:d0a1:6372:e569]:8333",
+	"[2607:fea8:6028:8101:be24:11ff:fe89:27f3]:8333",
+	"[2620:6:2003:105:67c:16ff:fe51:58bf]:8333",
+	"[2620:6e:a000:1:42:42:42:42]:8333",
+	"[2800:150:11d:645:28ae:91e2:344d:b107]:8333",
+	"[2800:40:38:544b:a236:bcff:fe58:b6ec]:8333",
+	"[2800:bf0:10d:e76:16b3:1fff:fe03:c93e]:8333",
+	"[2803:a200:2ca:106d:be24:11ff:fe17:12e0]:8333",
+	"[2804:14c:123:82ab:bc84:1bff:fe29:c99b]:8333",
+	"[2804:14c:7985:a02a:be24:11ff:fe7f:c63f]:8333",
+	"[2804:18dc:e:c900:7533:341b:efb0:c66d]:8333",
+	"[2804:431:e038:cd01:aaa1:59ff:fe0d:44b8]:8333",
+	"[2804:d56:e78:1700:4128:2ee9:ebc7:5ac4]:8333",
+	"[2806:103e:1b:4647:a37e:f71a:8b6c:f6d2]:8333",
+	"[2806:2f0:56e0:f40b:729f:c8f8:1d77:e971]:8333",
+	"[2a00:1028:83c8:d352:cc58:acdf:ab7f:af8d]:8333",
+	"[2a00:11b7:1131:c800:2a0:98ff:fe1a:bd59]:8333",
+	"[2a00:11c0:47:1834:a89c:cfff:febd:1f45]:8333",
+	"[2a00:11c0:47:1c1c::]:8333",
+	"[2a00:12e0:101:99:20c:29ff:fe29:d03f]:8333",
+	"[2a00:1370:818a:60d1:53e6:8cd7:58f8:7e77]:8333",
+	"[2a00:1398:4:2a03::bc03]:8333",
+	"[2a00:13a0:3015:1:85:14:79:26]:8333",
+	"[2a00:15c0:300a:100::1]:8333",
+	"[2a00:1768:2001:27::ef6a]:8333",
+	"[2a00:1e:e083:af01:b62e:99ff:fe7d:2521]:8333",
+	"[2a00:1ed0:142::c]:8333",
+	"[2a00:1f40:5001:108:5d17:7703:b0f5:4133]:8333",
+	"[2a00:1f40:5001:386:dead:beef:b1ac:c0fe]:8333",
+	"[2a00:1f:6e80:b901:9057:2ce5:4310:f590]:8333",
+	"[2a00:23a8:83b:2201:dc52:e0c7:9e99:a076]:8333",
+	"[2a00:23c5:58a0:2201:9e6b:ff:fe91:101f]:8333",
+	"[2a00:23c8:c014:1301:9806:1242:66ac:426b]:8333",
+	"[2a00:23c8:c014:1301:a1f9:1705:ac1e:7750]:8333",
+	"[2a00:23cc:db00:d401:8dfd:48b3:b133:56d3]:8333",
+	"[2a00:23cc:e174:9901:a319:af95:2b46:d9e0]:8333",
+	"[2a00:4d80::1]:8333",
+	"[2a00:6020:4a80:6978::10]:8333",
+	"[2a00:6020:4a9f:2a00:ffe8:27be:ad77:e526]:8333",
+	"[2a00:6020:502c:d000:211:32ff:fe5c:369c]:8333",
+	"[2a00:6020:509e:a400:211:32ff:fe5c:369c]:8333",
+	"[2a00:6020:50c9:cd00:936f:a16a:e832:938b]:8333",
+	"[2a00:6020:a79f:8700:be24:11ff:fea9:9df8]:8333",
+	"[2a00:8a60:e012:a00::9001]:8333",
+	"[2a00:ae40:240e:3202:96c6:91ff:fe12:548a]:8333",
+	"[2a00:bba0:1204:3700:21e:6ff:fe4a:5378]:8333",
+	"[2a00:bba0:120a:f100:216:96ff:feec:b63]:8333",
+	"[2a00:c6c0:0:142:1::1]:8333",
+	"[2a00:d4e0:107:4f02:7af2:9eff:fe90:31e0]:8333",
+	"[2a00:d880:5:c2::d329]:8333",
+	"[2a00:ee2:800:9000:1e69:7aff:fea0:d8d4]:8333",
+	"[2a01:239:407:a700::1]:8333",
+	"[2a01:240:ad00:2502:3:81f1:7eff:2061]:8333",
+	"[2a01:261:21c:ad00:ed34:c85:3391:d694]:8333",
+	"[2a01:4b00:807c:3100:ecbf:72a1:5c4d:f8fa]:8333",
+	"[2a01:4b00:bf36:8801:d2bf:9cff:fe45:9a60]:8333",
+	"[2a01:4f8:13b:1e59::2]:8333",
+	"[2a01:4f8:160:33c1::2]:8333",
+	"[2a01:4f8:221:2755::2]:8333",
+	"[2a01:4f8:231:3d6f::2]:8333",
+	"[2a01:4f8:261:2bcd::2]:8333",
+	"[2a01:4f8:262:5122:de54::ae1c]:8333",
+	"[2a01:4f9:2a:1550::2]:8333",
+	"[2a01:4f9:3071:21c5::2]:8333",
+	"[2a01:4f9:4a:2ad8::2]:8333",
+	"[2a01:4f9:5a:44a5::2]:8333",
+	"[2a01:4ff:1f0:8517::1]:8333",
+	"[2a01:4ff:1f0:c3c1::1]:8333",
+	"[2a01:4ff:2f0:35fb::1]:8333",
+	"[2a01:4ff:f0:977c::1]:8333",
+	"[2a01:5f0:c001:108:1e::1]:8333",
+	"[2a01:7e04:e001:f2:d784::7a8c]:8333",
+	"[2a01:8740:1:1a::d3b2]:8333",
+	"[2a01:8740:1:753::e5cb]:8333",
+	"[2a01:cb00:13fd:8500:660f:5a8e:4025:2124]:8333",
+	"[2a01:cb00:1428:ea00:56bf:64ff:fe1e:3403]:8333",
+	"[2a01:cb05:9475:8f00:8e59:8964:5ba:f8ca]:8333",
+	"[2a01:e0a:22f:f470:8aa2:9eff:fe4b:c705]:8333",
+	"[2a01:e0a:802:9d30:e5f2:6326:43d5:fea2]:8333",
+	"[2a01:e0a:9be:4620:67c:16ff:fec9:be6c]:8333",
+	"[2a01:e0a:9e9:c240:8e3a:af64:4f0:8f79]:8333",
+	"[2a01:e0a:a0f:60:bb:662a:e4d6:7ffb]:8333",
+	"[2a01:e0a:aa7:c8c0:34ba:6da1:af8:3928]:8333",
+	"[2a01:e0a:b9b:83e0:691d:12cb:2018:1787]:8333",
+	"[2a01:e0a:d:4400:6a1d:efff:fe59:bd9]:8333",
+	"[2a01:e0a:e6e:6bb0:2e0:4cff:fe68:232]:8333",
+	"[2a01:e0a:fa8:e880:be24:11ff:fe3e:3477]:8333",
+	"[2a01:e11:1007:d1a0:cf88:b2fa:a28:4229]:8333",
+	"[2a01:e11:5008:5c70:a0b4:2f46:683e:4333]:8333",
+	"[2a02:1210:2441:e500:bb72:9793:7fb0:ffb2]:8333",
+	"[2a02:1210:2e3f:7d00:e866:660e:735:339c]:8333",
+	"[2a02:1210:3e02:25c7:6e4b:90ff:fe32:be4c]:8333",
+	"[2a02:1210:4a25:2f00:b367:a9cd:3be5:f04c]:8333",
+	"[2a02:1210:60e0:800:8d6e:134d:a0ca:ef24]:8333",
+	"[2a02:121f:2539:0:8bc6:9959:48e8:f2bf]:8333",
+	"[2a02:13b8:f000:101::a]:8333",
+	"[2a02:168:2000:119:227c:14ff:fef4:853c]:8333",
+	"[2a02:168:2000:97::26]:8333",
+	"[2a02:168:21e5:febb:96de:80ff:fea3:fd00]:8333",
+	"[2a02:168:420b:a::20]:8333",
+	"[2a02:168:5d42:faac:bc:1:0:7]:8333",
+	"[2a02:168:b5cf:4::]:8333",
+	"[2a02:169:4a0e:c:3c5c:3dff:fe47:251e]:8333",
+	"[2a02:16a:c200::15d]:8333",
+	"[2a02:1748:dd5c:ef20:ee8e:b5ff:fe75:3f03]:8333",
+	"[2a02:17d0:533:1d00::110]:8333",
+	"[2a02:17d0:533:1d00::bd]:8333",
+	"[2a02:21b4:3cdb:3400:f03:9c55:3092:599c]:8333",
+	"[2a02:21b4:4a7f:c500:18fa:1f2:dbf:b87d]:8333",
+	"[2a02:21b4:a26a:6d00:ecbd:9d02:1bbf:9294]:8333",
+	"[2a02:21b4:b252:2400:9e4c:da28:5a43:151f]:8333",
+	"[2a02:220b:2000:f800:fb46:25d3:84c7:20c0]:8333",
+	"[2a02:2479:38:800::1]:8333",
+	"[2a02:2479:48:3500::1]:8333",
+	"[2a02:2780:9000:70::7]:8333",
+	"[2a02:2780:9000:70::f]:8333",
+	"[2a02:2780::e01a]:8333",
+	"[2a02:28e8:901:204::b1c:1]:8333",
+	"[2a02:29e0:1:420::64]:8333",
+	"[2a02:3102:a130:26e0:eaf3:1128:359f:5d2d]:8333",
+	"[2a02:390:9000:0:20b:eff:fe0f:ed]:8333",
+	"[2a02:768:f92b:db46:5e46:772b:71d:29b7]:8333",
+	"[2a02:7a01::91:228:45:130]:8333",
+	"[2a02:7b40:50d1:e77e::1]:8333",
+	"[2a02:8012:477e:1::e]:8333",
+	"[2a02:8070:783:b440:96c6:91ff:fe15:50f1]:8333",
+	"[2a02:8071:b683:2d60::ea51]:8333",
+	"[2a02:8108:8a8a:8a00:42:acff:fe10:6402]:8333",
+	"[2a02:810b:4782:9e00:4eb4:c874:7ee0:15f2]:8333",
+	"[2a02:810d:2402:900:6e1f:f7ff:fe75:d3fc]:8333",
+	"[2a02:810d:6d05:2600:4e52:62ff:fe19:346]:8333",
+	"[2a02:810d:6d05:2600:9d93:7b85:c346:b779]:8333",
+	"[2a02:810d:9f86:8500:fac:fa77:d7f2:452e]:8333",
+	"[2a02:8308:216:6f00:5cb7:ff7d:5f82:1817]:8333",
+	"[2a02:8308:216:6f00::b189]:8333",
+	"[2a02:8308:8188:5100:ab4b:4802:166:fa84]:8333",
+	"[2a02:8428:1ec:2101:75d1:7de4:c1da:943a]:8333",
+	"[2a02:842b:b480:8001:1621:64ab:b839:4c69]:8333",
+	"[2a02:a03f:d9df:fe00:9657:a5ff:fe63:720]:8333",
+	"[2a02:a313:21f8:8200:9473:870c:b2a1:b0ce]:8333",
+	"[2a02:a457:1a1b:f4::10:33]:8333",
+	"[2a02:a45a:94cd:f00d::1]:8333",
+	"[2a02:a45a:c7c7:5:3315:64ae:8518:db03]:8333",
+	"[2a02:a468:61f8:1::2]:8333",
+	"[2a02:a469:3eda:1:be24:11ff:feb0:604a]:8333",
+	"[2a02:a46e:2e2c:0:88c:5cb5:c26e:cf51]:8333",
+	"[2a02:a471:d884:2::40]:8333",
+	"[2a02:a474:2ccd:1:4a69:b7f:b885:4989]:8333",
+	"[2a02:c206:2172:2852::1]:8333",
+	"[2a02:c206:3012:8083::1]:8333",
+	"[2a02:c207:2247:5935::1]:8333",
+	"[2a02:c207:2264:4868::1]:8333",
+	"[2a02:c207:2280:2824::1]:8333",
+	"[2a02:c207:2286:2581::1]:8333",
+	"[2a02:c207:2299:2228::1]:8333",
+	"[2a02:c207:2304:8453::1]:8333",
+	"[2a02:c207:3002:8456::1]:8333",
+	"[2a02:c207:3016:7779::1]:8333",
+	"[2a02:c38:a5a9:700a::21]:8333",
+	"[2a02:cb43:4000::178]:8333",
+	"[2a02:ce0:3002:b801:7587:aab1:5551:d0b1]:8333",
+	"[2a02:e5e:1:10::27]:8333",
+	"[2a03:1ac0:2e92:e7bb:4fa4:3148:829e:ca00]:8333",
+	"[2a03:4000:2a:9f:a474:d5ff:feb2:3f72]:8333",
+	"[2a03:4000:4d:f1:b4a7:38ff:fe8e:fd75]:8333",
+	"[2a03:4000:5d:bd4:a8bf:78ff:fe98:7ea4]:8333",
+	"[2a03:4000:5f:cfc:14c3:eff:feb5:1c1a]:8333",
+	"[2a03:4000:6:56f3:480a:e9ff:fe36:e7bb]:8333",
+	"[2a03:4000:6b:c6:24e9:1dff:fefc:87d4]:8333",
+	"[2a03:b0c0:1:e0::1a17:b001]:8333",
+	"[2a03:b0c0:2:f0::1bed:9002]:8333",
+	"[2a03:b0c0:3:f0::2cc5:2001]:8333",
+	"[2a03:b0c0:3:f0::2cc5:3000]:8333",
+	"[2a03:cfc0:8000:13::c303:de42]:8333",
+	"[2a03:cfc0:8000:29::c122:d58c]:8333",
+	"[2a03:cfc0:8000:b::5fd6:3735]:8333",
+	"[2a03:ec0:0:928::701:701]:8333",
+	"[2a04:2180:dc03:2::13]:8333",
+	"[2a04:2180:dc03:ff01::254]:8333",
+	"[2a04:4880::702e]:8333",
+	"[2a04:ec81:100:3049::77]:8333",
+	"[2a05:3580:d101:3700::]:8333",
+	"[2a05:4cc0:0:321::2]:8333",
+	"[2a05:6d40:b94e:d100:230:48ff:fedf:1432]:8333",
+	"[2a05:d014:1419:8f00:96ab:91f0:7753:cc86]:8333",
+	"[2a05:d014:a55:4000:30c3:9d25:5fae:cd12]:8333",
+	"[2a05:d018:a75:6c00:2bd:7e5c:9f8c:508e]:8333",
+	"[2a05:d018:a75:6c00:b9c4:6bb:507c:6d25]:8333",
+	"[2a05:d018:a75:6c00:f8c4:a2c4:6ca3:8927]:8333",
+	"[2a05:d01c:672:9200:2a50:2110:f6aa:844]:8333",
+	"[2a05:d01c:672:9200:716c:153e:b9d1:9b17]:8333",
+	"[2a05:d01c:672:9200:86e8:46bf:f02:b295]:8333",
+	"[2a05:d01c:672:9200:de1d:3ee1:1154:377a]:8333",
+	"[2a06:e881:3408:2::2]:8333",
+	"[2a07:9a07:3::2:1]:8333",
+	"[2a09:2681:1001::23]:8333",
+	"[2a09:4c0:100:c121::5e83]:8333",
+	"[2a09:b280:fe01:3b::2]:8333",
+	"[2a0a:4cc0:101:3c7:8852:f6ff:fe76:624f]:8333",
+	"[2a0a:4cc0:1:1174:98f6:3cff:fe03:c19c]:8333",
+	"[2a0a:4cc0:80:311d:34ea:d0ff:fe4e:3876]:8333",
+	"[2a0a:51c4:5:d6ba::]:8333",
+	"[2a0a:8dc0:108a:b:0:2009:1:3]:8333",
+	"[2a0b:4880::b67a:f1ff:fe39:82c4]:8333",
+	"[2a0b:f4c0:c1:920e:b25a:daff:fe87:77b4]:8333",
+	"[2a0d:3344:1d2:9100:428d:5cff:fe5f:902d]:8333",
+	"[2a0d:3344:2383:aa00::9e24]:8333",
+	"[2a0d:8144:0:117::cafe]:8333",
+	"[2a0e:1d47:d28c:4100:96c6:91ff:fe1e:2064]:8333",
+	"[2a0e:8f02:21d1:144::101]:8333",
+	"[2a0e:b107:1ef0:2b49:dac9:c7ce:8243:12da]:8333",
+	"[2a0e:b107:1ef0:3fe5:ee54:8ddd:3b59:e6db]:8333",
+	"[2a0e:b107:1ef0:7438:a525:7965:6fcf:97cf]:8333",
+	"[2a0e:b107:1ef0:9004:c04c:d770:fa01:4816]:8333",
+	"[2a0e:b107:1ef0:938e:65fe:2c48:226b:57cd]:8333",
+	"[2a0e:b107:1ef0::404]:8333",
+	"[2a0e:b107:1ef0:aaef:2601:f579:f7ac:a5ba]:8333",
+	"[2a0e:b107:1ef0:bb25:e996:a173:a219:7955]:8333",
+	"[2a0e:b107:1ef0:cab:d7fb:f373:8a15:4a62]:8333",
+	"[2a0e:b107:1ef0:d5e4:ee8f:4974:937e:22c1]:8333",
+	"[2a0e:e701:103e::4]:8333",
+	"[2a0e:e701:1098:108::142]:8333",
+	"[2a0f:b780:300:1::2]:8333",
+	"[2a10:24c0:ad1b:ad1b:21b:21ff:febc:12f2]:8333",
+	"[2a10:3781:2c19::1]:8333",
+	"[2a10:3781:3fff::1]:8333",
+	"[2a10:8702:0:1c00::7c04]:8333",
+	"[2a10:9300:520:100::161]:8333",
+	"[2a10:e300:8888::2]:8333",
+	"[2a11:6100:0:12:2e60:cff:febc:13df]:8333",
+	"[2a11:6100:0:22:2e60:cff:febc:13df]:8333",
+	"[2a11:6100:0:23:ae1f:6bff:fed5:146a]:8333",
+	"[2a11:6100:0:5216::]:8333",
+	"[2a11:6c7:f04:241::1:3]:8333",
+	"[2a11:d540:531:b00b::5]:8333",
+	"[2a12:8e40:5668:e40c::1]:8333",
+	"[2a12:8e40:5668:e411::1]:8333",
+	"[2a12:8e40:5668:e415::1]:8333",
+	"[2a12:8e40:5668:e419::1]:8333",
+	"[2a12:8e40:5668:e41e::1]:8333",
+	"[2a12:8e40:5668:e421::1]:8333",
+	"[2a12:8e40:5668:e423::1]:8333",
+	"[2a12:8e40:5668:e42b::1]:8333",
+	"[2a12:8e40:5668:e42d::1]:8333",
+	"[2a12:8e40:5668:e42e::1]:8333",
+	"[2a12:bec4:1821:5a1:b0:2009:1:3]:8333",
+	"[2a13:4ac0:10:0:f816:3eff:fee0:4911]:8333",
+	"[2c0f:fb18:402:5::3]:8333",
+	"2boy2eupcrkymvf456swszxglxgckeoasshdasbgp4kt6jobovnmb5ad.onion:8333",
+	"2dsiqghzk2ky2morcscv7ivws5qy5ztqasxnpxchttqumhzrhpdxqpad.onion:8333",
+	"2ezowyspxovboz4vh6gapgrum33exbcchfkczkdgwbwuuilg4eg6dbad.onion:8333",
+	"2gmwpdjjjdjo4qnwtx3ywrxsxquwtlxr3okxq4l7mieym34qv4x27lqd.onion:8333",
+	"2j4blxfgc2ktgh3ygbd76xa2bbhy235m454tazag34pmf2w7m3bg3gyd.onion:8333",
+	"2mgi53r3olnwx7sgpfnb5xpkvyl3kayii2gvel4adfwaglkiwsgwamqd.onion:8333",
+	"2nvlyn6j3asbqdltlpgqesljr2flv66ze5ycnr3ew2krzd6vuevuujad.onion:8333",
+	"2pjgii7v5wcngvi73az3rddaaafvanqxnd5kdgpbuihh67jqraz7lbad.onion:8333",
+	"2wvjs3covcftgqzgeg5sg2ixpjmb45plqvoxjapuqgpccadwuddfguyd.onion:8333",
+	"2x2fa4k4gw6oday46g7oqnotkk5dy4lgxwc6zjzfxdei2rvpyfxwzdqd.onion:8333",
+	"32djhc6hjaff2ohueoytojgahm4f4acij7hmcrlyjeattar2ihz35uad.onion:8333",
+	"32ntxv5owu2ucahqrtdiwxww2j37cafr6zbhjd3s4wccazw37ngcshyd.onion:8333",
+	"3apokahxz2qqjaqy25mp3tltwnw234e2nd45sic6vfug7ohsxwrd3pyd.onion:8333",
+	"3cjide55n3vix3pdeuarnvim3se7ffnzvnx6mzp7d6tc7z27ink3guyd.onion:8333",
+	"3cu7ae4jil536wjsdqjdpet5vfdgocip4i6azaakxjpeiuhe5vqwsayd.onion:8333",
+	"3ddlxdzl4sbzqnxnuasuovtvevsmmxqlh6koamn2y4otoykuk2mdzuad.onion:8333",
+	"3m4nc6q5sg3bzo6bo4isymj23uq5rluqhu3hr4ycoc2wpe7foiuqdyad.onion:8333",
+	"3nkhe4uwqdfdas34okz4wmug4vmlkgxyobfiaib6fjzbaf56dl4q5oad.onion:8333",
+	"3nn5cux3znccj3mptt232w3fvcopltkqxt762xauau6p66nsqtxljeid.onion:8333",
+	"3pjhiqctqbv3rbmchxzjbtozi5sr67jp33oecxppsi3m2oz3veav6aid.onion:8333",
+	"3rx7ekxlz7win4wke62j5sk6pxft6w7aycazbfxhixgqvku5cbxc22id.onion:8333",
+	"3tlf7bheckixd2bolkxu575ch6cpheoa26pcnn3xxoq2bcyq764hriqd.onion:8333",
+	"3xjuckzzvmh6yewxnqggsohws77dqnhwmdrqdc6itz42tkb4o3tpdwqd.onion:8333",
+	"3z3zwvf4geonjor6w4ulv2243hpccai6u2iri4nn2tb2674y4myhf2qd.onion:8333",
+	"3zfzwwobxeuhmi7tnycakytn447vfzl6sjew3rmw3ufsvydmx5a5ywqd.onion:8333",
+	"44h55qoaxb2g3b2sml6zclwrlj6cf5fmyem7z6kkdz4vkfp4ki64ahyd.onion:8333",
+	"457aw7llsegwsgbyywljxgd6fjcbv7efa37bh3wcl3stzpf7w5mr4oqd.onion:8333",
+	"462llr6zevwmvkuhsercdigxu7gy3rt6hhcjij73a5nbaxmwq4z5cpyd.onion:8333",
+	"46cl6zhwzsjxstkxrljpavd6f6xlomyeitoeax5tj32p2voaxgax5uqd.onion:8333",
+	"46mw7goeegqz27oqp73vnrh7zd445de3lfjahcsw4uoyrehpnqcmy4qd.onion:8333",
+	"47kckjm73uicp26v66oqrkkk5sned2cxwwjm3snqaayuzvfowoqm6pad.onion:8333",
+	"4dsrmaoawijnhnodqbpqpgfhhbnyl66qsesg6egyluijxkdcrplcfpyd.onion:8333",
+	"4ihjwclm44ggwsoe62fx2brb3fwfc5c3l4kcnjukv6hvlicjackhpmid.onion:8333",
+	"4jg7uabqh3zsprvx7zubkilbjylllcifvnlmnqeqxbticupx5lyzduqd.onion:8333",
+	"4jv5umxak6owxgmdeuhtp5776kfzr5iw6liqjbiabtvy3fjs7ifoxhad.onion:8333",
+	"4l6ltv7hlx5bixzuk5r53bscyu5osg53fzc5m7bw7qy3cdn7rncem7yd.onion:8333",
+	"4lskdkybekr645vw7t3qs5flxgifzmek22u6pkmfh5n5kait4i25oxad.onion:8333",
+	"4p4oh3mck6h5cq2vn7xr6ajmmv7gvn4upzximwxphbvx275ycvkvmnqd.onion:8333",
+	"4qdpwxqzmiq34vcnpf7ub3yj57k4kmfddgsxyiglejhu7endissjajyd.onion:8333",
+	"4r6ii7ldxktsq7m4zat5vlv5e44p7iuom5ateelp3ycuulhayyoqwnyd.onion:8333",
+	"4rw4wtyzql7l4ifnvae3jgxza3pfg5izae6olk5jrgyh77cmgsljjiyd.onion:8333",
+	"4rzkf4dumgzke5n5sb57n5wv7r7did37vf2umix6ez2bfrb4e7vxn2id.onion:8333",
+	"4u7flld3tc2v65x2lzlbmgorsmfbo4o7yts7ix34lfd6wigzu5zfk2yd.onion:8333",
+	"4x4ngqy6bdokv7cqudnyn65wh6byhre6dfqejrzzxrimsp26bpsmugad.onion:8333",
+	"5b4aks6jl5if43ohd7cgs434klmd2phl33hsu6qlor6u2ogpqizhppqd.onion:8333",
+	"5bdljec46m5f32qh7gbkjh3jkddpxtyt3xi7mvhixo3n3ycxveiyl3id.onion:8333",
+	"5cil4amtqyxfuygz2nwt33gnk4ism2k4743bizsat6l7vafyzs4luzid.onion:8333",
+	"5cklvqlpxv34okt7wpzzcfiggav44ufr7evm6c3w66pgrkiebd2lljid.onion:8333",
+	"5d534rqy44s7u3pxnovul64yzrdwf37jatcycz6umpbougi2nn2tbgid.onion:8333",
+	"5eljjrsf2jkopxy6qsf5i3ivuenawgkruzaatlh46zzfsyswmg223pad.onion:8333",
+	"5gzmx4yib6mhfn6l5rnicrp3oonz23eemg3wjdwdpwpkhezgksy3fsyd.onion:8333",
+	"5hkf4ukqzuip5hs55t3jmpkaezzbc4dp3nhvzqhweaek5ejgby72dvyd.onion:8333",
+	"5hp3zpbkk5ap4nxqurtttq6acn2ps5fxiwmwfl6by2ebfg4rq3qeiwqd.onion:8333",
+	"5itx3dz3imameqbt55kw3ji4yl2p7rftyr3te2wymy6q6wyafiy3wpad.onion:8333",
+	"5js3fh2jlkecpk2ejrildigccup7zcjjcdtcjdu7ipdtyzvpqtaic7yd.onion:8333",
+	"5jw5x32jypyivibetbie6lkmxshoduz46auota3w65qptpthsdg7apyd.onion:8333",
+	"5lnzkfqlq56mpmxkstef3xj26qgaa7bu4bzvfs5rfwgrvr764bfgmxqd.onion:8333",
+	"5pafrmmxjuzlltsdl453rkhqjvga3skdppblvyhpnggd3lc4ojczftqd.onion:8333",
+	"5pewkpzkmxobeese64uinpvwnlcitokutllxaii6jptpqqkuelvswtad.onion:8333",
+	"5pjnfvwjawegbdffowe3estdhcbyo6dhtepw7vzc4eq7bxz7tuo2yjid.onion:8333",
+	"5sjrwvize5fq3zchdmgvow5wtmyeuqvwdxenorbjhe2hgi6wqypxyeqd.onion:8333",
+	"5uds67fzzk2q26erca6d6giyy4uzwj4un6nrk5nj3s4a3krau3cpulyd.onion:8333",
+	"5vfpa5yp4srxlyggcjslanup3dkav4rgj4pzotwlbhmbhdd7scidouad.onion:8333",
+	"5yo77jrh5ga2kmajudwlnqoeepi635v5w37fwvsrlbxqwfjoz2nikdyd.onion:8333",
+	"5yqo4k3ilzbvygfzspslh3sye3d3h27ewbd736oj3qssoe3jgq7jrgqd.onion:8333",
+	"5z56m27m73te57egak7ulmgcw6b22vryp6eawlx5lxghx5pto2lccxyd.onion:8333",
+	"626eqcvi54ub23ozotpmitpec622jdxsanso3hnp2lgovpnze4wmmjad.onion:8333",
+	"64tstelomwbus6why75tirum55omgo6offj7ob4tm5q7iy6sosocbzqd.onion:8333",
+	"67437r33peui7m2yvy66kmjye2b4lig5cz6okjpbxzbtklng6ba232id.onion:8333",
+	"6bnzwwysz3jfohn4jw662foxfi4w6as6b6xryumbybdf6tu5itlupdqd.onion:8333",
+	"6bxesbbbermwgekl4wvfrlzsoix2jdvepnkhswowbtn2x7ybnj5ftpad.onion:8333",
+	"6c4zr2uzyubml6dcyrr76fptd366oajrh4uezksbbs36x52szx4lknyd.onion:8333",
+	"6cs5iwgbjmvjdgk6xh2w5q2ixeo4ab5ivtogiprxzyzcpzit4jxldmad.onion:8333",
+	"6k6u76bbwydnifeze6m5nzp5dotcqgfhrb6o2mel2diroh7lcfl56nqd.onion:8333",
+	"6mizuil345niebm3pqnj3klwxybr3unkhjj2mhmlon32mwwc65ugtdqd.onion:8333",
+	"6qomhekjnbg4shzqni5fqw4jhgosfv4mm4ek5775qxwtwev3jki3j6ad.onion:8333",
+	"6raaayakwmt7cbj2lzi3lrhcuk7iqfyypso4uznqlft6xv7yoywvi4id.onion:8333",
+	"6wf7jdpvlwmyv4l7q6stclslny4gcrtxknkwzml2rwnaepll3mmee3ad.onion:8333",
+	"6xg54mskqvimgcrxy5sjdkitr45di55wwrvxuqhwualzztzzx52shyqd.onion:8333",
+	"6xxntj2njzgfcow3z3puyhcsl7ewe4lwgtqndso5djnd5jp7ivrvhoad.onion:8333",
+	"74m3lpfqc55v2yrjbtgldt3jdrg4gdelhiaxe2ijaqypjk2l5gtnrvid.onion:8333",
+	"75kojazxezckxt7m5muiuhkipb6ujwku6jnyuuhtyustq7vhk46owbyd.onion:8333",
+	"76krttlvay5df3c7l7qdfnoequwfxbnm7pd6t54abqosf72tadziypqd.onion:8333",
+	"772ppgjejbnafixx447gk5c6zkmw3d26djq6i74ig2loooz47s3e3qqd.onion:8333",
+	"7bbvgxjmpxelh2ie6dfoe5t7kndkakrsghbiehzr4j2prf2ol57lanad.onion:8333",
+	"7fpt5pv6ywtkdoakyoktyqdnqlycjlpkjwsz4nposnng5ndg25hwr6qd.onion:8333",
+	"7hiujjuj4ddd3os6xsnlzhp2ke2ahdn77ryi2zeh2vt7peajnu6d6ead.onion:8333",
+	"7ki2ehd6v5zc74qiwitz6t732k4hicqrpxrmbdyd56qqk3q6x2krpfqd.onion:8333",
+	"7my4zha7yelptot6eopjsptu555wwv3g5tbhkvuizbavp33yeg75n6qd.onion:8333",
+	"7nckc3ozq2l4xaoxczf4aywd36k3vcdvl6ia6affjwn35qjw5ziqwxad.onion:8333",
+	"7nr5m27uq5dtswoaijeg4abx3exhb6fgcvpugojlzl2q3i4lofnrjxad.onion:8333",
+	"7pnf7vvtwmet5bxgysb7pw6zsih7slou7nek6nb6ws22adopnavchaqd.onion:8333",
+	"7tmxr54wpqarv2rls26ypwutozkmjrz4jv52uh7v4j5htgd7vn3c5syd.onion:8333",
+	"7wl4hcvrqmd6zd6477k4phjx4kxos5ua7lnnysvb6hdcwwqintearoyd.onion:8333",
+	"7wt2hx3f3ci7uwn6w4csly6cv7l7qfm45b37fw2mv7qfrykdcy4vuwid.onion:8333",
+	"7xsmz5bxncrqgnjrt7ut3r5ev7b3fnm2hqzgu3v4xuxw75trshzydmqd.onion:8333",
+	"a2awwh5yhapt7xlxti5jaycsntfcy6d5pi4hidqbaw5eyzncfyr6ljid.onion:8333",
+	"a7svwb2hcf6d7kanw7trh5igye723lj7eov4jfzvet3x2mayb6uyqiqd.onion:8333",
+	"agfkbmll6xn7ue5pkfpf6jycrvojpgc6d7ibj42fn44on634ec6igtyd.onion:8333",
+	"ahzqqu3ayyyfb4jgagptqaas4xlwgggpnd6gafoh34ldnlpod6g62rqd.onion:8333",
+	"alpomv43bt4yb4i63wkajueoafvjgruetfcq6emnzfmda3c4etv2gsad.onion:8333",
+	"anbusqwtbceto5qzvqgfgtykl6tj2o427bmkbgxblsedldqxudvqfrad.onion:8333",
+	"apmia3dbg4z5kfl55g2o2iipejbbtkrw2ibr6ujdljczcubnqq3j6pqd.onion:8333",
+	"arfsx4d3iv7wlmmyhnklnbux4qzdyamet224mctdheeezoai5qtkt5qd.onion:8333",
+	"ariwxra7aqzbb5fyashiu6a77nwgp2btvmwv3fb6a4kmfgpll2qcvtyd.onion:8333",
+	"axffjoqk67r47sip4o2mqqamoo3hpmx2kngjvgeefj4fxtg7curjp4yd.onion:8333",
+	"ayqofpmapnj2yyslsq2cqlzriaune7fzpnwpzb3ck2ucods7r5egjcyd.onion:8333",
+	"ba3f62tbkloevbkfgcvitar5rut2cbpkdvct5caa23fghossiiff77qd.onion:8333",
+	"bau2yypxjg7o7sbuhjxlb2p75abaaix6i2pswneysf265oii732u3aqd.onion:8333",
+	"bbikxmdchs4myc7ersqv6wzdeenkyhdw27hlsvpdigvrmqmr4m3dl6qd.onion:8333",
+	"beizroquax6rfyfqdtd3e4ar5ye6x3veqob5zl6xgr3bkzc6y2qv3yid.onion:8333",
+	"bghbyeffnpyf3uggmx7xdxf3jgiu76665apd77yzsbdzggsuyhngbuyd.onion:8333",
+	"bol4eqjp4s4q4yxavoejrcdbim7hd2nkah7kpipqhsa5do6bt2cvauad.onion:8333",
+	"bongbex6uh3ney2b6ou6gfr4xx26jnpe6erxa5gpztheooxek2d44hid.onion:8333",
+	"boukdszq5d326ljzurzaugxq5kgjrly3avxke6wq65omwdpkptwpuead.onion:8333",
+	"bt5xf6afgdjh7hyeb23jcnwoipz2s7dartjzcrxvlmxujbddvbrv4fid.onion:8333",
+	"btps5f4lyq2c3p6wu3extidykon5cfsdefn53r3pape4kmqjwxpvuyad.onion:8333",
+	"bznzv7mx7b3ds36obzho43gjghtfcaf6cya2vmk3dy2apmeoxqm3y2ad.onion:8333",
+	"c3ecwcjyb3hn3gp2x4csecrf53dnqh6lm2omjbmdy5q2kruivliplxyd.onion:8333",
+	"cavyt3fa4xxdssk6serhv434swc4i7ha73yuhikc6ptf7simynk7k7ad.onion:8333",
+	"cfee34r6sb4qrisyo7t44uozjvyfajocq6atl7htdarn3tgjxl635jqd.onion:8333",
+	"ch6nf2fes464vrjsxirsdzvjlz37pq7iy6t7sqrc3mpqrehb7asmamyd.onion:8333",
+	"ch7qv2xvg657bz3wvscm6vo7l6tybgtpguybpm2r4zksgterqt254uqd.onion:8333",
+	"ck6qjpowjsw2rsth6ysgsd3oddatqksow77drrpt6lqfkowfhlxepiid.onion:8333",
+	"ckqkcv3dk74rciwpxaogjeasmbol7pgsi6b4meznkwdzc6d2auym3nqd.onion:8333",
+	"cktihqaqvv26gyxg6srit344me75uj63glpa6himhbyq3o6roljwalid.onion:8333",
+	"coxlgfjherkt6w5f6h6z642epurvqnv6vsw2bm4gdrg6gtmcle24ztqd.onion:8333",
+	"cpl4q4x6jyzhznhghsm754gfjtnqi5sf54hr2iobjcqb4fpocfjhnzid.onion:8333",
+	"cs24aeobszprs3ceb6epx23ptna2otehv2pwufuuikeihmfygvwuekad.onion:8333",
+	"cu3tz7rk75faa7wsagbefssdaeigehcfsbexnijfnxjvo7pscrz477ad.onion:8333",
+	"cukvrfmgszl6pscxikrrtreajmi5uyya732kxzavlnkz5r5qwrrcv4ad.onion:8333",
+	"cvihqgbyzphlpoqggrmiwc3mwyqihnznd3i3rqnhcxnqawgc4l4nt7yd.onion:8333",
+	"cxek3kkpiavinzq6odanzf5jieqkhnk4t3p5zzhu4aw67nhpqqrmmvqd.onion:8333",
+	"d73r5lnznhwk3e7xbopx3ic64zwyvmzqyted7kt5isiqtootqqzv7nid.onion:8333",
+	"d7fu3exepygdmla24v2q4abdmc2bvsifz67ayagcw2hjcd7qscvqdhid.onion:8333",
+	"d7icsp7naafsooy3jdalxqeqypfow674hzmt33z3zcvr7m6ohynbinyd.onion:8333",
+	"dbuaf3m52hk6xdgz2qawnqbaiox6ox2ravht3z6xi32vdyrezh57byqd.onion:8333",
+	"dczos4uwxk4zrnqzghekfwwfn2xoobjf46zi6tscyjisef5sqvvdzcad.onion:8333",
+	"dfm5ov3kmcc542ynj6dmvhoekqnjpbyt34auun65m34fampx5ckzwhid.onion:8333",
+	"dg7eht7volybfe5ksl7lg2drho3lf7fa3p66s2ivr2xida3fucc6urid.onion:8333",
+	"dgoszpsh2ou6z75js5va3rdxiwp3jpen7rjhdvhjw33uzs3m5hxw3rqd.onion:8333",
+	"di2vurmytyvaa223vkoeekj2rkctiuufkgrwkzf7pwnulqfie7r32eyd.onion:8333",
+	"dmk4rgpstuqevebihlb2rnlb656a75iyn3eokrews4ltax6thdhftkqd.onion:8333",
+	"dougxotkmhnt25zoyfpq6lusppbmhkasjsus4obtvplcxbkudubjivqd.onion:8333",
+	"dpy25u4p7novodssfwlzjtsrfsinjgr5cqnupjrhe4hbubxa4cewl4yd.onion:8333",
+	"dxtynfs7ez367vemphccgumy3d3ke75aim4zuv56ytry7mcnbfvrcnqd.onion:8333",
+	"dzkmeec3jqmp6l2h273hwvidd6lnliqkyxnxfzdnwke4qppy2oxilpid.onion:8333",
+	"e5sryfqyxhqi47cgtxqvjascnoftsjgxc6xcadrybkj6fhm7sfo5p5qd.onion:8333",
+	"e7avkcrr5wyd3jb2pruprjujsdyoi6otdcwzpw4b3m2n4nyjlcjbahyd.onion:8333",
+	"e7fyehgar6u7n44vltmvymeqbazuzswmm4fdp6xaa2fel35itifeehad.onion:8333",
+	"ecpvzqvyxqkozpovnhgfp74wdcqjaugbmjvoos35zuox2uzx6mtirqad.onion:8333",
+	"edsiwje7qkaqzunrnhx7oalcublzn754t76lph62rtn5qev25ktuzhad.onion:8333",
+	"eecusjy3xshaynplq5gsep6s4lufwtvavke5ua6wtbrsyvklkdeu3oid.onion:8333",
+	"ehdxwh4z6gx3kmzuo5s2okdip6mjdzjkjsmnlyf5oxaslnrsvumus4ad.onion:8333",
+	"ehqddddsjqwj4nsk6na63plz6ak4svkch5aep5ujbruc2ou6iajq43id.onion:8333",
+	"ehzydkyedksjznbnv5byb45zyc7kl2bzp4tpymyb6xdatdk5c7wldlqd.onion:8333",
+	"elba25chv5x6nolo5332ua2xkkb5d34prt6tmtl23vljc76wgikh2xqd.onion:8333",
+	"eohmgcepxqe5segzfdsgsdtvbdgvgc6od7fbidcnqas4uppjf3i3wrqd.onion:8333",
+	"eqlgno2eibpnsk7lajkint7ft4ol43fxqiadtlpvpdav6x76oxlb3aad.onion:8333",
+	"esbhnxhmsmaul6ecnvd6kvndba3t2fl6mq5npxmfhpfcce4ls5kmread.onion:8333",
+	"etircbgqsobng3ik6ayi6imfz445zjxccoieeccaxdmjqzh5zrx263yd.onion:8333",
+	"ewjifm34r26yn33ieoomkx4fnv4vrig5wf7ma2ffummjau2dy324xmqd.onion:8333",
+	"ewkpliodenpsxrnsvcshtinesvkgvfesiyxyuk5sulcboy7syi72pgyd.onion:8333",
+	"exhnj33rwfict377cprss3n443febg6kpcs3hi5t4wctulfpkpi4boqd.onion:8333",
+	"eyb4qupdhna4ml7ang4gr26ygumckjypyztxfyofqkyhcdbku23u4fad.onion:8333",
+	"eygz4x4vgnjbsnwailoupv4ziv255tubwr5wv7u4hhpntdgevbjpx7id.onion:8333",
+	"f2ze2jehhnfw7iuz4ceysvghpbmdewgvutfvkvwbwnsjaynxirjlfeyd.onion:8333",
+	"f7sr74hkpd6h5jeu4cz7kqol4yscpinxwbmoelurdz4xxh5nu5oy2jid.onion:8333",
+	"fbbbf5i2hhkn4h6meyrrsod57sufmw5a4oj66yndstjnmxg3c3cwafyd.onion:8333",
+	"fd7xarhngf2kyrpu7byt6pjyf5ycmshwq7ed7z3pxwfwp65wb6alpjad.onion:8333",
+	"fk7s4uml2z5pvxgoorkkgcjw73lqztkj7dkled2dsncuzkhvqzsjcayd.onion:8333",
+	"fwbe3wh5uvfheqcphuuc4m23pb4ezdk753trsniudljkgflqnymaxsqd.onion:8333",
+	"fye7opjruaj46u5pgtsb5jg2o4va3l2dmtsjfymph3qjsbenjvoqfqqd.onion:8333",
+	"g32e3acmzyqekmmfdsnqspspjeyw2uhdmmjpqkydjsslqkeipfnqntad.onion:8333",
+	"g4mdqyy5kvkvfnfbwouuw3rnhw75hx3ragqizkat43nlpmc5wsllfoad.onion:8333",
+	"g5qokl7sdsetkms7cb2xmhedcpihgio353izkcx7f5u3rsqlewougoid.onion:8333",
+	"g5y27lkcxlouggftx2nqwg3mipmltgzktyldmqnqbkdzdskwenq3qxad.onion:8333",
+	"g6sezn4p3rs4yqvrkvoiya6rcvktrnhfsm6hls3crgl5eo6l67gufnad.onion:8333",
+	"gafbbgj2monnqzzcf5ewimr3mvpjfxzbk7rzvxhllrlospp4wfygn6id.onion:8333",
+	"gcjowek6dff3vgdjntnmxbzrcy6mkfhcjspyp5wgcpjvxlguybbqfmqd.onion:8333",
+	"gfabu5lmolowa6zdniqjubop4dumc7gxal7asx2ndjw5tfusex33qbqd.onion:8333",
+	"giq6wurcsdz5rof5kwjgxqtgu7cgby7lklndlyqpksj44i2trpsb3hyd.onion:8333",
+	"gmc2dhkjxmlibkr2ka2hcqy7xfbnccfu4nnvlolq3m4kycwyjoikm5yd.onion:8333",
+	"gn6v23ve2sl6ip7ib34hbqnx5y7avnwngjrgnsezokf2ljmzqidblcqd.onion:8333",
+	"gnemhi5n7w24jucssi22bsl75wclwnprsf7edhl2tx4nu4yq5b4a4cqd.onion:8333",
+	"go43pbl24l3nzssgvet4bsowel54ksbzo3q3asn7xrp7ftdui6ewceyd.onion:8333",
+	"gpqxot7vn3uetsraspfhsx3gpeorn5rkb25hk6uscdu3rqswko7vawid.onion:8333",
+	"gpsbtitkoowumz4vsvf7yqjqqf7f5vsbwjcq6scexcmcwy3h765bg4id.onion:8333",
+	"gqzuercqjpx7kocl3o7bmq2lg7bvfk2qwpr65x57nuprsvunyhfjzoyd.onion:8333",
+	"grnvh2yibpadvrf4ej7diezox3yhx7torb2qlwu57ld3qjnnfig5ucqd.onion:8333",
+	"gydqmwdrz2ly3uxkjbjrjkr2336zrd52irqh5tadif45s25riunsk7id.onion:8333",
+	"h4zjm2glrwe4r57t6rkhp3rpzecsvmhyqbd25shtq73smc3roaasyfqd.onion:8333",
+	"h6biucjste2pqqexaotpox2ortlc66kxqvs2uh6zjzxcsakd2f7dngqd.onion:8333",
+	"h6dlvkoatzr3brbn6xizmus4wlkevd6wwn4lcw3533cniaym4msyd2yd.onion:8333",
+	"h6exit3ac3ttm5dvqaxdlel4jbu3nnzs3aczrt7ybyes7bz7iesl36qd.onion:8333",
+	"ha5jjdxx5ugmsi35ugkxjeogpksslao56bky7fp24xztlv2aoqhbynid.onion:8333",
+	"hagusxwbl7filysoungruheemxctqpapeotbsvf56vn2n26z5butkvad.onion:8333",
+	"hbxqnq7y2bfb46dvkaedgcgb5qha2omsljmsrfogl6bu4sffydnxomqd.onion:8333",
+	"hgdyatewzn635qcb5x7wun7j7rtlplxactibeg5ht5xhl4wimem35zid.onion:8333",
+	"hk5eodjrlioujovfin5hwgfyjrqrxjfcwcp2otj67cq3wj7vym4ryqad.onion:8333",
+	"hlz5fdtprwrvgpati4ximhwtvg2jaksayx5cbgwevcgv56fa5eecd6id.onion:8333",
+	"hqbjh5b4dsiugmu6hdzyaamatwavyvi6y3ax7rybzd3od764byrgpbid.onion:8333",
+	"huuoatpe5raaz3yhzzfkeu56yzizoncrny6o6ovdbtid4twkcvimvqad.onion:8333",
+	"hwydtfysaq2dhi7n7gd7nmuweg3v62vzo4xnekaxeottoeyp6r72ayad.onion:8333",
+	"hxdiznfsgs6rwoxvkoj5np7xqkea3cyx4sueg3veeqqq7devwbpz34yd.onion:8333",
+	"hyoyy5milcxhkbg5oknkvjx3nawvk4km7bfe2mctd2gjivoadqog2cqd.onion:8333",
+	"hyupotzq56wkrduqsp6eemccle44fg6xr5uapcbkajrd3yvhqcwimpyd.onion:8333",
+	"hzfv3tdua3oaisgbbuc64usyxlab2iypn26y6m3wkqarba2whdf7dmqd.onion:8333",
+	"i3y5htyphpjdowyhsqfojh5kw3zu6lifeivdymtqyrmrlncwwsa4miid.onion:8333",
+	"i3zkwfuni7rmfugnxisnxqtsm4j4vuwubuebmpaaqsmrr6uq52u7vdid.onion:8333",
+	"i77oyn467wt5ixiqk7pn7avinz3vkpugvs7q2e4ez677eeujxw6hn7yd.onion:8333",
+	"i7ijqmqbqdxt46ogdxpodzmf72qejfevvzyktuoef46qx5kghmtas3yd.onion:8333",
+	"ipdlxljb7gwu7szw6fr5zhs7suog2zrbjabq7chzg3snvqo6zcpo4jqd.onion:8333",
+	"j36lczl4botxtpugdsdhac3xm4a3h5gedoaykntjuc5sgfrptxeku2ad.onion:8333",
+	"j3evn6bkkm74f7inrvqtjuxo47ia62hrfsnjbyhazjpaz7q3hzosurqd.onion:8333",
+	"j4vztiwjrhwgquway7ub4az3buo7iyou3avz6j7ivn2g3ltliqdydeyd.onion:8333",
+	"jagbyunxfoqfdyppvvrhhasmohj2xfl5faanbz2trwexab4woyh6qbyd.onion:8333",
+	"jbktugcozicp4rbdo73imctccarghvb4ag4zsmsekqgcfpakbmh3inad.onion:8333",
+	"jkhavrdwfkvqxa4tgyncsoupfj7upwdxj2hmijkpwg75j66g7wcbplad.onion:8333",
+	"jks32aib2ebuzpze6slsrlqwj2nektd3a64tf67xngmvkdtu3rvklryd.onion:8333",
+	"jltlsk6mvvwyk27cuwfzvbtocgg2hd6jzg5kuknnvrnsokpw76s5nvad.onion:8333",
+	"jo2fqjrneozkj77gv6xpfhycxk7eiv7v3q7m2c7vyuawgbshx5vhk2id.onion:8333",
+	"joxvy2f32wt2vttfv3l2euavvf6m5e5zpno4zrsokgpahb6zovitlrid.onion:8333",
+	"jrbnukzzpexnxm4mpdbjaqgrh26pl65brqe25adac5zwubuvfss4ilyd.onion:8333",
+	"jtkcue6fq52tmfhavyggne3cuvxl4q5m4frwb3uahzk6sebnumvlnsyd.onion:8333",
+	"juap42d47h4al53zsyysavhtgypeuya7myedsgq7evma7s623vsgt4ad.onion:8333",
+	"jwoplqt3mvi7uszbt5ycnh2wk6j6e3vowrat7fmwbbn24agwovzqhuqd.onion:8333",
+	"k2r5fkg2qwauj24kolxwouy6b5mofl35ndbtcfque2x6eu65ouajkead.onion:8333",
+	"k2r6eb2mkirndedynwqvyreqwxiu3alvllxthci4zrzvhcb37czukfid.onion:8333",
+	"k3h4nteddyebq2jmmzhg7zytpzqoiyrjmfdrmc6wqgnmkhdmwzrjefid.onion:8333",
+	"k5kfc5gyk4f73viepfmjskjgazmajeerf5uu3jyyvnd5ol7fim47mkad.onion:8333",
+	"k7vu427xrzmnmq73hbibveugbmz6o6gvdbw35gsb24iqsvr5zc5ohhqd.onion:8333",
+	"k7yqmhtv7lok3ksora5ljr5jvzqlhem3biw45kmrrcq3tsh2mxpjkfid.onion:8333",
+	"kdhjyaqu5cgrlw2hdhc2a4rjzn4pukqvtmy66al3u7ml6t33kb5ti5id.onion:8333",
+	"ke736kvql5jfpc5kxeaepvnu7ysjuyslrsabbypx5pygvmugewuo4pqd.onion:8333",
+	"kgkf3rvtgj7cszqwidqtrgqjdclvdbn76p7ecaq2rcolcjda4tlp7myd.onion:8333",
+	"kguf3xww6c7uiliqtjm4lzv2ftsxcmiauvnsht6zejb5xphexgdcvjyd.onion:8333",
+	"khuh2s7s3e3knlkaljmd45du3oty7rtvw4yh3x7z5zf7i63tslivc7ad.onion:8333",
+	"kjpqkad4xipxstem23ub4om4q4dbtp5sku23qn25mh6rzr7iz3czneid.onion:8333",
+	"klhz4njqsy6dob7vu473nvquzvaupzng7mttgyxz4l2evuxzpavlq4yd.onion:8333",
+	"kmas6z5dnwg6vs4czqm3o7d3lcs6ihqiamxfue5irch72d25yjbgjead.onion:8333",
+	"knntez2mbvhbfkggyuvflcievj5hph3dmdketycqph6bg7t262m7wqad.onion:8333",
+	"kqpzmdinw4phvqv5i7kziirbowjcf7i55lxshy7sb5hdatmxu2invqyd.onion:8333",
+	"ksa6h2dlwrzyzh6wkdcpzfajw5eha22g6r5enccfwm6vpdl54yq4b6yd.onion:8333",
+	"ku5vzo4wyprye64cdsqxegygvquhxu6glzk6mbuqhiilgpe7xzlv2iad.onion:8333",
+	"kv5y4ktqw74itis4bevim34fjbgxjbzd4qyr6e65owklefeni6wp5sid.onion:8333",
+	"kxnhif22pf67yjk2czls3gi475cdiiviieecl2tu4qx5mxbgeyfyfhqd.onion:8333",
+	"ky33floqulhlauxpr764vux3t5voypkvplogieio6vwynac7yikkanad.onion:8333",
+	"kysylelrnyhcutqx3f6dgqgajvol5xvsmarudburgylg5typn2mejjad.onion:8333",
+	"l3l5xcswgzo2djpnu6bw6ko5zpafanhkiykgbx5uug227osswjsscvyd.onion:8333",
+	"l5abit5rjjwrptkr7aqzxsxnplbsdwtlro3hrocyt7es4mbufrrpfqad.onion:8333",
+	"l5b75umcnrjo2q42emfkwkgsb7maee3woou2nz26sqw57vxaczi6vvid.onion:8333",
+	"l7uwwsslspc45e76y7ibi5sczyrbf3vcvlpmej6reb3a7mjqfo5pnhad.onion:8333",
+	"lajt6ajyfy6r4isiwdyjk3l7gtrh5zwekgvy6xlmikmzfu6okyuhgoad.onion:8333",
+	"laxugf7afh5mgcdrquqbh6hljrlpt4ibsuantzvb22ek2p3jzd5gaaqd.onion:8333",
+	"lbrhapho32qxm6el4yctogz55ogllo4kprwidosl3txkvkrgncnzqzqd.onion:8333",
+	"lce6pre6ead7boqlapnmtvyhiaqezs5ljdckocxj5frfypeymvkewsid.onion:8333",
+	"lec7f3iaa6phcjgtxwdcelmnkoc3xgyfiiui2ytcjd5zd3o2gjb53pqd.onion:8333",
+	"levtmzreamem7gfqxsosqu4mhm2yqqgsmkw6iafosecgisnsb3cn7dqd.onion:8333",
+	"ljjuc2cvq52of4uyqh7qa6cjkzawd4oev42ffrtwd7sfhcjbtu4vsead.onion:8333",
+	"llbxif37camn6hytdgasnb4tqgubtewc7m4docg6ii3wnwszem5basyd.onion:8333",
+	"llkdqvcyjyz55pahw44yf2moeizafzfdgbnc467hc4ipbomnuobqyaqd.onion:8333",
+	"llkhym35nhoipr2fizqs6lbcfdrhxtt5j4qu6eby5rxgkhsu3hclbjad.onion:8333",
+	"lnrqppct47krkt66u3zvbyk4dko3aqmynqm6x57jbr3iumysggaebkqd.onion:8333",
+	"lnvkvmchaqybaiwaynhlfs3btjdn6xozpfvs2jsm77fkmnlh42memtad.onion:8333",
+	"lqgx4iwy4lwwxh75i6rbmt3fsvzpb32dwipuks2qfdpdsmq6wars2zyd.onion:8333",
+	"lsxa3sgjdufl6aa3zhlhtkzizdl3ti54cf7f46ojlwv6drizl3w4phid.onion:8333",
+	"ltv5hftwnndpu43difhstu3z73x32imciz2wdtdqsu5jminr72d32jqd.onion:8333",
+	"lvl4u4olowvqni7exa23ourvsvaifsjbsinqm567652cwq3gf4nxcbad.onion:8333",
+	"lwa5jhnrhaswseiert3jrhcu6at6rs4dj3rgvbfvcv7yy7tnv73rerqd.onion:8333",
+	"lxzqic4wgr4qng6s2khqfg252wgqqvsprtbhao6b46vrhtdvnrdsvtad.onion:8333",
+	"m2u336eq4vj3qay27r4yvxbcgb2bejoxgzhvydoyfvovxilvhx5fxbyd.onion:8333",
+	"m6ejvre26e7p4y7nbfoqv5figzhlqcyl7jy3h5wohcb3nfxkst62gzid.onion:8333",
+	"magfqimrwqsx7jd5mk5hjur7w4i46td666ktniz3k7yaq2ra4pgxhmyd.onion:8333",
+	"meljdy72puslmjsnedbdlxsuo26bgq4ul6rr5f43o7xaynmss23or7ad.onion:8333",
+	"mesayrnr3op5nlcutcbm7jsfzlcopkglyhu3iwzji3cnpius7xs35vad.onion:8333",
+	"mif2u6cilguf3pmlvu7lkvoibbnrh6ljlhe4y7yh6sddudtwhkuv3tad.onion:8333",
+	"mjewng7av225buelmcsknvm2jffvv3llf3xxdeb2i7fccol7kgofnwad.onion:8333",
+	"mkfmh62v62gfi4l6oftobhi2uyyrrmw3niobtfrkcp2butsrzsdz2syd.onion:8333",
+	"mmbvunth45ogu52fgwoho5nsjkdq3vpb7hlbcpyqt7piyixj7iurgyqd.onion:8333",
+	"mrvsop5g43nmrxlf4a4nqrndftexragl74jt3mw5l3vrp47hdno6xqad.onion:8333",
+	"mssd6spy7kkrpsbklcxgbcwthqstibfq2uohzghal66nloh2hqku5fad.onion:8333",
+	"mtwknuys54nf6rpe7vgpuvtmdpsutik6svkqz3g5o3ub6ovfxyzs7bad.onion:8333",
+	"muvdhx3kpuus5yfonu5qm7wdatzdf2fqoelec2us5qpq2ebts3o6v6yd.onion:8333",
+	"mvaqeeya5j75rsevn3jjojjmaecmycgagkrbjusj42le3aedrexikzqd.onion:8333",
+	"n2snxkqi2ukxmprp6xdxgluekiwa47rmfpjlwu2rji4os2jgqv4huaqd.onion:8333",
+	"n56w3dulod6mtfhit2jufyhyynfiqrp5dfnlpintbg4ptpfj2rlf33id.onion:8333",
+	"n5rivbb2di4foagpzpkuk7evubhvi6ztju5jv2vaf7dsuvpjwblrlead.onion:8333",
+	"naliiaalxxb2xerzq44qk7vyl5emkj4mdax37xbfzvtwrxrgbnzc4pad.onion:8333",
+	"ngv7aqj57gp5ggdepvaa6f57h6nec3znotpbpfqydtk5qk6mr57bgtqd.onion:8333",
+	"nhzbmen3uindjurabpr66kqpmjmxp3mafvguvfxkvl7njm6hnsshckid.onion:8333",
+	"nismz4n6za3qk7tq5kxg5ccqzd7oytqpsqkalbqgg7w7miszcyoccyyd.onion:8333",
+	"njgn2bolcd7r6m45egj3z2na5ygizaiwa7m6w76ur7rkhffy4bfzu7yd.onion:8333",
+	"njlsl3vqincetqsuha7s5usm3fessfws3iz4xgasjwae4a3lcwo5umqd.onion:8333",
+	"nkdmvhi3sj7bs6uijnu4az2uu3tbss36ditdh7qo6k6nggnumkgyfgqd.onion:8333",
+	"nttkhv4xc7cqwffpdoenghpo2c7kzzgm2n6w7fqtjlvhm6yt5vxldbqd.onion:8333",
+	"nttzhooizmzgtmmoiekdcrkpah3fdh6bjhlsozudzyj42qk3uohq5lyd.onion:8333",
+	"nusp2exwyipg6mobf43rv663fa5dvtiavdmj6rsgbulcegkpezry4nad.onion:8333",
+	"nxo24wohu7kyhh7tmqrlgbf7ybehwgwwqxvkfhblrk2n5tpwx2a32cad.onion:8333",
+	"nyxolfewbf4mqbd5pf7f6wnuksytlptcrqenchwnvlkdvnnalqhtomid.onion:8333",
+	"nzahfgnyavzabwerjzk4hlj3e36g3esedvpxf3zi3liqsdpznjtryyad.onion:8333",
+	"o3stgp3n4wyfzds2cectuvzfyn73sulflgebh5yckndlbc6rs3ewu3yd.onion:8333",
+	"o3znpc65ata5qtnpxsladsi5cjnaagvazwmju3yr4uywldbosayezdqd.onion:8333",
+	"oapl6vgm5ujktk55rivxzzm3hy3lpd26ur2znbmavyotdfhylmxct7id.onion:8333",
+	"ocalcuzrnuhhg6xr4v7h66gk3lnrybv5tisy6t2qvvovlxcaoukrimqd.onion:8333",
+	"od23bpkxwoxd6t6wnrfmqcenfqeiucocxk757dq4f4gfdl3awqhkiuid.onion:8333",
+	"odvxf2y3jw3koouncxesybhjyopvbkri7exo5gfjw72j6dplgf3ipyyd.onion:8333",
+	"ofdj5sozmtawczbir42zkf5bzcjwquuftoeii6bknbh3ackpre2vagyd.onion:8333",
+	"oh2zlcpuu7kohkifsjmdrfptzchox6gabqwd45zmmtagbdutsju5v6yd.onion:8333",
+	"oi7myopz4egfch47gdhqrawhqyjd7msnbvmebxlmwkxj2oc3v7jgfpqd.onion:8333",
+	"ololrmd6nhdoagjzpfbdbxwcln7w6xbgsojngq2u7vs6i7fgbl2dacqd.onion:8333",
+	"oovkmmlohpawqrrteee3sdu5cr7dj4t74t6uplia4gnacrcnzxv4m7id.onion:8333",
+	"orsclewmktu3oslwgcngqsqkmoao5mfwizomcmtdnzyslamfr5ecdgid.onion:8333",
+	"os7cs7spwfnse2hbxnebdhetzi62okt3nnhc2bdvxpihenyew42vxsad.onion:8333",
+	"osrkc3tyeqguid7e7tulxiyr2wencdnlzqfztloy5ysf7g6f6jkkcsad.onion:8333",
+	"ostf72nz65rryilvumios55edp32qx5aklnzehijcvneu67nqezxgjyd.onion:8333",
+	"ovt2cumfgbf75h67z6i5t5xq6wxhv7k4obhp7z7hmhfmo5e5pb3m3rid.onion:8333",
+	"oy2peoj3e4n3huhkq5jeztylp7zmaeofdoczxyfnbhswne7df36y6tad.onion:8333",
+	"ozkwwzhhwopwabka5q722am5xoaoyu2hm7xzbieu5oawr3v3lrxx3tyd.onion:8333",
+	"p24nb6icm2ul77eqrmgwdhtr3yuzobyprbdrvolcdbxi7yepshafiwyd.onion:8333",
+	"p2yyfyqo5v25lw74665uejgtu5uiwajbt53op67lnj4egb5acq4bujqd.onion:8333",
+	"p4gyuodhkfnprybid2ubfhyqmwiohzbvouo3phsmnmqleotmqcwnt6ad.onion:8333",
+	"p66i5g2jckghtwgmli5tdblluivf7rlfzze43b7lgp45rrustkqtdbid.onion:8333",
+	"p6cycvqnwma5vwpjtogdapyd6uxsbzrq4fgvdjr24v3wd4nht3xcplyd.onion:8333",
+	"pgghugjycg5gee6ebk52qcjxnrgvpo6evksnz56ldisom25ieg6yjuid.onion:8333",
+	"phet5l7nltnqbpyqp5oxinxeecpzptcaefyzopz6g4js5ihfqvbejmid.onion:8333",
+	"pkgyge32n6gxrtwdochwfvx3wfrn35oaq5jbfjdj27oktq7fkpn3j4id.onion:8333",
+	"pln25js5g4vd4zboytjxdet3xfpadsvx3wh4pcvbcxqfpu53h22mc4ad.onion:8333",
+	"pmhtpaz7d25ykrxet7rxzfeuzbjfmiemevrjll7vtm5n7ny5reobf2id.onion:8333",
+	"pphwfpgozfrlogfwysqiwu2ckvzbixy7cuzj3lhbv2resus5hoac6xqd.onion:8333",
+	"pq3cxnzsvoqt57wob7zlrdnhbjqt4oqsyr2bgj4otdlhtcr27soihfyd.onion:8333",
+	"pw2lmuadhbmd7cfvne6d7nfk74fio4zii27h4h33mgiky7l6yuieacad.onion:8333",
+	"pwgwl3apd5kohikzxrfr5gzbxcawd6bzexob737etgvbs4fbrmdttdyd.onion:8333",
+	"q3b2iqm3lcvctmiszchnso5hlxqtaq72gllwe4d2crimsqyo3haqadad.onion:8333",
+	"q4hdvlra6kp243aqqg37kzv2iz7xgx5vmv2kjcnlitm2vuwmuwr2ubid.onion:8333",
+	"q5auzukt3wvmm33ggcwr2ajya2cwwlu2qfegcsolekej6vl3dhh7slid.onion:8333",
+	"q5niakbu3wbkaxmvnjwjd5ystahj64sdkrntym223m5wzp4r3axey4qd.onion:8333",
+	"q6pq52urrbdf3ywp2p7z2l2jjzt4ss7a3l34r4sluk4xljq3bmujacqd.onion:8333",
+	"qg4nheeamet326xntz2wp3j52btarkfbjmqvxjjckkipingb2qdrbhad.onion:8333",
+	"qhvkfr7ws454yzil4we2z43pgdciaga7xiixzi2kwwab4pkgrppdzvad.onion:8333",
+	"qhz7hfy3myllbso4ftaky6ov6x2uapc3itqdxlioylnhqrg5r5nqgeqd.onion:8333",
+	"qji25f62cmrpztphv3jq5vtj5pp7uisua2aq7tte66sil5jn2xgjgxyd.onion:8333",
+	"qlpf26dtarvyx4hkr3gqeyy7tw4zcxcexi555fwzj3iaxb7gqtqkfkqd.onion:8333",
+	"qsauypffrmupw6puuoemfr65uyqbaxuyfbhtpzyc333o64lvwaiaywqd.onion:8333",
+	"qshastjvghuri4il6lziyhimir5ak3vorvolmokdwffefann2feftwid.onion:8333",
+	"qtbt3acapg3jm6ivdzee72ftp6kavaptqmktnhmdeycljpkv7upmo3id.onion:8333",
+	"qtwmgohfnmislgcndsrs6occpcbcfogvvlyp23yv4dx4fr326vmzw7yd.onion:8333",
+	"qvgmxozpgdgbvvt2equ5zh6xignafd2ljcqx5b3rxrt7oj2qqecucsqd.onion:8333",
+	"qvrhka6tupggu7alh6irjplg5yv2dlvwl3jpko552nl2q34mnivlezad.onion:8333",
+	"qz37ralpi5pcilahln2rsys2e6dkvyw7mpfg7p3ico3tnbyysoqsbpqd.onion:8333",
+	"r25tugopslfd5fomkuwnsrc53e4c2a3ka37lb5nmwuwjn26uj5fdxsad.onion:8333",
+	"rhpozy2z5ut6hs4ukawjej6sn5vfqrsc7z5i4u6b3rtgv7anlmybf7yd.onion:8333",
+	"rky7zz76yvft4ofwmkdsprm2b4tbqxygxxqbvh4s2erhrjelr5wgcryd.onion:8333",
+	"rmxqevztxdk7omz6ihk6edlufnnprwn36i5ynr5dzykymn6quuq7txqd.onion:8333",
+	"ron4xpdbifirfnlizxw3dle2su2f2v2kledpfoc27ccnbgz2lr7z25ad.onion:8333",
+	"rplwb7mifasoogj5hl67i2iqbtlkwn6ap5xjfoey6cvkgbcsnilzapqd.onion:8333",
+	"rpv5jazbjgdxlxblvknadzu5sw6swlrs7sg6jsfpipjjg3omjxbz4cad.onion:8333",
+	"rqonp4khtqv2jrxjpf7hkxomohn2boialproduk2apbi5ir4wjuqd3ad.onion:8333",
+	"rryp45e5fch4gaxnaknwlxf54psef5kc5gi6u5lvuk7hnsxdicvdsmid.onion:8333",
+	"rt7trmprcrmstmycjkbpfsiaxr6x7ad5ageavpz2vdyogmu3xp5s2oyd.onion:8333",
+	"runbtcnd7trpbqkvqa4qwg2s6cnlnyc2ajxk5nxh2znk7fkrphysn4ad.onion:8333",
+	"runbtcndu6cirobkbrpfr3lyagmokzuvp2bxep7thzg6pdmn3mir36yd.onion:8333",
+	"rv523ahz34izva2gzraunbdlqr67jqzkooo4iqv4wujffh46haconvad.onion:8333",
+	"rvkxwfgmnn52ubmyase7stexaxmq2mgeatjiw4kbelvqliq5nycqvead.onion:8333",
+	"rwrradz2co4uskaq5gbguvlkyxo3rqgrm6ee6mjuoiofzoc34dlyaiid.onion:8333",
+	"rxwt652n3jf6wr3npuebmifabncsygkdrsrq6qxjhcmso2o3njxtfuad.onion:8333",
+	"rzucplnjvh6z7ay64mmhzvblct26qdf7jrb4cxcn6yejml3owzw5szqd.onion:8333",
+	"s3nkl6qs6mvi5i4j2p2zcf3sdpt4hydxhqtr7ju4fjp4gcqawthw6nid.onion:8333",
+	"s3vfmjosdcn26t7w3qawhspgn5rsf7dvb7kmuwrj275d2f2qrcnbynad.onion:8333",
+	"s4ycyfms6rgyaovivdqbyttmf4nxmf4xx2rnebzjlm4iuzh2frvxjaid.onion:8333",
+	"sdexgsheypocelq5wwr53ydykb6xfc7nso2m6wxapkxogowvwvlwg5id.onion:8333",
+	"senqk4fohsky4cvo5c6yl4cyekvseo2yhnnqmdczdky45ri5kks6g3yd.onion:8333",
+	"sfyy2eqfn73did7ab3xjdr4nbp32kpsduhn342iah4peq5xy32e33rqd.onion:8333",
+	"shame2rrgqa2fq3esx4zudeerzpx4vzvmbjjhbclbnalxsluc32iikqd.onion:8333",
+	"shedlblwqh5ejv33od6fezaejqg46hnu2p4lapujv2mjjcdb5ngfxfqd.onion:8333",
+	"shwsgubsvy642st5rja3evh4u5laoqb7kcdikvi4hjwq2tahv4ae2cyd.onion:8333",
+	"sixrzjq7fnl2h2cvidvfii2z5yuvmcobkp74boffwc62fv3px35svkad.onion:8333",
+	"skbp5rd5i3igr55ehqul6r5y3g7rv2wcxcogambuqrmn7koast7dsfqd.onion:8333",
+	"smyvxsksy5lqi7zwhfyxjisvdh57exc2wyqcaq5qinljbieq4tllu6id.onion:8333",
+	"soiivwy5kvpoqis4isqporlzuuugd7rg3ldqk5faau3nbrupq5jfjmqd.onion:8333",
+	"srqgwonojokywxinmm5u3c7smiynkym42ludgjgnxr7m45wqzln3hsid.onion:8333",
+	"srt4i56nickppic3guxaqg4phberdjihp3brdnu2wt4kaurduoxluxyd.onion:8333",
+	"stw2v772blji7h6wc5zss5mjexzjc6fukpni3jbp3llodc2a5apqyoyd.onion:8333",
+	"stxz5a5n7lvro4jda2ugr4i3ufnhkvifqdw6c2xverdlipsrgonek5id.onion:8333",
+	"svezmh24cgqujtbgvp77rbutwu5b5detv5zddah42i7hypwqu62cvzqd.onion:8333",
+	"svqwicj6fmkpop7nhxpbrrrdoq5w2g2fnod2cvjc2hpwpbkdbbmsioid.onion:8333",
+	"sw5ed5kqi2ujy36ljajhsy7oee5qhq5u4onjivikevtor3gichrdcpad.onion:8333",
+	"swb2xjt2f3hng7evp3n5wgvopsyykpclizi6cm44cqp5zmq5b4jzpiyd.onion:8333",
+	"szqmy6sqxliejrupgzrixvzro7skpjaoc23rqlfqrkcg2d64rinofmqd.onion:8333",
+	"t5r35bnbdfam3va4w5p2gy4tmk27cdkd6yfyiufvelxlt7hwxl5kqaid.onion:8333",
+	"td5ihk5swzrrrw43wglgh7adyhlxsguhct6tifvbungv5yu7kp4zwsad.onion:8333",
+	"teqacgwuqbjfwqirtfevhwoyv26yi2zn32lf7mrxrxagh4wo5fwzmaad.onion:8333",
+	"tgsr6t4yyvxbssksh3ykadrr7yx5bw5aoaxukkvl2g4i6aq5aeqlxoid.onion:8333",
+	"th6amf7ae3vhkg3d6g4wg4f2y5346riod45hgp76svrv2eahv3owymqd.onion:8333",
+	"thcco7adzelopnujxih3jssjx3aqpl4fkkcxolo43msa5ekss4lvz4qd.onion:8333",
+	"thksfypuh2vielph72yvrjwxvsgzrsraanfr3x6wqom64puckfzvzbad.onion:8333",
+	"tjeiksr4gizw4jozvyilivrhd7dbik4asabja5nsc7j667mz4as4rhyd.onion:8333",
+	"tkm3adunzeix3wtaco5v6jhclmytxrfd5n3hsutygiwrl7rb6lhaciad.onion:8333",
+	"tkpuyawyjx65ryd333qglyx7xynrufae4wzr3stdwcfgynt2bq47pyyd.onion:8333",
+	"tm6zthoru35qazg23oa3f3kqtdoqqy7x3meiiy4d2ica373dqwcl2lad.onion:8333",
+	"tnvymqsi6mbtf7qhqo4mwr3u5fzf7efwnzq7fb7gjmtw633wuqw6x5id.onion:8333",
+	"tostega5we66duknqueqsgoxqokekwim2itgpktwryhlzdemwwcdhqqd.onion:8333",
+	"tour2awil6qfrj43ho7l4wgfdihxytqg4fx3ktewuuylq4wk24o4hzqd.onion:8333",
+	"ttev7xtlfgbapp3ixladldbnwneglpv6a5cwlmwydk6ouplg54oydjyd.onion:8333",
+	"tuypwxrtrma7vmk45wmsiuphacsxcsci7pqxdfaohsa5ejgswcix4xyd.onion:8333",
+	"tw2gw2ownxt4a6wz534j74abjr3d4lxmxdsinddanh2vyys4ct4e3had.onion:8333",
+	"tzo5ca532cvtpkye2vw5hrw5hyf7y3amzmley77dmcv4jw4iiwqdvwqd.onion:8333",
+	"u27wkrdw4dzwvwimrtu56vby4nx2q3odlogaftxejltocczox5klpaid.onion:8333",
+	"u2r4wolbm2t3dnwpdxqkttn3cpghu6ivivu7ddzkhfijona3anbo2tqd.onion:8333",
+	"u2t3wm6qeivpsglk5ejk2mlmnykofv6nh7rslr3xhbtmeeuywefoosqd.onion:8333",
+	"u4dmemh54dwnagcn3s7nnfx4d2dhst4mnu4bqjzrscfuxujct46i6jqd.onion:8333",
+	"u4eenrubscscjwhjp6qwmmlkjgcuucftscnjf4laihni3h7dihf4ufyd.onion:8333",
+	"ub3btcznmvsugxasshhqui3jduuuzk53oxqnwr25355k72dbec67vxid.onion:8333",
+	"ubq4af7c52mrxa6i4k5eyphsai7mnt2myeqti3kbyampqdp7njujicyd.onion:8333",
+	"uckywjngyn3537kppfpqqid5vqvrh2hcvycdcl3rulkxezwc5dywdxqd.onion:8333",
+	"ugrktq7cvbxuiairsjg355otgo6sj5o6v4tdh6jgyfzhwot6hdk35vid.onion:8333",
+	"ugsrgkkhboxydoy4jv3zd2temr7z2vdhkajluipymyck3oldax7gv7ad.onion:8333",
+	"ugymttztvn2phtt7kri27znqce2ygztwd6esmnkhrdr666uoqsmdtxad.onion:8333",
+	"unw3ucuqryr2wfssrq27hbryr4hi6gfh5254ovj24hejovnjdczjnnad.onion:8333",
+	"uo2ujjaw5gvlhgj3p4itqodw6ch2mzw4xqhmib67c4sf3vzugyiefsad.onion:8333",
+	"uqzihpjtgmfk7eciqwfxfayrma7kjqhwyt2ezuw2n7m7s2ajqnqh6hqd.onion:8333",
+	"ursmc4womh42wbot4ne2xyp3wttarcaouoa2z4q3ibl74hkbh6ix64yd.onion:8333",
+	"utroczdxvnhziozke5vu2mltn2nylckicvu2teyrbiujjzwbwzeha2yd.onion:8333",
+	"uvxdqu76qq75w3hv2obxjupjvoagbdmbyt36x7bihl4m3f4vexfk7dad.onion:8333",
+	"uzjmlb42mhl45ld6awhvk6pwdqif76jgzzq5hsmuk5pqdlzqawu3rsad.onion:8333",
+	"v6dk54o7eqt3qu6cyfh73whi4yojqfvljeric7a36bpuy677wzxu2hid.onion:8333",
+	"v6hdsumwmt72iecmhfpqieakg6u37t5yl6vzrzo5wzejmwv7ajnn6lid.onion:8333",
+	"v7izfunvqpm754jda6xjwupegwsl36roeeokhe3uxf43uzmgjnggmiad.onion:8333",
+	"vbamp6wl42afwiataoe3vv4yfcblmqulrddjutiqwmhflckbgdtrtfid.onion:8333",
+	"vdorrxom6cqzejzxdpvc5mvo52dssualn2tjide577ccl33ty372ygad.onion:8333",
+	"vf6365t4ftyebzjxibo3pbh46fxemxj3nr4x2ehpqihx7kgev2zxeiid.onion:8333",
+	"vgwykkib46lhtvqk2kde2mc6hiekizpq3eme3co2hkpsl2ax44yqf4id.onion:8333",
+	"vh55hbszi6thejontpjosd2pqay34dmrutujzzs7arieia5xacx2ajad.onion:8333",
+	"vimmegqjmemijtpcoxdc7f3rlj3fa2qwuapkcdgpoomaibjc3qk5udqd.onion:8333",
+	"vk27mupi3qxydqzp3kbkocsw4cdjsup4zro7t7ug325y5jnj2taoiwqd.onion:8333",
+	"vkrylooy2kphnalj743mhqobudi7fy3u3e2d4smluzmz3ocat7y3bpid.onion:8333",
+	"vl7jhrg6yz4mjgm5xe52t4xtcf4dgj7ovd3xb2d4eypzc2fb42cabcyd.onion:8333",
+	"vlp36ofuj46zpbrkpo2vmxjrkimsgocnsjojwxyhj726algpr4fq2sqd.onion:8333",
+	"vlshl2xo2urcm3evjgph2d6hzxq2wc33mit4dmctemhjsylpbaguncyd.onion:8333",
+	"vmfpjx66xzxjou3feseelc2bvtd2rtxzri36odg66u7nfbrb5bew2pqd.onion:8333",
+	"vqfiycyhxoh44hciaznjyeasgevc7n3ht6sbo5ifbdazxyflq6xoidid.onion:8333",
+	"vv7qfzofxdalimndpkqysesbyh7i756asooilzlaxifyuu7jhdcy4nyd.onion:8333",
+	"vxirtc7lltsthxgslfnchqrpgttnbklrrz747jrvnge6mqecevnubwyd.onion:8333",
+	"vzt22de3oeesqnude7spvyzd5qfsfjkfuhcselrsjp4yutrvz77ixiyd.onion:8333",
+	"w2djzxozgqym7o5mtpoumyzuneji3ykqdhfj2p2sbffc5cmivwiwt4qd.onion:8333",
+	"w3vecku6tbnlbxkhlauw25eh7ra2h4nfhxuozgmfzshwlz47bny45did.onion:8333",
+	"w76irg7ejjknkbnylzmrwpvvv4c5247cqzbf2otgw2cj5w5fedmswnqd.onion:8333",
+	"waxo2tweygkwf4cqmktpbl6uxnhv6qv5dysgqjku4yglbr2jf7psa6yd.onion:8333",
+	"wh3bulh3t5s6cg3zo4nxneja5ske2d322kktyuknzzox6lu2cc7jclqd.onion:8333",
+	"wrngu6dqd77vnkmzq5eomv54mswtezwyq3houfzbjrerc5yvjxrpepid.onion:8333",
+	"x22qnk2zgro2ymrbblbr53qnr65ckg4atma2i4uid5comob332fxenqd.onion:8333",
+	"x2f4nakfdndqxjdarnrsrtrrnaxdncu7h2esbimbxhen22apz4loimad.onion:8333",
+	"x34feedrzd5jomdvlhrjf4b7sqgixl7gak5ccr72bckvebxcdzbzp4id.onion:8333",
+	"x3wvphqo7f5kqnirobo3jptefrew3h4rmjc7gh5r5elaioxc6m32lfad.onion:8333",
+	"x4u2dkz7tkbrrk6isgr76ueioykvgjdumglgsvpckkxkbmjdgquvo2ad.onion:8333",
+	"x6phkjxljpaxadss27tacebq4n4rgmothga4xvrdxsj5rxst2kr46uqd.onion:8333",
+	"x6tyyoqbn5enswjlbf5caawla5jxqx4pxmumjzdmgqpksbntvw5ssmyd.onion:8333",
+	"xa263tygi2ozp4t2u2n4stz4fsynbi4wf3ykar6aqa6y4kgi4yeclpqd.onion:8333",
+	"xb34gza24is3p3k62okovjuv67l42vfc6nivkvkw46lhnfbhamslnuqd.onion:8333",
+	"xbsqjd7e5grys5gl2v6ewc4vy334mdb5kujcngcl4mak7lvevjrdpqad.onion:8333",
+	"xcw6dgrsmovq3ekkwhsfl2xpq2ggjjh4ccky7q2ogcw3p43u3i2g7dad.onion:8333",
+	"xdsiekckqa4phxh5a3jvji2cicr2yr4hyy3omw5x73u45ipn4ajwdxad.onion:8333",
+	"xgoidfkdv43ogx3pzczcyjfzjjjnj4it7ssyuehqddcynl2chn3ahmqd.onion:8333",
+	"xhjo4wsq6swrmhlhktn5qunm55zi5nwsmepn24xemekjlszijd6jxeqd.onion:8333",
+	"xinspp6xvlhzkmuchcoq2wx25qjd5t3d4oictuowkvlcdp62zracdyyd.onion:8333",
+	"xj6ud77fdqlal7vpapmgnnpkzxdyiqlh6dq3grcxlittx7yvjwd72lid.onion:8333",
+	"xkc2rjgoxvhrgk2gqyw2hktlmxfl3sqpl5v24ubnphe4n6thhsuux5qd.onion:8333",
+	"xkozjj3mp7xw3qpxwnymcjqcpbtww24ri363x2pz5g64bmqpjguzw6qd.onion:8333",
+	"xngxbbko7i5pe2fcyjs5yzbqzgucjksqgz5gdfk364l4a2l4dzewn3yd.onion:8333",
+	"xnt4oa23anc4hvsspz2sq7x3jxinqgr4j3ckj632akjpohan2p6vfhyd.onion:8333",
+	"xo6d4upsa6gu4ngxcekp5b6czcbx5ahuc5xajji5t5khkmr2b7ca5iyd.onion:8333",
+	"xogn4krs3iklbqdvrbztkmsxbyxyq4gncdosrudiwblob5qf33zpsvad.onion:8333",
+	"xssfymvm5o6g7q6m5rcgovhqlr4xnikvskcvvdil3uk2gkfoyqyed4qd.onion:8333",
+	"xts5yac4megadaovscuowx2q7frroyvtaanzr5x7x7cabp6i2grkzqad.onion:8333",
+	"xtvkcpef4wsn2ysquuqohypuheko3fpwgyustgm7fsh5zk2z5w3wkqad.onion:8333",
+	"xwqdtpbzeulple3qfbmldavkrj3uruewbyeytyhfa3um2twq2zbxzaid.onion:8333",
+	"xzsffyx3fvloqtjjpbffeo4hy6xsviybhj5jerof4xxoomvx7t5gneqd.onion:8333",
+	"y2iaaicvyvqrt5gnqngbna2psl22c4zwvkupdzkxp6ysjeep74sgtdid.onion:8333",
+	"y4f4ldumw2xtyhf5fsamz3o73ikaote2bfh2qyxhyo7ux6u2qiuyoqid.onion:8333",
+	"y4qp7lfujsvdkpcn2nf73n6d42ghvn2gmsuoygrbeid3gbvcpcfx7oid.onion:8333",
+	"y6ildbrwjr45rnzrv345mwpxoogp6xkeg4fnuqadk6guknjxdqrkfead.onion:8333",
+	"yadufpjilf7yflt7p4mmfi2xrjqipf3dt2njall2w4nkvz2jqyt6j3ad.onion:8333",
+	"yds4it7kiqjlfmjnludhursdinnhw5axngvfji4y5psrqphnoxe2cfyd.onion:8333",
+	"yfus3l7hr23icltwfifhgcx4o7gq3km2t273vtoaudk6z4jnhsyboryd.onion:8333",
+	"yjew6ztu27l4nf6yeqjj2sjd65alknvyjbo7ptrfnv3oxvtnm6gnkbyd.onion:8333",
+	"yklmuva66pzp3o2znx5mkzboic6fm63pt5katvmbniok2fvo2yggf7qd.onion:8333",
+	"ylcfu6vntifhpq7wzd6saujskqxobeiutvrirzgsw6vkqxh2swmyo4qd.onion:8333",
+	"ypy6l6ifrdl4kfxfgvajji5fbscozvp3fuzjkb3fslr62on3fxh4s6qd.onion:8333",
+	"yqcbdydfe7qthlysrrwxtxhucgcf54kw2modewfbl32dag65kacvwbad.onion:8333",
+	"yqdsywyf6bict2e2jebxm7j4nhdikaswcalh4cbzgqk3sm44qzoh4aid.onion:8333",
+	"ysj5dkk6jxj3cmdvkakl4ex743dudy3xuu5yodltt5usufp5cf7kmuid.onion:8333",
+	"yvnxxe3ghte7lsz77izb5uygzgi7fyijhqfhvdzjhzkgakr5tkqydrqd.onion:8333",
+	"yvptdlqrm2nij2jog6dvhyy4vfvlsbabfwnpbtz3hs7qvz7cm5sojrqd.onion:8333",
+	"yyuwpblymivgxmxo54djkgpl2lgbvtj6wjsa4iaza4govrf2gfyrdwqd.onion:8333",
+	"z4kf5t5rvcv6rssyp4tcr3rz2dfpowlp4wci5tnouzpxkxofwutnw3yd.onion:8333",
+	"z5eiwiz23c33luawbcwmusvzdclxvgi64qxdbevtmqj2y734dvg7ojid.onion:8333",
+	"z7ietpkfu6lwyhnmcpgv4gh7wwr37z5axy6ii4mrwqm5zwnackkzv2id.onion:8333",
+	"zc3ctvidgljnbmjg2eul645cafhlag42lewwfkjqoes4iexryosz3iid.onion:8333",
+	"zcl57iy5xdi4pofv3gofeis3pcqf55atyeazpnpy5477ay25u26t5dqd.onion:8333",
+	"zepry77cmp5srnywvxorqt6y6yfxy4ajiruxt5qpeymqs3fhbi6mhead.onion:8333",
+	"zfiecxbvvptcyy57lwo6andzd7kxqgpsbvmrmdylmwhubbxkxxmonoad.onion:8333",
+	"ziuadlizedxh34g5ecw5vhwscukrnwknaf2kdvp4jfu7ejlymo7rrcad.onion:8333",
+	"zjff6dils67uzfbfflojgjha2qrscjbdx3xl33qxbu6s7ukcawnmytyd.onion:8333",
+	"zkc4xs2lbbb3ozzmqnh4i55frt5zvusljn2c2o237cj7mzikaf3s6fqd.onion:8333",
+	"zqm2s7pvssxlcbw7qqpb2jqgbzgy2jpz2ex6gghyazlqzwk6agcw6nad.onion:8333",
+	"zrcsvuobla4b2i7shdiljxsulexz6odhwxdsi5j72e3l7finlffsggqd.onion:8333",
+	"zrggximdixhwt62lqktar4mjum3byusx36dc52kyqsv65apsmztabdad.onion:8333",
+	"zrhxbiloczuw6ujm4ygh55rhvfuhuary5auvoq6h5jueiwnboj7fctad.onion:8333",
+	"zs56rm4wkmb7qy4yr32sm2dou6yfluwfjtkuo6ft5gwb7h4o5z65haqd.onion:8333",
+	"zx3qjmvjtg3dd6yeon5gmm3mvdbkhpihdxkucpxm7n6atpghpgcrcxyd.onion:8333",
+	"zxx7rf4a44zjqrk42pq2ifygado7n4mc655jgtutkyyifca6z52xwcid.onion:8333",
+	"zydzl45fygimiugvekdkojrxbcrdchqno5ea2rxa7m6xcyhhdxhj3xid.onion:8333",
+}
+
+// testNet3SeedNodes はテストネットの固定のシードノード
+var testNet3SeedNodes = []string{
+	"[fc1f:22c3:95dc:a3af:4a93:8251:beb9:1858]:18333",
+	"hedmbfis4h4inqrwo2uzmku5xu6xaq74tg7sofnetqikdvndtvja.b32.i2p:0",
+	"ica24vnstxip7gkr3c6dlmqgw4opmfrvv3dppjds7a3uddurpmxa.b32.i2p:0",
+	"nhla3xzibmhvav7meemluh2d2jxdrlwicq636a2sohwqhrfgqi4a.b32.i2p:0",
+	"o6j3b33bv26vthoqzjy3h7a3qsuqbtbe3ulajmjzsl43vaqyieyq.b32.i2p:0",
+	"xgctj4seo3ofstiymoyeuzq74bvddrbr6jtia6erodlf5va3cz5a.b32.i2p:0",
+	"xy6ytjxel5ku4naqumj4f6hn4agrzh3w44gqcs6xkkzwm5s3jdgq.b32.i2p:0",
+	"zakpo2angzhk3w32dwtcbx4rt7cpw6adx3cs4helfqe3rlqn7vnq.b32.i2p:0",
+	"zklt7hncdrl2hupfwnlbt3qaayhjayjhvwvj2m3fok3z36tlnxhq.b32.i2p:0",
+	"18.118.231.3:18333",
+	"23.227.223.209:18333",
+	"24.160.99.9:18333",
+	"35.183.51.117:18333",
+	"35.210.184.94:18333",
+	"38.102.86.40:18333",
+	"45.50.223.112:18333",
+	"45.55.132.91:18333",
+	"45.82.64.163:18333",
+	"51.75.147.82:18333",
+	"54.236.59.55:18333",
+	"62.210.207.63:18333",
+	"65.21.33.227:18333",
+	"70.95.111.216:18333",
+	"75.119.158.18:18333",
+	"77.163.221.171:18333",
+	"80.90.32.185:18333",
+	"80.253.94.252:18333",
+	"82.181.27.200:18333",
+	"85.93.205.58:18333",
+	"89.58.9.219:18333",
+	"89.169.128.112:18333",
+	"91.123.182.164:18333",
+	"92.115.99.159:18333",
+	"95.141.35.117:18333",
+	"95.213.143.91:18333",
+	"104.155.226.24:18333",
+	"121.45.34.75:18333",
+	"124.236.16.91:18333",
+	"129.226.198.211:18333",
+	"134.195.89.130:18333",
+	"135.180.99.74:18333",
+	"137.184.2.124:18333",
+	"141.98.219.198:18333",
+	"141.98.219.199:18333",
+	"142.234.33.228:18333",
+	"143.92.61.230:18333",
+	"148.51.196.40:18333",
+	"149.202.88.152:18333",
+	"150.136.77.157:18333",
+	"156.155.48.99:18333",
+	"158.101.104.219:18333",
+	"160.80.11.66:18333",
+	"169.155.45.180:18333",
+	"172.173.81.233:18333",
+	"173.231.40.170:18333",
+	"176.96.231.180:18333",
+	"178.21.118.82:18333",
+	"178.162.218.121:18333",
+	"185.28.96.16:18333",
+	"185.107.68.135:18333",
+	"185.210.125.33:18333",
+	"188.42.129.156:18333",
+	"188.213.90.149:18333",
+	"193.30.123.70:18333",
+	"194.95.66.129:18333",
+	"194.145.201.243:18333",
+	"195.66.213.33:18333",
+	"195.201.126.87:18333",
+	"203.132.94.196:18333",
+	"203.206.21.155:18333",
+	"206.204.104.7:18333",
+	"208.68.4.50:18333",
+	"208.68.4.71:18333",
+	"216.219.91.82:18333",
+	"[2001:41d0:700:544c::]:18333",
+	"[2001:41d0:700:6e79::]:18333",
+	"[2001:41d0:a:7e98::]:18333",
+	"[2001:5a8:4164:7a00::1f8]:18333",
+	"[2001:5a8:4164:7a00:be60:b5aa:22f0:d1cb]:18333",
+	"[2001:b07:6469:3491:56be:f7ff:fe26:21bb]:18333",
+	"[2401:d002:3902:700:8708:37c4:e231:d3d8]:18333",
+	"[2602:f480:ac:c010::50]:18333",
+	"[2602:f480:ac:c010::71]:18333",
+	"[2602:f480:ac:c010::77]:18333",
+	"[2603:301f:301:7000:2d5a:9709:3277:3d4e]:18333",
+	"[2603:301f:301:7000:89aa:4db9:1304:a3e1]:18333",
+	"[2603:301f:301:7000:9c3:dcb0:c968:fd43]:18333",
+	"[2603:301f:301:7000::f720]:18333",
+	"[2603:301f:301:7000:c6a:66:9b68:4d22]:18333",
+	"[2603:301f:301:7000:e02a:8874:7317:3f88]:18333",
+	"[2603:301f:301:7000:e16a:a332:df2a:3b3b]:18333",
+	"[2605:3380:422e:1::50]:18333",
+	"[2605:4840:3:2c23::1]:18333",
+	"[2607:5300:203:b2e2::]:18333",
+	"[2804:431:e038:cd01:aaa1:59ff:fe0d:44b8]:18333",
+	"[2806:2f0:5681:f191:4049:ef30:5956:b8e3]:18333",
+	"[2806:2f0:5681:f191::11]:18333",
+	"[2806:2f0:5681:f191::e]:18333",
+	"[2806:2f0:5681:f191:f597:a2cc:c53b:dc1d]:18333",
+	"[2a01:4f8:190:4026::2]:18333",
+	"[2a01:4f8:201:508f::2]:18333",
+	"[2a01:4f8:202:626f::2]:18333",
+	"[2a01:4f8:231:645::2]:18333",
+	"[2a01:4f8:c0c:7776::1]:18333",
+	"[2a01:4f9:3070:26e2::2]:18333",
+	"[2a01:4f9:5a:161b::2]:18333",
+	"[2a01:4f9:5a:44a5::2]:18333",
+	"[2a01:4f9:6a:13c3::2]:18333",
+	"[2a01:4f9:6b:2ce3::2]:18333",
+	"[2a02:29e0:1:420::64]:18333",
+	"[2a02:c206:2075:3352::1]:18333",
+	"[2a03:4000:2a:514::]:18333",
+	"2lsncqdflwk272dhydrxf7ikfy23ppnmm54dnynyxiym6lqf3wowrmqd.onion:18333",
+	"36fwktckggarkclbpu2pumsdpck46ahe6cwpozd2gm6q7kgdqljclmad.onion:18333",
+	"3dt6kgfrilc3nwliwy5wbmc6oa2b5y3t33nkxlrxrkunr2hoaj56kgyd.onion:18333",
+	"3rfphqncnepbm7tvnaqjetkaqvauj566nw3ys27fert6zx3q75zetoid.onion:18333",
+	"3vnbi5o3hyzk4cm3hhnyo3h7tclr6pybmhmqwkbyclslfhqtg72pq2id.onion:18333",
+	"3ya4bo256hdws7wweyc4z7hpldsli5ra7vvdjkbh3p4yerd6b5vvr7ad.onion:18333",
+	"5axx4qyub7qtgssrvbbqudu6uqjbuknbvbld2wdscv5p6kh2hsfzjxqd.onion:18333",
+	"5qdgulsryjejkjinocpvknazwtnbqtuf4f4pui2at4jpedwwpg427qyd.onion:18333",
+	"6bhop3n32rreiynu35epznhc2exxkhuleromdhnida2hgmu2bhczgmqd.onion:18333",
+	"6fdbuqf2kgeweeoeikmwfezauys657lzezzus4nxmekkumouptg5ezad.onion:18333",
+	"7zlqrihb5do5ebbmjwgspxigqfdmkfslkqtg2ngdc6ypsunzb4iootqd.onion:18333",
+	"aesy6tfufadkut6flu2bsqgnw2422ur2ynjalguxlzuzuktg3zehttqd.onion:18333",
+	"ayx35r2mhwydczzoqu7b6dl3sup4oht74sgnlrjkxybzh2hmfnaix3qd.onion:18333",
+	"bizdwmm7naqq5pehkbfggznqtena5eodz3kprvsbj4nkkax4fvxx53id.onion:18333",
+	"bjqttlyt5kxcn7sitpiavf2cuhdf6rlwigericlh7um7gzkqyyefqnqd.onion:18333",
+	"c6pk75ti4mjmoumlnxjwrcyr6u2qoltf3ywk2odweupz3sr55gqg6pqd.onion:18333",
+	"devwork6shguhs6miygeq7qpyszu2lizeyop33sbrey7hk53jw26hnad.onion:18333",
+	"dskf46hfkefyr6nio5gtqp7em7rh6aqwrugdegfooaoxcux5ym6bkuqd.onion:18333",
+	"f472j4pynbgltb63mr2lhyvhecfqdjdcfmr2s2id4rreemyn32xtjuid.onion:18333",
+	"fl5wdp4jleymf2efplmos6hbj7py7nvwaps2yzfa6sfhvk6e7bd4umid.onion:18333",
+	"gggan3nuzxpd655lafnsunqfxgy56bvsxuuzucgmaowyvq3d7a7o7jad.onion:18333",
+	"ghqbqp3dirya7espnj3m3kh6mhalqxjv75muum4seukopedtwysxc2qd.onion:18333",
+	"gsw6sn27quwf6u3swgra6o7lrp5qau6kt3ymuyoxgkth6wntzm2bjwyd.onion:18333",
+	"guudgx24aokistytvm3hj7u5cbvdkr2b643r5t33pa536ekfn2lfykid.onion:18333",
+	"gwtx5qluopwaxjhedlifnr6pptw2eihojzhq3stnv2gfbvqbgqdhi5qd.onion:18333",
+	"hjwzovgwou3py6ncemgmzpvcihay5cuzuxvqdn74axndmll25p4mwjqd.onion:18333",
+	"hvbmmzvqrpgps2x5u4ip4ksf3e5m2fneac754gtnhjn2rsevni6cz3ad.onion:18333",
+	"iradeaffnpqfhkuo4rqbq5dgdazymxyjifeb6jz4yq7rgqhnsflfhzqd.onion:18333",
+	"j53y5paeb7xcrptntut7epccjglduquldjlzjlho4lj2msabnygrljqd.onion:18333",
+	"jbgve7zi4vx5l7654ih4nzutspu36dvs66xlegciur2cpym4gdjigbyd.onion:18333",
+	"jtvqgaccxbtzshtulk5xlsb4wkoil3psmpn5avqyp2i5vm4hqh5cicyd.onion:18333",
+	"jun6gdmbgasr57bhr4zs3dfhc6pzpaw27nqb4dadbai7yrbq55zskeid.onion:18333",
+	"kamanho5clcpn5l3sc6ih3vb4skmfhgxw3h2mgs7e5idfezu5dw5oxyd.onion:18333",
+	"lh3wfz2n7xe2muwdjrz5vgnjwjx7lc6iifojhr2rh53ojkwo5dr66uyd.onion:18333",
+	"lka74booetkbmat2q64haawcytbwx7mnctvo63ww4jgi4yuqnl6p3kad.onion:18333",
+	"lwfecq35ocqjfbdq4h2qmcwl3cfcyiyycxtvrz36ihdbuczehi3bmrad.onion:18333",
+	"mdj4zxy5afohbhm5qdwcbzzpqsdi5w6ur24obkkjcctzj76dgtkedqqd.onion:18333",
+	"mh2bq22jchtolmufub5abodq2l36kmrg26owlb634hsv5h5eagyuekyd.onion:18333",
+	"nkqgfcw3esmr2nbflt5h4zhfhrzqh2txoh2crq6cyq3as5dra7m4vkyd.onion:18333",
+	"nyrekcbm2ici6wv2umogtmwg7o2ivu4vm7bpcge5fayos5l377zldlqd.onion:18333",
+	"o3zwhskjvuzbti3su3kfspgmvwoiq5iqw4drgo6jv6ehv2zfoyyy5nqd.onion:18333",
+	"o4fnldblck6fmycg55lh77owlw7wihb7qltjibantj45ijhow73otdad.onion:18333",
+	"o4zdqfdoe4jfvn35twqtjkzmptmk3bsg45sp46wuxanceg3euqtwdjqd.onion:18333",
+	"pcbipn5yyvhsedfjjywrcep4b5z43px7duemxvs7e6tvanxgw46ux7ad.onion:18333",
+	"ppe2pepzphox3swftj5lbyi7ckigspbih5qnyuhrb3r6bn3g7k7bp4qd.onion:18333",
+	"q2kkfl42elsu3f4c4yqxbgqc4tn3khtwdomi6ofznta3d22iits4i5id.onion:18333",
+	"qiep4hvuovedbbc36hl7nwslwi6ah6uw4nnseyjdtc73cc5rfdauvnad.onion:18333",
+	"rw6reu6rdetz6rnpzoyn2hfy7sxpbja4v5ktmaicqqgszi2eata5lhqd.onion:18333",
+	"s3bxrurro4bmvsmntw74qf4vw3xl25xtnvqegayvlwj3b6t7d76kkwid.onion:18333",
+	"t5pvdtzutdegthjxvnhlqnnuurdrktz7vb3m45sisvrjmixxrbsrjfad.onion:18333",
+	"t6hfkkujktjyzpepmphskoaivflu6fieyuxrshxfzwriwvoa2uccvvqd.onion:18333",
+	"ueyykmnyqk2bnmbgvmqrb4jfbjvpgeew6cuq4gaqe7v2oiyxaubkx4qd.onion:18333",
+	"urhagmjceyqbzhjitpa7j7mygivxw3wlwqzkibw6zezrxeantkikgcyd.onion:18333",
+	"v7blnsimaxbfuh33xdi5d6zjbpfcoz565kdpgjkdtbwjxxt5clxl4dad.onion:18333",
+	"vctlwaqgmu53eutz2hewuakcipfgtyljsd7czut4dd62xr3rp6fqezad.onion:18333",
+	"vi3z4khdvopuiljdjdhpetnxztuzsct2aacuzcm5ba27h4j3cfwsvjad.onion:18333",
+	"wgc4wp25gtnb54vd7x5h2xtetzrdoiizeyyh6fv3vxzp3bbalejbd3ad.onion:18333",
+	"wqx56diehvnwca6mndqw3omsufbiem3o6lwivauiilxfx5iqsv7emfad.onion:18333",
+	"xlnixiuutnml2gygxvgdmpdapov2pq4ssicsaavsrclihcacqwhd3dyd.onion:18333",
+	"xlx7k5qzawdld5xcfd5lhhasp5tiklc2z326lamcnkmoru56jv6os6ad.onion:18333",
+	"y4blifygwjgepjmnr6wlbqpgies4c2bdudifcfbplqnbjwzs2zrdnvyd.onion:18333",
+	"y5h652jz6sgmb4bkcfnfxnj24gnczqf7lhd5lqbvdhf5mpll4gye5kid.onion:18333",
+	"y7xjvb7gns27vb2titz3wzlkkhk6itzn2lsu6yopo6ggj3u5ybebdayd.onion:18333",
+	"ya6s7ov7myixz3ql23u45hty7moxo3r4d26qainw55z656vtau676xid.onion:18333",
+	"ywpmgq5kdtlnba4nb2oebj3shucvx2hzhgmqlrkkvpp3t75d2dfxtiid.onion:18333",
+	"zcsn3j6aswnrf56xj2n5jn4tlwmyoq4benjn5ujcgz46co2y5tm642id.onion:18333",
+	"zefnna2a3ga4ez2nutvypma7my35prw3ycinbqwva7v4pf3aurqhjcyd.onion:18333",
+	"zhiju2obxifqpjbcm6xtlgjdbof7jhoctvw3x57vhiftstb5hi3gmsqd.onion:18333",
+	"zsmxmusdzrcqusk5h6syfq6a3ph6lwx3wpiltun4dmkrt3pa2fp7qaid.onion:18333",
+}
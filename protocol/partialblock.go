@@ -0,0 +1,124 @@
+package protocol
+
+import (
+	"github.com/pkg/errors"
+)
+
+// PartialBlock はコンパクトブロックからブロックを復元する途中の状態を表す型
+// メモリプールのトランザクションで埋まらなかったものはgetblocktxnで要求して埋めます
+// https://github.com/bitcoin/bips/blob/master/bip-0152.mediawiki#implementation-notes
+type PartialBlock struct {
+	header BlockHeader
+	// ブロック内の位置ごとのトランザクション、nilはまだ見つかっていないもの
+	txs []*Tx
+}
+
+// NewPartialBlock はコンパクトブロックとメモリプールのトランザクションからPartialBlockを生成します
+// useWTxIDはsendcmpctで合意したバージョンが2の場合にtrueにします
+// ショートIDが重複している場合はErrReconstructBlockを返すのでブロック全体を要求します
+func NewPartialBlock(cmpct *HeaderAndShortIDs, mempool []*Tx, useWTxID bool) (*PartialBlock, error) {
+	count := cmpct.TxCount()
+	if count == 0 || maxCompactBlockTxs < count {
+		return nil, errors.Errorf("コンパクトブロックのトランザクションの数が不正です: count=%d", count)
+	}
+	b := &PartialBlock{
+		header: cmpct.Header,
+		txs:    make([]*Tx, count),
+	}
+
+	for _, p := range cmpct.PrefilledTxs {
+		if count <= int(p.Index) || b.txs[p.Index] != nil {
+			return nil, errors.Errorf("事前に含めたトランザクションのインデックスが不正です: index=%d", p.Index)
+		}
+		b.txs[p.Index] = p.Tx
+	}
+
+	// ショートIDからブロック内の位置を引けるようにする
+	positions := make(map[ShortTxID]int, len(cmpct.ShortIDs))
+	pos := 0
+	for _, id := range cmpct.ShortIDs {
+		for b.txs[pos] != nil {
+			pos++
+		}
+		if _, ok := positions[id]; ok {
+			return nil, errors.Wrapf(ErrReconstructBlock, "ショートIDが重複しています: %012x", uint64(id))
+		}
+		positions[id] = pos
+		pos++
+	}
+
+	// メモリプールで同じショートIDを持つトランザクションが複数見つかった位置は要求し直す
+	collided := map[int]bool{}
+	k0, k1 := cmpct.ShortIDKeys()
+	for _, tx := range mempool {
+		pos, ok := positions[NewShortTxID(k0, k1, txHashForShortID(tx, useWTxID))]
+		if !ok || collided[pos] {
+			continue
+		}
+		if b.txs[pos] != nil {
+			b.txs[pos] = nil
+			collided[pos] = true
+			continue
+		}
+		b.txs[pos] = tx
+	}
+	return b, nil
+}
+
+// BlockHash は復元しているブロックのハッシュを返します
+func (b *PartialBlock) BlockHash() Hash {
+	return b.header.BlockHash()
+}
+
+// MissingIndexes はメモリプールから見つからなかったトランザクションのインデックスを返します
+func (b *PartialBlock) MissingIndexes() []uint32 {
+	var indexes []uint32
+	for i, tx := range b.txs {
+		if tx == nil {
+			indexes = append(indexes, uint32(i))
+		}
+	}
+	return indexes
+}
+
+// IsComplete は全てのトランザクションが揃っているか判定します
+func (b *PartialBlock) IsComplete() bool {
+	return len(b.MissingIndexes()) == 0
+}
+
+// NewMsgGetBlockTxn は足りないトランザクションを要求するgetblocktxnを生成します
+func (b *PartialBlock) NewMsgGetBlockTxn() *MsgGetBlockTxn {
+	return NewMsgGetBlockTxn(b.BlockHash(), b.MissingIndexes())
+}
+
+// FillBlock はblocktxnで受け取ったトランザクションで残りを埋めてブロックを復元します
+// 全て揃っている場合はmissingにnilを渡します
+// マークルルートが一致しない場合はショートIDの衝突の可能性があるのでErrReconstructBlockを返します
+func (b *PartialBlock) FillBlock(missing []*Tx) (*Block, error) {
+	block := &Block{
+		Header:       b.header,
+		Transactions: make([]*Tx, len(b.txs)),
+	}
+	next := 0
+	for i, tx := range b.txs {
+		if tx == nil {
+			if len(missing) <= next {
+				return nil, errors.Errorf("トランザクションが足りません: count=%d", len(missing))
+			}
+			tx = missing[next]
+			next++
+		}
+		block.Transactions[i] = tx
+	}
+	if next != len(missing) {
+		return nil, errors.Errorf("トランザクションが多すぎます: count=%d, missing=%d", len(missing), next)
+	}
+
+	if err := block.CheckMerkleRoot(); err != nil {
+		return nil, errors.Wrap(ErrReconstructBlock, err.Error())
+	}
+	if err := block.CheckWitnessCommitment(); err != nil {
+		return nil, errors.Wrap(ErrReconstructBlock, err.Error())
+	}
+	return block, nil
+}
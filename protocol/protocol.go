@@ -36,16 +36,53 @@ func NetworkTypeMessageMagic(netType core.NetworkType) (MessageMagic, error) {
 	}
 }
 
+// Bytes はメッセージに書き込まれるバイト列としてのマジックを返します
+func (m MessageMagic) Bytes() [4]byte {
+	var b [4]byte
+	defaultByteOrder.PutUint32(b[:], uint32(m))
+	return b
+}
+
 // Version はプロトコルのバージョンを表す型
 // https://bitcoin.org/en/developer-reference#protocol-versions
 type Version int32
 
 const (
 	// CurrentVersion はサポートしているプロトコルのバージョンになります
-	// Bitcoin Core 0.13.2 (Jan 2017)
-	CurrentVersion Version = 70015
+	// Bitcoin Core 0.21.0 (Jan 2021)
+	CurrentVersion Version = 70016
+
+	// AddrTimeVersion はaddrのアドレスにタイムスタンプが付くようになったバージョン
+	AddrTimeVersion Version = 31402
+	// BIP37Version はversionにRelayが追加されたバージョン
+	BIP37Version Version = 70001
+	// SendHeadersVersion はsendheadersが追加されたバージョン(BIP130)
+	SendHeadersVersion Version = 70012
+	// FeeFilterVersion はfeefilterが追加されたバージョン(BIP133)
+	FeeFilterVersion Version = 70013
+	// CompactBlocksVersion はsendcmpctとcmpctblockが追加されたバージョン(BIP152)
+	CompactBlocksVersion Version = 70014
+	// WTxIDRelayVersion はwtxidrelayとsendaddrv2が追加されたバージョン(BIP339, BIP155)
+	WTxIDRelayVersion Version = 70016
 )
 
+// NegotiateVersion は自身とピアのバージョンから通信に使うプロトコルのバージョンを決めます
+// 低い方のバージョンに合わせます
+func NegotiateVersion(local, remote Version) Version {
+	if remote < local {
+		return remote
+	}
+	return local
+}
+
+// checkVersion はメッセージを送受信できるプロトコルのバージョンか検証する
+func checkVersion(command string, pver, minVersion Version) error {
+	if pver < minVersion {
+		return errors.Errorf("このプロトコルのバージョンでは使えないメッセージです: command=%s, version=%d < %d", command, pver, minVersion)
+	}
+	return nil
+}
+
 // VarUint は可変長の符号なし数値を表す型
 type VarUint uint64
 
@@ -62,6 +99,10 @@ var defaultByteOrder = binary.LittleEndian
 // Bitcoin Coreはメッセージのフィールドごとに決めているここでは決め打ちしておく
 const maxStringLength = 0xffff
 
+// maxBytesLength は可変長のバイト列のサイズの最大値
+// スクリプトなどメッセージに1つしか含まれないものもあるのでメッセージの最大サイズにしておく
+const maxBytesLength = messageMaxSize
+
 // 可変長数値の識別子を定義
 const (
 	varUint8Max        = 0xfc
@@ -74,13 +115,46 @@ const (
 // https://en.bitcoin.it/wiki/Protocol_documentation#version
 type ServiceFlags uint64
 
-// const (
-// 	NodeNetwork       ServiceFlags = 1 << iota
-// 	NodeGetUTXO                    = 2
-// 	NodeBloom                      = 4
-// 	NodeWitness                    = 8
-// 	NodeNetworkLimted              = 1024
-// )
+const (
+	// NodeNetwork は全てのブロックを提供するノード
+	NodeNetwork ServiceFlags = 1 << 0
+	// NodeGetUTXO はBIP64のgetutxosに対応するノード
+	NodeGetUTXO ServiceFlags = 1 << 1
+	// NodeBloom はBIP37のブルームフィルタに対応するノード
+	NodeBloom ServiceFlags = 1 << 2
+	// NodeWitness はBIP144のウィットネスを含むブロックとトランザクションを提供するノード
+	NodeWitness ServiceFlags = 1 << 3
+	// NodeCompactFilters はBIP157のコンパクトブロックフィルタを提供するノード
+	NodeCompactFilters ServiceFlags = 1 << 6
+	// NodeNetworkLimited は直近288ブロックだけを提供するノード(BIP159)
+	NodeNetworkLimited ServiceFlags = 1 << 10
+	// NodeP2PV2 はBIP324の暗号化通信に対応するノード
+	NodeP2PV2 ServiceFlags = 1 << 11
+)
+
+// Has は指定したサービスを全て提供しているか判定します
+func (f ServiceFlags) Has(services ServiceFlags) bool {
+	return f&services == services
+}
+
+// MessageEncoding はトランザクションを含むメッセージのシリアライズ形式を表す型
+type MessageEncoding int
+
+const (
+	// WitnessEncoding はBIP144のウィットネスを含む形式
+	WitnessEncoding MessageEncoding = iota
+	// BaseEncoding はウィットネスを含まない従来の形式
+	BaseEncoding
+)
+
+// EncodingForServices はピアが提供するサービスに応じたシリアライズ形式を返します
+// NodeWitnessを提供していないピアにはウィットネスを含めずに送ります
+func EncodingForServices(services ServiceFlags) MessageEncoding {
+	if services.Has(NodeWitness) {
+		return WitnessEncoding
+	}
+	return BaseEncoding
+}
 
 // NetPort はネットワークアドレスのポート番号を表す型
 type NetPort uint16
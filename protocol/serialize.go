@@ -13,7 +13,7 @@ import (
 // Serialize は値をプロトコルに応じたデータに変換してwに書き込みます
 func Serialize(w io.Writer, i interface{}) error {
 	switch v := i.(type) {
-	case bool, int8, int16, int32, int64, uint8, uint16, uint32, uint64, MessageMagic, ServiceFlags, Version, [16]byte, [messageCommandSize]byte, [messageChecksumSize]byte:
+	case bool, int8, int16, int32, int64, uint8, uint16, uint32, uint64, MessageMagic, ServiceFlags, Version, [16]byte, [messageCommandSize]byte, [messageChecksumSize]byte, Hash, InvType:
 		return binary.Write(w, defaultByteOrder, v)
 
 	case Uint32Time:
@@ -23,7 +23,7 @@ func Serialize(w io.Writer, i interface{}) error {
 		return Serialize(w, int64(time.Time(v).Unix()))
 
 	case NetPort:
-		return binary.Write(w, binary.LittleEndian, v)
+		return binary.Write(w, binary.BigEndian, v)
 
 	case VarUint:
 		return serializeVarUint(w, v)
@@ -31,6 +31,9 @@ func Serialize(w io.Writer, i interface{}) error {
 	case string:
 		return serializeString(w, v)
 
+	case []byte:
+		return serializeBytes(w, v)
+
 	case UserAgentName:
 		return serializeString(w, string(v))
 
@@ -49,7 +52,7 @@ func Deserialize(r io.Reader, i interface{}) error {
 	case *bool, *int8, *int16, *int32,
 		*int64, *uint8, *uint16, *uint32, *uint64,
 		*MessageMagic, *ServiceFlags, *Version,
-		*[16]byte, *[messageCommandSize]byte, *[messageChecksumSize]byte:
+		*[16]byte, *[messageCommandSize]byte, *[messageChecksumSize]byte, *Hash, *InvType:
 
 		if err := binary.Read(r, defaultByteOrder, p); err != nil {
 			return errors.Wrapf(err, "読み込みに失敗しました: %T", p)
@@ -70,7 +73,7 @@ func Deserialize(r io.Reader, i interface{}) error {
 		*p = Int64Time(time.Unix(int64(v), 0))
 
 	case *NetPort:
-		if err := binary.Read(r, binary.LittleEndian, p); err != nil {
+		if err := binary.Read(r, binary.BigEndian, p); err != nil {
 			return errors.Wrapf(err, "読み込みに失敗しました: %T", p)
 		}
 
@@ -80,6 +83,9 @@ func Deserialize(r io.Reader, i interface{}) error {
 	case *string:
 		return deserializeString(r, p)
 
+	case *[]byte:
+		return deserializeBytes(r, p)
+
 	case *UserAgentName:
 		var s string
 		if err := deserializeString(r, &s); err != nil {
@@ -218,6 +224,35 @@ func deserializeString(r io.Reader, p *string) error {
 	return nil
 }
 
+// serializeBytes は可変長のバイト列をシリアライズします
+func serializeBytes(w io.Writer, v []byte) error {
+	if err := serializeVarUint(w, VarUint(len(v))); err != nil {
+		return err
+	}
+	if _, err := w.Write(v); err != nil {
+		return errors.Wrap(err, "Bytesの書き込みに失敗しました")
+	}
+	return nil
+}
+
+// deserializeBytes は可変長のバイト列をデシリアライズします
+func deserializeBytes(r io.Reader, p *[]byte) error {
+	var len VarUint
+	if err := deserializeVarUint(r, &len); err != nil {
+		return err
+	}
+	if maxBytesLength < len {
+		return errors.Errorf("Bytesが読み込める最大長を超えました: length=%d", len)
+	}
+
+	buf := make([]byte, len)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return errors.Wrapf(err, "Bytesの読み込みに失敗しました: length=%d", len)
+	}
+	*p = buf
+	return nil
+}
+
 // serializeIP はIPAddressをシリアライズします
 func serializeNetAddress(w io.Writer, v NetAddress) error {
 	var ip [16]byte
@@ -0,0 +1,308 @@
+package protocol
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// トランザクションのデータ構造
+// https://en.bitcoin.it/wiki/Protocol_documentation#tx
+
+const (
+	// TxVersion はこのクライアントが作成するトランザクションのバージョン
+	// BIP68の相対ロックタイムを使うためにバージョン2にします
+	TxVersion int32 = 2
+
+	// TRUCTxVersion はBIP431のTRUCトランザクションのバージョン
+	// エフェメラルアンカーを持つトランザクションはこのバージョンにする必要があります
+	TRUCTxVersion int32 = 3
+
+	// MaxTxInSequenceNum はTxInのSequenceの最大値
+	// 全てのTxInがこの値の場合はロックタイムが無効になります
+	MaxTxInSequenceNum uint32 = 0xffffffff
+
+	// MaxBIP125Sequence はBIP125でRBFを通知するSequenceの最大値
+	// これ以下のSequenceを持つTxInが一つでもあればトランザクションは置き換え可能になります
+	MaxBIP125Sequence uint32 = 0xfffffffd
+
+	// LockTimeThreshold はLockTimeをブロックの高さとして扱うか時刻として扱うかの境界
+	// この値未満はブロックの高さ、以上はUNIX時刻になります
+	LockTimeThreshold uint32 = 500000000
+)
+
+// BIP68の相対ロックタイムで使うSequenceのビット
+// https://github.com/bitcoin/bips/blob/master/bip-0068.mediawiki
+const (
+	// SequenceLockTimeDisabled はこのビットが立っている場合に相対ロックタイムを無効にする
+	SequenceLockTimeDisabled uint32 = 1 << 31
+	// SequenceLockTimeIsSeconds はこのビットが立っている場合に相対ロックタイムを時間として扱う
+	SequenceLockTimeIsSeconds uint32 = 1 << 22
+	// SequenceLockTimeMask は相対ロックタイムの値を取り出すためのマスク
+	SequenceLockTimeMask uint32 = 0x0000ffff
+	// SequenceLockTimeGranularity は時間の相対ロックタイムの単位(2^9=512秒)
+	SequenceLockTimeGranularity = 9
+)
+
+// minTxInSize はTxInの最小のバイトサイズ
+// OutPoint(36) + スクリプト長(1) + Sequence(4)
+const minTxInSize = 41
+
+// minTxOutSize はTxOutの最小のバイトサイズ
+// Value(8) + スクリプト長(1)
+const minTxOutSize = 9
+
+// ウィットネスを含むトランザクションのマーカーとフラグ(BIP144)
+// https://github.com/bitcoin/bips/blob/master/bip-0144.mediawiki
+const (
+	witnessMarker byte = 0x00
+	witnessFlag   byte = 0x01
+)
+
+// maxWitnessItemsPerInput は1つの入力のウィットネスに含められるアイテムの最大数
+// ブロックの最大weightを1バイトのアイテムで埋めた数
+const maxWitnessItemsPerInput = 4000000
+
+// maxPrealloc はデシリアライズで宣言された数に応じて先に確保する要素数の上限
+// 数バイトのデータで大量のメモリを確保させられないように、これを超える分は読み込んだ分だけ追加します
+const maxPrealloc = 1024
+
+// preallocCount は宣言された要素数から先に確保する容量を返す
+func preallocCount(count VarUint) int {
+	if maxPrealloc < count {
+		return maxPrealloc
+	}
+	return int(count)
+}
+
+// OutPoint は使用する前のトランザクションの出力を指し示す型
+type OutPoint struct {
+	Hash  Hash
+	Index uint32
+}
+
+// TxIn はトランザクションの入力を表す型
+type TxIn struct {
+	PreviousOutPoint OutPoint
+	SignatureScript  []byte
+	Sequence         uint32
+	// SegWitの署名などのウィットネスデータ
+	Witness TxWitness
+}
+
+// TxWitness は入力のウィットネスのアイテムの一覧を表す型
+type TxWitness [][]byte
+
+// TxOut はトランザクションの出力を表す型
+type TxOut struct {
+	// 出力の金額(satoshi)
+	Value    int64
+	PkScript []byte
+}
+
+// Tx はトランザクションを表す型
+type Tx struct {
+	Version  int32
+	TxIn     []*TxIn
+	TxOut    []*TxOut
+	LockTime uint32
+}
+
+// NewTx は空のトランザクションを生成します
+func NewTx() *Tx {
+	return &Tx{
+		Version: TxVersion,
+	}
+}
+
+// AddTxIn はトランザクションに入力を追加します
+func (tx *Tx) AddTxIn(in *TxIn) {
+	tx.TxIn = append(tx.TxIn, in)
+}
+
+// AddTxOut はトランザクションに出力を追加します
+func (tx *Tx) AddTxOut(out *TxOut) {
+	tx.TxOut = append(tx.TxOut, out)
+}
+
+// HasWitness はトランザクションの入力にウィットネスを持つものがあるか判定します
+func (tx *Tx) HasWitness() bool {
+	for _, in := range tx.TxIn {
+		if 0 < len(in.Witness) {
+			return true
+		}
+	}
+	return false
+}
+
+// TxHash はウィットネスを含めずにトランザクションのハッシュ(txid)を計算します
+func (tx *Tx) TxHash() Hash {
+	buf := &bytes.Buffer{}
+	// bytes.Bufferへの書き込みは失敗しない
+	_ = tx.SerializeNoWitness(buf)
+	return DoubleHash(buf.Bytes())
+}
+
+// WitnessHash はウィットネスを含めたトランザクションのハッシュ(wtxid)を計算します
+// ウィットネスを持たない場合はtxidと同じになります
+func (tx *Tx) WitnessHash() Hash {
+	buf := &bytes.Buffer{}
+	// bytes.Bufferへの書き込みは失敗しない
+	_ = tx.Serialize(buf)
+	return DoubleHash(buf.Bytes())
+}
+
+// SerializeSize はウィットネスを含めたシリアライズ後のバイトサイズを返します
+func (tx *Tx) SerializeSize() int {
+	buf := &bytes.Buffer{}
+	_ = tx.Serialize(buf)
+	return buf.Len()
+}
+
+// Weight はBIP141のトランザクションのweightを返します
+// ウィットネス以外のデータは4倍、ウィットネスは1倍で数えます
+func (tx *Tx) Weight() int {
+	buf := &bytes.Buffer{}
+	_ = tx.SerializeNoWitness(buf)
+	return buf.Len()*3 + tx.SerializeSize()
+}
+
+// Serialize はトランザクションをシリアライズします
+// ウィットネスを持つ場合はBIP144の形式でシリアライズします
+func (tx *Tx) Serialize(w io.Writer) error {
+	return tx.serialize(w, tx.HasWitness())
+}
+
+// SerializeNoWitness はウィットネスを含めずにトランザクションをシリアライズします
+func (tx *Tx) SerializeNoWitness(w io.Writer) error {
+	return tx.serialize(w, false)
+}
+
+// serialize はトランザクションをシリアライズする
+// BIP144のマーカーとフラグでウィットネスの有無が変わるため、タグを使わずに書いています
+func (tx *Tx) serialize(w io.Writer, witness bool) error {
+	if err := Serialize(w, tx.Version); err != nil {
+		return err
+	}
+	if witness {
+		if err := BulkSerialize(w, witnessMarker, witnessFlag); err != nil {
+			return err
+		}
+	}
+	if err := Serialize(w, VarUint(len(tx.TxIn))); err != nil {
+		return err
+	}
+	for _, in := range tx.TxIn {
+		if err := BulkSerialize(w, in.PreviousOutPoint.Hash, in.PreviousOutPoint.Index, in.SignatureScript, in.Sequence); err != nil {
+			return err
+		}
+	}
+	if err := Serialize(w, VarUint(len(tx.TxOut))); err != nil {
+		return err
+	}
+	for _, out := range tx.TxOut {
+		if err := BulkSerialize(w, out.Value, out.PkScript); err != nil {
+			return err
+		}
+	}
+	if witness {
+		for _, in := range tx.TxIn {
+			if err := Serialize(w, VarUint(len(in.Witness))); err != nil {
+				return err
+			}
+			for _, item := range in.Witness {
+				if err := Serialize(w, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return Serialize(w, tx.LockTime)
+}
+
+// Deserialize はトランザクションをデシリアライズします
+// BIP144の形式の場合はウィットネスも読み込みます
+func (tx *Tx) Deserialize(r io.Reader) error {
+	var count VarUint
+	if err := BulkDeserialize(r, &tx.Version, &count); err != nil {
+		return err
+	}
+
+	// 入力の数が0の場合はウィットネスのマーカーとして扱う
+	witness := false
+	if count == VarUint(witnessMarker) {
+		var flag byte
+		if err := Deserialize(r, &flag); err != nil {
+			return err
+		}
+		if flag != witnessFlag {
+			return errors.Errorf("ウィットネスのフラグが不正です: %#x", flag)
+		}
+		witness = true
+		if err := Deserialize(r, &count); err != nil {
+			return err
+		}
+	}
+
+	if messageMaxSize/minTxInSize < count {
+		return errors.Errorf("TxInの数が多すぎます: count=%d", count)
+	}
+	tx.TxIn = make([]*TxIn, 0, preallocCount(count))
+	for i := VarUint(0); i < count; i++ {
+		in := &TxIn{}
+		if err := BulkDeserialize(r, &in.PreviousOutPoint.Hash, &in.PreviousOutPoint.Index, &in.SignatureScript, &in.Sequence); err != nil {
+			return err
+		}
+		tx.TxIn = append(tx.TxIn, in)
+	}
+
+	if err := Deserialize(r, &count); err != nil {
+		return err
+	}
+	if messageMaxSize/minTxOutSize < count {
+		return errors.Errorf("TxOutの数が多すぎます: count=%d", count)
+	}
+	tx.TxOut = make([]*TxOut, 0, preallocCount(count))
+	for i := VarUint(0); i < count; i++ {
+		out := &TxOut{}
+		if err := BulkDeserialize(r, &out.Value, &out.PkScript); err != nil {
+			return err
+		}
+		tx.TxOut = append(tx.TxOut, out)
+	}
+
+	if witness {
+		for _, in := range tx.TxIn {
+			if err := Deserialize(r, &count); err != nil {
+				return err
+			}
+			if maxWitnessItemsPerInput < count {
+				return errors.Errorf("ウィットネスのアイテムの数が多すぎます: count=%d", count)
+			}
+			in.Witness = make(TxWitness, 0, preallocCount(count))
+			for i := VarUint(0); i < count; i++ {
+				var item []byte
+				if err := Deserialize(r, &item); err != nil {
+					return err
+				}
+				in.Witness = append(in.Witness, item)
+			}
+		}
+		if !tx.HasWitness() {
+			return errors.New("ウィットネスのマーカーがあるのにウィットネスがありません")
+		}
+	}
+
+	return Deserialize(r, &tx.LockTime)
+}
+
+// SignalsReplacement はトランザクションがBIP125のRBFを通知しているか判定します
+func (tx *Tx) SignalsReplacement() bool {
+	for _, in := range tx.TxIn {
+		if in.Sequence <= MaxBIP125Sequence {
+			return true
+		}
+	}
+	return false
+}
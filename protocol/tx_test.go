@@ -0,0 +1,60 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/hex"
+	"runtime"
+	"testing"
+)
+
+// ジェネシスブロックのコインベーストランザクション
+const genesisCoinbaseTx = "01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff4d04ffff001d0104455468652054696d65732030332f4a616e2f32303039204368616e63656c6c6f72206f6e206272696e6b206f66207365636f6e64206261696c6f757420666f722062616e6b73ffffffff0100f2052a01000000434104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5fac00000000"
+
+func TestTx(t *testing.T) {
+	raw, _ := hex.DecodeString(genesisCoinbaseTx)
+
+	tx := &Tx{}
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.TxIn) != 1 || len(tx.TxOut) != 1 {
+		t.Fatalf("入出力の数が一致しません: in=%d, out=%d", len(tx.TxIn), len(tx.TxOut))
+	}
+	if tx.TxOut[0].Value != 5000000000 {
+		t.Errorf("出力の金額が一致しません: %d", tx.TxOut[0].Value)
+	}
+	if tx.SignalsReplacement() {
+		t.Errorf("RBFを通知していないのに置き換え可能と判定されました")
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tx.Serialize(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, buf.Bytes()) {
+		t.Errorf("シリアライズしたトランザクションが一致しません")
+	}
+
+	if txid := tx.TxHash().String(); txid != "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b" {
+		t.Errorf("txidが一致しません: %s", txid)
+	}
+}
+
+func TestTxHugeWitnessCount(t *testing.T) {
+	// ウィットネスのアイテムの数だけ大きく、続きのデータがないトランザクション
+	buf := &bytes.Buffer{}
+	if err := BulkSerialize(buf, int32(2), witnessMarker, witnessFlag, VarUint(1), Hash{}, uint32(0), []byte{}, uint32(0), VarUint(1), int64(0), []byte{}, VarUint(maxWitnessItemsPerInput)); err != nil {
+		t.Fatal(err)
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	if err := (&Tx{}).Deserialize(bytes.NewReader(buf.Bytes())); err == nil {
+		t.Error("途中で終わるトランザクションが読み込めました")
+	}
+	runtime.ReadMemStats(&after)
+	// 宣言された数の分を先に確保しない
+	if allocated := after.TotalAlloc - before.TotalAlloc; 1<<20 < allocated {
+		t.Errorf("確保したメモリが多すぎます: %d", allocated)
+	}
+}
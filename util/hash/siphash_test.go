@@ -0,0 +1,20 @@
+package hash
+
+import "testing"
+
+func TestSipHash(t *testing.T) {
+	// 論文の付録Aのテストベクタ、鍵は00..0f、メッセージは00..0e
+	k0, k1 := uint64(0x0706050403020100), uint64(0x0f0e0d0c0b0a0908)
+	msg := make([]byte, 15)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	if h := SipHash(k0, k1, msg); h != 0xa129ca6149be45e5 {
+		t.Errorf("ハッシュが一致しません: %x", h)
+	}
+
+	// 参照実装のvectors.hの最初の値、空のメッセージ
+	if h := SipHash(k0, k1, nil); h != 0x726fdb47dd0e0e31 {
+		t.Errorf("空のメッセージのハッシュが一致しません: %x", h)
+	}
+}
@@ -0,0 +1,309 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/pkg/errors"
+)
+
+// アカウント構造の仕様
+// https://github.com/bitcoin/bips/blob/master/bip-0044.mediawiki
+// https://github.com/bitcoin/bips/blob/master/bip-0049.mediawiki
+// https://github.com/bitcoin/bips/blob/master/bip-0084.mediawiki
+// https://github.com/bitcoin/bips/blob/master/bip-0086.mediawiki
+
+// HardenedKeyStart はBIP32の強化導出となるインデックスの開始位置
+const HardenedKeyStart uint32 = 0x80000000
+
+// DefaultGapLimit はアドレス探索時に未使用アドレスが何個続いたら探索を打ち切るかのデフォルト値
+// BIP44で推奨されている値
+const DefaultGapLimit = 20
+
+// Purpose はBIP43で定義される導出パスの最初の階層を表す型
+// どのスクリプト形式のアドレスを導出するかを決めます
+type Purpose uint32
+
+const (
+	// PurposeBIP44 はP2PKHアドレスを導出するアカウント
+	PurposeBIP44 Purpose = 44
+	// PurposeBIP49 はP2SH-P2WPKHアドレスを導出するアカウント
+	PurposeBIP49 Purpose = 49
+	// PurposeBIP84 はP2WPKHアドレスを導出するアカウント
+	PurposeBIP84 Purpose = 84
+	// PurposeBIP86 はP2TRアドレスを導出するアカウント
+	PurposeBIP86 Purpose = 86
+)
+
+// ScriptType はアカウントが導出するアドレスのスクリプト形式を表す型
+type ScriptType int
+
+const (
+	// ScriptTypeP2PKH はレガシーなP2PKH形式
+	ScriptTypeP2PKH ScriptType = iota
+	// ScriptTypeP2SHP2WPKH はP2SHでラップしたP2WPKH形式
+	ScriptTypeP2SHP2WPKH
+	// ScriptTypeP2WPKH はネイティブSegWitのP2WPKH形式
+	ScriptTypeP2WPKH
+	// ScriptTypeP2TR はTaprootのP2TR形式
+	ScriptTypeP2TR
+)
+
+// ScriptType はPurposeに対応するスクリプト形式を返します
+func (p Purpose) ScriptType() (ScriptType, error) {
+	switch p {
+	case PurposeBIP44:
+		return ScriptTypeP2PKH, nil
+	case PurposeBIP49:
+		return ScriptTypeP2SHP2WPKH, nil
+	case PurposeBIP84:
+		return ScriptTypeP2WPKH, nil
+	case PurposeBIP86:
+		return ScriptTypeP2TR, nil
+	default:
+		return 0, errors.Errorf("未対応のPurposeです: %d", p)
+	}
+}
+
+// Chain はアカウント内のチェーンを表す型
+// 受け取り用とお釣り用でアドレスを分けて導出します
+type Chain uint32
+
+const (
+	// ExternalChain は外部から受け取るためのアドレスのチェーン
+	ExternalChain Chain = 0
+	// InternalChain はお釣りを受け取るためのアドレスのチェーン
+	InternalChain Chain = 1
+)
+
+// validate はアカウントが持つチェーンかどうかを検証する
+func (c Chain) validate() error {
+	if c != ExternalChain && c != InternalChain {
+		return errors.Errorf("未対応のチェーンです: %d", c)
+	}
+	return nil
+}
+
+// CoinType はネットワークタイプに対応するSLIP44のコインタイプを返します
+// https://github.com/satoshilabs/slips/blob/master/slip-0044.md
+func CoinType(netType core.NetworkType) (uint32, error) {
+	switch netType {
+	case core.MainNetwork:
+		return 0, nil
+	case core.TestNetwork:
+		return 1, nil
+	default:
+		return 0, errors.Errorf("invalid NetworkType: %v", netType)
+	}
+}
+
+// DerivationPath はBIP32の導出パスを表す型
+type DerivationPath []uint32
+
+// NewDerivationPath はpurpose/coin_type'/account'/change/address_indexの導出パスを生成します
+// purpose、coin_type、accountは強化導出になります
+// 各階層の値はHardenedKeyStart未満でなければなりません
+func NewDerivationPath(purpose Purpose, coinType, account uint32, chain Chain, index uint32) (DerivationPath, error) {
+	if err := chain.validate(); err != nil {
+		return nil, err
+	}
+	for _, i := range []uint32{uint32(purpose), coinType, account, index} {
+		if HardenedKeyStart <= i {
+			return nil, errors.Errorf("導出パスのインデックスが大きすぎます: %d", i)
+		}
+	}
+	return DerivationPath{
+		uint32(purpose) + HardenedKeyStart,
+		coinType + HardenedKeyStart,
+		account + HardenedKeyStart,
+		uint32(chain),
+		index,
+	}, nil
+}
+
+// String は導出パスを「m/84'/0'/0'/0/1」の形式の文字列で返します
+func (p DerivationPath) String() string {
+	elems := []string{"m"}
+	for _, i := range p {
+		if HardenedKeyStart <= i {
+			elems = append(elems, fmt.Sprintf("%d'", i-HardenedKeyStart))
+		} else {
+			elems = append(elems, fmt.Sprintf("%d", i))
+		}
+	}
+	return strings.Join(elems, "/")
+}
+
+// Account はBIP44形式のアカウントを表す型
+// チェーンごとに次に使うべき未使用のインデックスを保持します
+type Account struct {
+	Purpose  Purpose
+	CoinType uint32
+	Index    uint32
+
+	nextIndex [2]uint32
+}
+
+// Path はアカウント内の指定したアドレスの導出パスを返します
+func (a *Account) Path(chain Chain, index uint32) (DerivationPath, error) {
+	return NewDerivationPath(a.Purpose, a.CoinType, a.Index, chain, index)
+}
+
+// NextIndex はチェーンで次に使う未使用のインデックスを返します
+func (a *Account) NextIndex(chain Chain) (uint32, error) {
+	if err := chain.validate(); err != nil {
+		return 0, err
+	}
+	return a.nextIndex[chain], nil
+}
+
+// NextPath はチェーンで次に使う未使用アドレスの導出パスを返します
+func (a *Account) NextPath(chain Chain) (DerivationPath, error) {
+	index, err := a.NextIndex(chain)
+	if err != nil {
+		return nil, err
+	}
+	return a.Path(chain, index)
+}
+
+// MarkUsed は指定したインデックスのアドレスが使用済みになったことを記録します
+// 強化導出のインデックスはアドレスに使わないのでエラーになります
+func (a *Account) MarkUsed(chain Chain, index uint32) error {
+	if err := chain.validate(); err != nil {
+		return err
+	}
+	if HardenedKeyStart <= index {
+		return errors.Errorf("アドレスのインデックスが大きすぎます: %d", index)
+	}
+	if a.nextIndex[chain] <= index {
+		a.nextIndex[chain] = index + 1
+	}
+	return nil
+}
+
+// isUsed はアカウント内で一つでも使用済みのアドレスがあるか判定する
+func (a *Account) isUsed() bool {
+	return a.nextIndex[ExternalChain] != 0 || a.nextIndex[InternalChain] != 0
+}
+
+// ChainBackend はアドレスの利用状況をチェーンに問い合わせるためのインターフェース
+type ChainBackend interface {
+	// 出力スクリプトが一度でもチェーン上で使われたことがあるかを返す
+	IsUsed(pkScript []byte) (bool, error)
+}
+
+// ScriptDeriver は導出パスから出力スクリプトを求めるためのインターフェース
+type ScriptDeriver interface {
+	// 導出パスの鍵から指定したスクリプト形式の出力スクリプトを返す
+	DeriveScript(path DerivationPath, scriptType ScriptType) ([]byte, error)
+}
+
+// AccountManager はPurposeごとのアカウントを管理する型
+type AccountManager struct {
+	// 未使用アドレスがこの数だけ続いたら探索を打ち切る
+	GapLimit int
+
+	coinType uint32
+	accounts map[Purpose][]*Account
+}
+
+// NewAccountManager はネットワークタイプに応じたAccountManagerを生成します
+func NewAccountManager(netType core.NetworkType) (*AccountManager, error) {
+	coinType, err := CoinType(netType)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountManager{
+		GapLimit: DefaultGapLimit,
+		coinType: coinType,
+		accounts: map[Purpose][]*Account{},
+	}, nil
+}
+
+// Accounts はPurposeに属するアカウントの一覧を返します
+func (m *AccountManager) Accounts(purpose Purpose) []*Account {
+	return m.accounts[purpose]
+}
+
+// NewAccount はPurposeに次のインデックスのアカウントを追加します
+func (m *AccountManager) NewAccount(purpose Purpose) (*Account, error) {
+	if _, err := purpose.ScriptType(); err != nil {
+		return nil, err
+	}
+	if HardenedKeyStart <= uint32(len(m.accounts[purpose])) {
+		return nil, errors.New("これ以上アカウントを追加できません")
+	}
+	a := &Account{
+		Purpose:  purpose,
+		CoinType: m.coinType,
+		Index:    uint32(len(m.accounts[purpose])),
+	}
+	m.accounts[purpose] = append(m.accounts[purpose], a)
+	return a, nil
+}
+
+// Discover はシードからの復元時に使用済みのアカウントとアドレスを探索します
+// BIP44のアカウント探索に従い、使用済みアドレスが一つもないアカウントが見つかるまでアカウントを追加します
+// ただしアカウント0は使用されていなくても必ず作成します
+func (m *AccountManager) Discover(backend ChainBackend, deriver ScriptDeriver, purposes ...Purpose) error {
+	if m.GapLimit <= 0 {
+		return errors.Errorf("GapLimitが不正です: %d", m.GapLimit)
+	}
+	for _, purpose := range purposes {
+		scriptType, err := purpose.ScriptType()
+		if err != nil {
+			return err
+		}
+		// 既存のアカウントは探索済みとして次のアカウントから探す
+		for index := uint32(len(m.accounts[purpose])); ; index++ {
+			a := &Account{
+				Purpose:  purpose,
+				CoinType: m.coinType,
+				Index:    index,
+			}
+			for _, chain := range []Chain{ExternalChain, InternalChain} {
+				if err := m.scanChain(a, chain, scriptType, backend, deriver); err != nil {
+					return err
+				}
+			}
+			if index != 0 && !a.isUsed() {
+				break
+			}
+			m.accounts[purpose] = append(m.accounts[purpose], a)
+		}
+	}
+	return nil
+}
+
+// scanChain はチェーン上のアドレスをギャップリミットに達するまで探索する
+func (m *AccountManager) scanChain(a *Account, chain Chain, scriptType ScriptType, backend ChainBackend, deriver ScriptDeriver) error {
+	start, err := a.NextIndex(chain)
+	if err != nil {
+		return err
+	}
+	gap := 0
+	for index := start; gap < m.GapLimit; index++ {
+		path, err := a.Path(chain, index)
+		if err != nil {
+			return err
+		}
+		pkScript, err := deriver.DeriveScript(path, scriptType)
+		if err != nil {
+			return errors.Wrapf(err, "スクリプトの導出に失敗しました: %s", path)
+		}
+		used, err := backend.IsUsed(pkScript)
+		if err != nil {
+			return errors.Wrapf(err, "アドレスの利用状況の取得に失敗しました: %s", path)
+		}
+		if used {
+			if err := a.MarkUsed(chain, index); err != nil {
+				return err
+			}
+			gap = 0
+		} else {
+			gap++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package wallet
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/keiji0/btcwallet/core"
+)
+
+// fakeBackend は使用済みのスクリプトを保持するだけのテスト用チェーンバックエンド
+type fakeBackend struct {
+	used map[string]bool
+}
+
+func newFakeBackend(paths ...DerivationPath) *fakeBackend {
+	b := &fakeBackend{used: map[string]bool{}}
+	for _, path := range paths {
+		b.used[path.String()] = true
+	}
+	return b
+}
+
+func (b *fakeBackend) IsUsed(pkScript []byte) (bool, error) {
+	return b.used[string(pkScript)], nil
+}
+
+// fakeDeriver は導出パスの文字列をそのままスクリプトとして返すテスト用の導出器
+type fakeDeriver struct{}
+
+func (fakeDeriver) DeriveScript(path DerivationPath, scriptType ScriptType) ([]byte, error) {
+	return []byte(path.String()), nil
+}
+
+// mustPath はテスト用に導出パスを生成する
+func mustPath(t *testing.T, purpose Purpose, coinType, account uint32, chain Chain, index uint32) DerivationPath {
+	path, err := NewDerivationPath(purpose, coinType, account, chain, index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDerivationPath(t *testing.T) {
+	path := mustPath(t, PurposeBIP84, 0, 1, InternalChain, 5)
+	if path.String() != "m/84'/0'/1'/1/5" {
+		t.Errorf("導出パスの文字列が一致しません: %s", path)
+	}
+
+	// 強化導出の範囲のインデックスは導出パスにできない
+	if _, err := NewDerivationPath(PurposeBIP84, 0, HardenedKeyStart, ExternalChain, 0); err == nil {
+		t.Error("範囲外のアカウントの導出パスを生成できました")
+	}
+	if _, err := NewDerivationPath(PurposeBIP84, 0, 0, ExternalChain, HardenedKeyStart); err == nil {
+		t.Error("範囲外のインデックスの導出パスを生成できました")
+	}
+	if _, err := NewDerivationPath(PurposeBIP84, 0, 0, 2, 0); err == nil {
+		t.Error("未対応のチェーンの導出パスを生成できました")
+	}
+}
+
+func TestAccountDiscover(t *testing.T) {
+	m, err := NewAccountManager(core.MainNetwork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := func(account uint32, chain Chain, index uint32) DerivationPath {
+		return mustPath(t, PurposeBIP84, 0, account, chain, index)
+	}
+	backend := newFakeBackend(
+		p(0, ExternalChain, 0),
+		p(0, ExternalChain, 5),
+		// ギャップリミット内なので見つかる
+		p(0, ExternalChain, 25),
+		// 未使用が20個続いた後なので見つからない
+		p(0, ExternalChain, 46),
+		p(0, InternalChain, 2),
+		p(1, InternalChain, 0),
+		// アカウント2が未使用なのでアカウント3は探索されない
+		p(3, ExternalChain, 0),
+	)
+
+	if err := m.Discover(backend, fakeDeriver{}, PurposeBIP84); err != nil {
+		t.Fatal(err)
+	}
+
+	accounts := m.Accounts(PurposeBIP84)
+	if len(accounts) != 2 {
+		t.Fatalf("アカウント数が一致しません: %d", len(accounts))
+	}
+	tests := []struct {
+		account  int
+		chain    Chain
+		expected uint32
+	}{
+		{0, ExternalChain, 26},
+		{0, InternalChain, 3},
+		{1, ExternalChain, 0},
+		{1, InternalChain, 1},
+	}
+	for _, test := range tests {
+		next, err := accounts[test.account].NextIndex(test.chain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next != test.expected {
+			t.Errorf("次のインデックスが一致しません: account=%d, chain=%d, %d != %d", test.account, test.chain, next, test.expected)
+		}
+	}
+}
+
+func TestAccountDiscoverGapLimit(t *testing.T) {
+	m, err := NewAccountManager(core.TestNetwork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.GapLimit = 5
+	backend := newFakeBackend(
+		mustPath(t, PurposeBIP86, 1, 0, ExternalChain, 4),
+		mustPath(t, PurposeBIP86, 1, 0, ExternalChain, 10),
+	)
+	if err := m.Discover(backend, fakeDeriver{}, PurposeBIP86); err != nil {
+		t.Fatal(err)
+	}
+	accounts := m.Accounts(PurposeBIP86)
+	if len(accounts) != 1 {
+		t.Fatalf("アカウント数が一致しません: %d", len(accounts))
+	}
+	if next, err := accounts[0].NextIndex(ExternalChain); err != nil || next != 5 {
+		t.Errorf("次のインデックスが一致しません: %d %v", next, err)
+	}
+	if path, err := accounts[0].NextPath(ExternalChain); err != nil || fmt.Sprint(path) != "m/86'/1'/0'/0/5" {
+		t.Errorf("次の導出パスが一致しません: %s %v", path, err)
+	}
+}
+
+func TestAccountInvalidChain(t *testing.T) {
+	a := &Account{Purpose: PurposeBIP84}
+	if _, err := a.NextIndex(2); err == nil {
+		t.Error("未対応のチェーンのインデックスを返しました")
+	}
+	if _, err := a.NextPath(2); err == nil {
+		t.Error("未対応のチェーンの導出パスを返しました")
+	}
+	if err := a.MarkUsed(2, 0); err == nil {
+		t.Error("未対応のチェーンを使用済みにできました")
+	}
+	if err := a.MarkUsed(InternalChain, 3); err != nil {
+		t.Fatal(err)
+	}
+	if next, err := a.NextIndex(InternalChain); err != nil || next != 4 {
+		t.Errorf("次のインデックスが一致しません: %d %v", next, err)
+	}
+
+	// 次のインデックスが0に戻らないように範囲外のインデックスは拒否する
+	for _, index := range []uint32{HardenedKeyStart, math.MaxUint32} {
+		if err := a.MarkUsed(ExternalChain, index); err == nil {
+			t.Errorf("範囲外のインデックスを使用済みにできました: %d", index)
+		}
+	}
+	if next, err := a.NextIndex(ExternalChain); err != nil || next != 0 {
+		t.Errorf("次のインデックスが一致しません: %d %v", next, err)
+	}
+}
@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"bytes"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/keiji0/btcwallet/util/hash"
+)
+
+// アンカー出力は子トランザクションで手数料を上乗せ(CPFP)するための出力です
+// https://github.com/bitcoin/bitcoin/pull/30239
+// https://github.com/lightning/bolts/blob/master/03-transactions.md#to_local_anchor-and-to_remote_anchor-output-option_anchors
+
+// AnchorOutputValue はLightningのアンカー出力の金額
+const AnchorOutputValue = 330
+
+// TRUCMaxVSize はBIP431のTRUCトランザクションの仮想サイズの上限
+// https://github.com/bitcoin/bips/blob/master/bip-0431.mediawiki
+const TRUCMaxVSize = 10000
+
+// anchorCSVDelay はLightningのアンカー出力を誰でも使用できるようになるまでのブロック数
+const anchorCSVDelay = 16
+
+// NewPayToAnchorScript は誰でも使用できるP2A(Pay-to-Anchor)の出力スクリプトを返します
+// OP_1 <0x4e73>
+func NewPayToAnchorScript() []byte {
+	return []byte{byte(core.Op1), 0x02, 0x4e, 0x73}
+}
+
+// NewAnchorWitnessScript はLightningのアンカー出力のウィットネススクリプトを返します
+// 公開鍵の持ち主はすぐに、それ以外は16ブロック後に誰でも使用できます
+// <pubKey> OP_CHECKSIG OP_IFDUP OP_NOTIF OP_16 OP_CHECKSEQUENCEVERIFY OP_ENDIF
+func NewAnchorWitnessScript(pubKey []byte) []byte {
+	return core.NewScriptBuilder().
+		AddData(pubKey).
+		AddOp(core.OpCheckSig).
+		AddOp(core.OpIfDup).
+		AddOp(core.OpNotIf).
+		AddInt64(anchorCSVDelay).
+		AddOp(core.OpCheckSequenceVerify).
+		AddOp(core.OpEndIf).
+		Script()
+}
+
+// NewP2WSHScript はウィットネススクリプトのP2WSHの出力スクリプトを返します
+// OP_0 <sha256(witnessScript)>
+func NewP2WSHScript(witnessScript []byte) []byte {
+	return core.NewScriptBuilder().
+		AddOp(core.Op0).
+		AddData(hash.Sha256(witnessScript)).
+		Script()
+}
+
+// AddAnchorOutput はP2Aのアンカー出力を追加します
+// 金額が0の場合はエフェメラルアンカーとなり、トランザクションはTRUC(バージョン3)で手数料を0にする必要があります
+// エフェメラルアンカーを持つトランザクションは他にダストの出力を持てず、仮想サイズはTRUCMaxVSize以下になります
+func (b *TxBuilder) AddAnchorOutput(value int64) {
+	b.AddOutput(value, NewPayToAnchorScript())
+}
+
+// isEphemeralAnchor は金額が0のP2A出力か判定する
+func isEphemeralAnchor(out *protocol.TxOut) bool {
+	return out.Value == 0 && bytes.Equal(out.PkScript, NewPayToAnchorScript())
+}
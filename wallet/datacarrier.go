@@ -0,0 +1,67 @@
+package wallet
+
+import (
+	"bytes"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/keiji0/btcwallet/util/hash"
+	"github.com/pkg/errors"
+)
+
+// MaxDataCarrierSize はリレーされるOP_RETURNの出力スクリプトの最大サイズ
+// OP_RETURN(1) + OP_PUSHDATA1(2) + データ(80)
+// Bitcoin Core v30からはデフォルトで上限と1トランザクションに1つの制限が緩和されていますが、
+// 古いノードや-datacarriersizeを絞ったノードでもリレーされるように、あえてv27までのポリシーに合わせています
+// https://github.com/bitcoin/bitcoin/blob/v27.0/src/policy/policy.h
+const MaxDataCarrierSize = 83
+
+// NewDataPayload は識別用のプレフィックスを付けたデータを返します
+func NewDataPayload(prefix, data []byte) []byte {
+	return append(append([]byte{}, prefix...), data...)
+}
+
+// NewDocumentHashPayload はドキュメントのタイムスタンプ用にプレフィックスとドキュメントのSha256を連結したデータを返します
+func NewDocumentHashPayload(prefix, document []byte) []byte {
+	return NewDataPayload(prefix, hash.Sha256(document))
+}
+
+// AddDataOutput はデータを埋め込んだOP_RETURNの出力を追加します
+// 標準ポリシーに従い、スクリプトのサイズはMaxDataCarrierSize以下で、1トランザクションに1つまでです
+func (b *TxBuilder) AddDataOutput(data ...[]byte) error {
+	script := core.NewNullDataScript(data...)
+	if MaxDataCarrierSize < len(script) {
+		return errors.Errorf("OP_RETURNのスクリプトが大きすぎます: size=%d", len(script))
+	}
+	for _, out := range b.outputs {
+		if _, ok := core.ExtractNullData(out.PkScript); ok {
+			return errors.New("OP_RETURNの出力は1トランザクションに1つまでです")
+		}
+	}
+	b.AddOutput(0, script)
+	return nil
+}
+
+// DataOutput はトランザクションに埋め込まれたOP_RETURNのデータ
+type DataOutput struct {
+	// 出力のインデックス
+	Index int
+	// プッシュされたデータの一覧
+	Data [][]byte
+}
+
+// Payload はプッシュされたデータを連結して返します
+func (d *DataOutput) Payload() []byte {
+	return bytes.Join(d.Data, nil)
+}
+
+// ScanDataOutputs はトランザクションからOP_RETURNの出力のデータを取り出します
+func ScanDataOutputs(tx *protocol.Tx) []*DataOutput {
+	outputs := []*DataOutput{}
+	for i, out := range tx.TxOut {
+		if data, ok := core.ExtractNullData(out.PkScript); ok {
+			outputs = append(outputs, &DataOutput{Index: i, Data: data})
+		}
+	}
+	return outputs
+}
@@ -0,0 +1,108 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/fee"
+	"github.com/keiji0/btcwallet/protocol"
+)
+
+func TestDataOutput(t *testing.T) {
+	prefix := []byte("DOCS")
+	payload := NewDocumentHashPayload(prefix, []byte("document"))
+	if len(payload) != len(prefix)+32 {
+		t.Fatalf("ペイロードの長さが一致しません: %d", len(payload))
+	}
+
+	b := NewTxBuilder(fee.NewRateFromSatPerVByte(1), p2wpkhScript(0xcc), ScriptTypeP2WPKH)
+	b.AddInput(newTestUtxo(1, 100000, 1))
+	if err := b.AddDataOutput(payload); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddDataOutput([]byte("second")); err == nil {
+		t.Errorf("2つ目のOP_RETURNの出力が追加できました")
+	}
+	if err := NewTxBuilder(0, nil, ScriptTypeP2WPKH).AddDataOutput(bytes.Repeat([]byte{0x01}, 81)); err == nil {
+		t.Errorf("サイズの上限を超えたOP_RETURNの出力が追加できました")
+	}
+	if err := NewTxBuilder(0, nil, ScriptTypeP2WPKH).AddDataOutput(bytes.Repeat([]byte{0x01}, 80)); err != nil {
+		t.Errorf("サイズの上限のOP_RETURNの出力が追加できません: %v", err)
+	}
+
+	// 金額0のOP_RETURNの出力はダストにならない
+	built, err := b.Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs := ScanDataOutputs(built.Tx)
+	if len(outputs) != 1 {
+		t.Fatalf("OP_RETURNの出力の数が一致しません: %d", len(outputs))
+	}
+	if outputs[0].Index != 0 || !bytes.Equal(outputs[0].Payload(), payload) {
+		t.Errorf("OP_RETURNのデータが一致しません: %d, %x", outputs[0].Index, outputs[0].Payload())
+	}
+
+	// プッシュ命令以外を含むスクリプトはデータとして扱わない
+	tx := protocol.NewTx()
+	tx.AddTxOut(&protocol.TxOut{PkScript: []byte{byte(core.OpReturn), byte(core.OpDup)}})
+	if outputs := ScanDataOutputs(tx); len(outputs) != 0 {
+		t.Errorf("プッシュ命令以外を含むスクリプトがデータとして扱われました")
+	}
+}
+
+func TestAnchorOutput(t *testing.T) {
+	// エフェメラルアンカーは手数料0のTRUCトランザクションになる
+	b := NewTxBuilder(0, p2wpkhScript(0xcc), ScriptTypeP2WPKH)
+	b.AddInput(newTestUtxo(1, 100000, 1))
+	b.AddOutput(50000, p2wpkhScript(0xaa))
+	b.AddAnchorOutput(0)
+	built, err := b.Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if built.Tx.Version != protocol.TRUCTxVersion || built.Fee != 0 {
+		t.Errorf("エフェメラルアンカーのトランザクションが不正です: version=%d, fee=%d", built.Tx.Version, built.Fee)
+	}
+
+	b.FeeRate = fee.NewRateFromSatPerVByte(1)
+	if _, err := b.Build(nil); err == nil {
+		t.Errorf("手数料を支払うエフェメラルアンカーのトランザクションが組み立てられました")
+	}
+
+	// エフェメラルアンカーの他にダストの出力は持てない
+	b.FeeRate = 0
+	b.AddAnchorOutput(0)
+	if _, err := b.Build(nil); err == nil {
+		t.Errorf("ダストの出力を2つ持つトランザクションが組み立てられました")
+	}
+
+	// TRUCの仮想サイズの上限を超える場合は組み立てられない
+	b = NewTxBuilder(0, nil, ScriptTypeP2WPKH)
+	for i := 0; i < 150; i++ {
+		b.AddInput(&Utxo{
+			OutPoint:   protocol.OutPoint{Hash: protocol.Hash{byte(i), 1}},
+			Value:      1000,
+			ScriptType: ScriptTypeP2PKH,
+		})
+	}
+	b.AddAnchorOutput(0)
+	if _, err := b.Build(nil); err == nil {
+		t.Errorf("仮想サイズの上限を超えるTRUCトランザクションが組み立てられました")
+	}
+
+	// 金額のあるアンカー出力は通常の出力と同じ扱い
+	b = NewTxBuilder(fee.NewRateFromSatPerVByte(1), p2wpkhScript(0xcc), ScriptTypeP2WPKH)
+	b.AddInput(newTestUtxo(1, 100000, 1))
+	b.AddAnchorOutput(AnchorOutputValue)
+	if _, err := b.Build(nil); err != nil {
+		t.Errorf("アンカー出力のトランザクションが組み立てられません: %v", err)
+	}
+
+	script := NewP2WSHScript(NewAnchorWitnessScript(bytes.Repeat([]byte{0x02}, 33)))
+	if len(script) != 34 || script[0] != byte(core.Op0) || script[1] != 32 {
+		t.Errorf("P2WSHスクリプトが不正です: %x", script)
+	}
+}
@@ -0,0 +1,147 @@
+package wallet
+
+import (
+	"bytes"
+
+	"github.com/keiji0/btcwallet/fee"
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// 手数料の引き上げ
+// https://github.com/bitcoin/bips/blob/master/bip-0125.mediawiki
+
+// IncrementalRelayFee はBIP125で置き換えトランザクションが追加で支払う必要のある手数料率
+// https://github.com/bitcoin/bitcoin/blob/master/src/policy/policy.h
+const IncrementalRelayFee fee.Rate = 1000
+
+// ErrNotReplaceable はRBFを通知していないトランザクションを置き換えようとした場合のエラー
+var ErrNotReplaceable = errors.New("トランザクションはRBFを通知していません")
+
+// BumpFee はウォレットのトランザクションを高い手数料率で組み立て直した置き換えトランザクションを返します
+// BIP125のルールに従い、元のトランザクションの入力は全て使用し、追加の入力は承認済みのUTXOだけから選びます
+// 置き換えトランザクションの手数料は元の手数料にIncrementalRelayFee分を上乗せした額以上になります
+func BumpFee(orig *BuiltTx, feeRate fee.Rate, candidates []*Utxo) (*BuiltTx, error) {
+	if !orig.Tx.SignalsReplacement() {
+		return nil, ErrNotReplaceable
+	}
+	if feeRate <= orig.FeeRate() {
+		return nil, errors.Errorf("手数料率が元のトランザクションより高くありません: %d <= %d", feeRate, orig.FeeRate())
+	}
+
+	// お釣りがなかった場合は置き換えトランザクションでもお釣りを作らない
+	var changeScript []byte
+	if 0 <= orig.ChangeIndex {
+		changeScript = orig.Tx.TxOut[orig.ChangeIndex].PkScript
+	}
+
+	b := NewTxBuilder(feeRate, changeScript, orig.ChangeType)
+	b.RBF = true
+	b.minFee = func(vsize int) int64 {
+		return orig.Fee + IncrementalRelayFee.FeeForVSize(vsize)
+	}
+	b.lockTime = orig.Tx.LockTime
+	for i, in := range orig.Inputs {
+		// 相対ロックタイムは元のトランザクションのものを引き継ぐ
+		if sequence := orig.Tx.TxIn[i].Sequence; isRelativeLock(sequence) {
+			b.AddInputWithSequence(in, sequence)
+		} else {
+			b.AddInput(in)
+		}
+	}
+	for i, out := range orig.Tx.TxOut {
+		if i != orig.ChangeIndex {
+			b.AddOutput(out.Value, out.PkScript)
+		}
+	}
+
+	confirmed := []*Utxo{}
+	for _, c := range candidates {
+		if 0 < c.Confirmations {
+			confirmed = append(confirmed, c)
+		}
+	}
+	return b.Build(confirmed)
+}
+
+// CPFP は未承認のトランザクションのお釣りを使用する子トランザクションを作ります
+// signedはparentに署名したトランザクションで、P2PKHやP2SH-P2WPKHの入力は署名でtxidが変わるので子はsignedのtxidを使用します
+// 親と子を合わせた手数料率がfeeRateになるように子の手数料を決め、残りをpkScriptへ送ります
+// 入力と出力のスクリプト形式は親のお釣りの出力とpkScriptのスクリプトから判定します
+func CPFP(parent *BuiltTx, signed *protocol.Tx, feeRate fee.Rate, pkScript []byte) (*BuiltTx, error) {
+	if parent.ChangeIndex < 0 {
+		return nil, errors.New("親トランザクションにお釣りの出力がありません")
+	}
+	if err := checkSignedTx(parent, signed); err != nil {
+		return nil, err
+	}
+
+	change := signed.TxOut[parent.ChangeIndex]
+	inType, err := scriptTypeOf(change.PkScript)
+	if err != nil {
+		return nil, err
+	}
+	outType, err := scriptTypeOf(pkScript)
+	if err != nil {
+		return nil, err
+	}
+	in := &Utxo{
+		OutPoint: protocol.OutPoint{
+			Hash:  signed.TxHash(),
+			Index: uint32(parent.ChangeIndex),
+		},
+		Value:      change.Value,
+		PkScript:   change.PkScript,
+		ScriptType: inType,
+	}
+	out := &protocol.TxOut{PkScript: pkScript}
+	vsize := estimateVSize([]*Utxo{in}, []*protocol.TxOut{out}, nil)
+
+	childFee := feeRate.FeeForVSize(parent.VSize+vsize) - parent.Fee
+	// 親の手数料が十分でも子自身の手数料は支払う
+	if min := feeRate.FeeForVSize(vsize); childFee < min {
+		childFee = min
+	}
+	out.Value = in.Value - childFee
+	if isDust(out) {
+		return nil, ErrInsufficientFunds
+	}
+
+	tx := protocol.NewTx()
+	tx.AddTxIn(&protocol.TxIn{
+		PreviousOutPoint: in.OutPoint,
+		Sequence:         protocol.MaxBIP125Sequence,
+	})
+	tx.AddTxOut(out)
+
+	return &BuiltTx{
+		Tx:          tx,
+		Inputs:      []*Utxo{in},
+		Fee:         childFee,
+		VSize:       vsize,
+		ChangeIndex: 0,
+		ChangeType:  outType,
+	}, nil
+}
+
+// checkSignedTx は署名したトランザクションが組み立てたトランザクションと同じ入力と出力を持ち、
+// scriptSigが必要な入力に署名されているか検証する
+func checkSignedTx(built *BuiltTx, signed *protocol.Tx) error {
+	if len(signed.TxIn) != len(built.Tx.TxIn) || len(signed.TxOut) != len(built.Tx.TxOut) {
+		return errors.New("署名したトランザクションの入力か出力の数が一致しません")
+	}
+	for i, in := range signed.TxIn {
+		if in.PreviousOutPoint != built.Tx.TxIn[i].PreviousOutPoint {
+			return errors.Errorf("署名したトランザクションの入力が一致しません: index=%d", i)
+		}
+		if scriptType := built.Inputs[i].ScriptType; len(in.SignatureScript) == 0 && (scriptType == ScriptTypeP2PKH || scriptType == ScriptTypeP2SHP2WPKH) {
+			return errors.Errorf("入力に署名されていません: index=%d", i)
+		}
+	}
+	for i, out := range signed.TxOut {
+		if out.Value != built.Tx.TxOut[i].Value || !bytes.Equal(out.PkScript, built.Tx.TxOut[i].PkScript) {
+			return errors.Errorf("署名したトランザクションの出力が一致しません: index=%d", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package wallet
+
+import (
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// タイムロックの仕様
+// https://github.com/bitcoin/bips/blob/master/bip-0065.mediawiki
+// https://github.com/bitcoin/bips/blob/master/bip-0068.mediawiki
+// https://github.com/bitcoin/bips/blob/master/bip-0112.mediawiki
+
+// RelativeLockBlocks はブロック数で指定した相対ロックタイムのSequenceを返します
+func RelativeLockBlocks(blocks uint16) uint32 {
+	return uint32(blocks)
+}
+
+// RelativeLockSeconds は秒数で指定した相対ロックタイムのSequenceを返します
+// 相対ロックタイムは512秒単位なので、秒数は512秒単位に切り上げます
+func RelativeLockSeconds(seconds uint32) (uint32, error) {
+	const unit = 1 << protocol.SequenceLockTimeGranularity
+	units := (uint64(seconds) + unit - 1) / unit
+	if uint64(protocol.SequenceLockTimeMask) < units {
+		return 0, errors.Errorf("相対ロックタイムの秒数が大きすぎます: %d", seconds)
+	}
+	return protocol.SequenceLockTimeIsSeconds | uint32(units), nil
+}
+
+// NewCLTVScript はLockTimeを過ぎるまで公開鍵の持ち主が使用できない出力スクリプトを生成します
+// <lockTime> OP_CHECKLOCKTIMEVERIFY OP_DROP <pubKey> OP_CHECKSIG
+func NewCLTVScript(lockTime uint32, pubKey []byte) ([]byte, error) {
+	if lockTime == 0 {
+		return nil, errors.New("LockTimeが指定されていません")
+	}
+	return core.NewScriptBuilder().
+		AddInt64(int64(lockTime)).
+		AddOp(core.OpCheckLockTimeVerify).
+		AddOp(core.OpDrop).
+		AddData(pubKey).
+		AddOp(core.OpCheckSig).
+		Script(), nil
+}
+
+// NewCSVScript は出力が承認されてから相対ロックタイムを過ぎるまで公開鍵の持ち主が使用できない出力スクリプトを生成します
+// <sequence> OP_CHECKSEQUENCEVERIFY OP_DROP <pubKey> OP_CHECKSIG
+func NewCSVScript(sequence uint32, pubKey []byte) ([]byte, error) {
+	if !isRelativeLock(sequence) {
+		return nil, errors.Errorf("相対ロックタイムが無効なSequenceです: %#x", sequence)
+	}
+	return core.NewScriptBuilder().
+		AddInt64(int64(sequence)).
+		AddOp(core.OpCheckSequenceVerify).
+		AddOp(core.OpDrop).
+		AddData(pubKey).
+		AddOp(core.OpCheckSig).
+		Script(), nil
+}
+
+// isRelativeLock はSequenceがBIP68の相対ロックタイムとして有効か判定する
+func isRelativeLock(sequence uint32) bool {
+	return sequence&protocol.SequenceLockTimeDisabled == 0
+}
+
+// isSameLockTimeType は2つのLockTimeがどちらもブロックの高さか、どちらも時刻か判定する
+func isSameLockTimeType(a, b uint32) bool {
+	return (a < protocol.LockTimeThreshold) == (b < protocol.LockTimeThreshold)
+}
+
+// validateLockTime はトランザクションのLockTimeとSequenceの組み合わせで入力を使用できるか検証する
+func validateLockTime(tx *protocol.Tx, inputs []*Utxo) error {
+	final := true
+	for i, in := range tx.TxIn {
+		if in.Sequence != protocol.MaxTxInSequenceNum {
+			final = false
+		}
+		if isRelativeLock(in.Sequence) && tx.Version < 2 {
+			return errors.Errorf("相対ロックタイムにはバージョン2以上のトランザクションが必要です: version=%d", tx.Version)
+		}
+
+		utxo := inputs[i]
+		if utxo.RequiredLockTime != 0 {
+			if in.Sequence == protocol.MaxTxInSequenceNum {
+				return errors.Errorf("CLTVの出力を使用する入力のSequenceが最大値です: %v", utxo.OutPoint)
+			}
+			if !isSameLockTimeType(tx.LockTime, utxo.RequiredLockTime) {
+				return errors.Errorf("LockTimeの種類が出力の条件と一致しません: %d, %d", tx.LockTime, utxo.RequiredLockTime)
+			}
+			if tx.LockTime < utxo.RequiredLockTime {
+				return errors.Errorf("LockTimeが出力の条件より前です: %d < %d", tx.LockTime, utxo.RequiredLockTime)
+			}
+		}
+		if utxo.RequiredSequence != 0 {
+			if !isRelativeLock(in.Sequence) {
+				return errors.Errorf("CSVの出力を使用する入力の相対ロックタイムが無効です: %v", utxo.OutPoint)
+			}
+			if in.Sequence&protocol.SequenceLockTimeIsSeconds != utxo.RequiredSequence&protocol.SequenceLockTimeIsSeconds {
+				return errors.Errorf("相対ロックタイムの種類が出力の条件と一致しません: %#x, %#x", in.Sequence, utxo.RequiredSequence)
+			}
+			if in.Sequence&protocol.SequenceLockTimeMask < utxo.RequiredSequence&protocol.SequenceLockTimeMask {
+				return errors.Errorf("相対ロックタイムが出力の条件より短いです: %#x < %#x", in.Sequence, utxo.RequiredSequence)
+			}
+		}
+	}
+
+	if tx.LockTime != 0 && final {
+		return errors.New("全ての入力のSequenceが最大値なのでLockTimeが無効になります")
+	}
+	return nil
+}
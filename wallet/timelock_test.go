@@ -0,0 +1,161 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/keiji0/btcwallet/fee"
+	"github.com/keiji0/btcwallet/protocol"
+)
+
+func TestRelativeLockSeconds(t *testing.T) {
+	tests := []struct {
+		seconds  uint32
+		expected uint32
+	}{
+		{0, protocol.SequenceLockTimeIsSeconds},
+		{512, protocol.SequenceLockTimeIsSeconds | 1},
+		{513, protocol.SequenceLockTimeIsSeconds | 2},
+		{86400, protocol.SequenceLockTimeIsSeconds | 169},
+	}
+	for _, test := range tests {
+		sequence, err := RelativeLockSeconds(test.seconds)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if sequence != test.expected {
+			t.Errorf("Sequenceが一致しません: %d, %#x != %#x", test.seconds, sequence, test.expected)
+		}
+	}
+	if _, err := RelativeLockSeconds(0x10000 * 512); err == nil {
+		t.Errorf("範囲外の秒数が指定できました")
+	}
+}
+
+func TestTimeLockScript(t *testing.T) {
+	pubKey := bytes.Repeat([]byte{0x02}, 33)
+
+	cltv, err := NewCLTVScript(500000, pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(cltv[:6]) != "0320a107b175" {
+		t.Errorf("CLTVスクリプトが一致しません: %x", cltv)
+	}
+
+	csv, err := NewCSVScript(RelativeLockBlocks(144), pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(csv[:5]) != "029000b275" {
+		t.Errorf("CSVスクリプトが一致しません: %x", csv)
+	}
+
+	if _, err := NewCSVScript(protocol.SequenceLockTimeDisabled, pubKey); err == nil {
+		t.Errorf("無効な相対ロックタイムでCSVスクリプトが作れました")
+	}
+}
+
+func TestTxBuilderLockTime(t *testing.T) {
+	newBuilder := func() *TxBuilder {
+		b := NewTxBuilder(fee.NewRateFromSatPerVByte(1), p2wpkhScript(0xcc), ScriptTypeP2WPKH)
+		b.AddOutput(50000, p2wpkhScript(0xaa))
+		return b
+	}
+
+	// 高さでロックしたトランザクション
+	b := newBuilder()
+	if err := b.SetLockTimeHeight(800000); err != nil {
+		t.Fatal(err)
+	}
+	b.AddInput(newTestUtxo(1, 100000, 1))
+	built, err := b.Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if built.Tx.LockTime != 800000 || built.Tx.TxIn[0].Sequence == protocol.MaxTxInSequenceNum {
+		t.Errorf("LockTimeが有効になっていません: locktime=%d, sequence=%#x", built.Tx.LockTime, built.Tx.TxIn[0].Sequence)
+	}
+	if err := b.SetLockTimeHeight(protocol.LockTimeThreshold); err == nil {
+		t.Errorf("時刻をブロックの高さとして指定できました")
+	}
+
+	// 全ての入力のSequenceが最大値だとLockTimeが無効になる
+	b = newBuilder()
+	if err := b.SetLockTime(time.Unix(1700000000, 0)); err != nil {
+		t.Fatal(err)
+	}
+	b.AddInputWithSequence(newTestUtxo(1, 100000, 1), protocol.MaxTxInSequenceNum)
+	if _, err := b.Build(nil); err == nil {
+		t.Errorf("LockTimeが無効なトランザクションが組み立てられました")
+	}
+
+	// CLTVの出力はLockTimeの種類と値が条件を満たす必要がある
+	cltvUtxo := newTestUtxo(2, 100000, 1)
+	cltvUtxo.RequiredLockTime = 800000
+	tests := []struct {
+		height uint32
+		ok     bool
+	}{
+		{799999, false},
+		{800000, true},
+		{800001, true},
+	}
+	for _, test := range tests {
+		b = newBuilder()
+		if err := b.SetLockTimeHeight(test.height); err != nil {
+			t.Fatal(err)
+		}
+		b.AddInput(cltvUtxo)
+		if _, err := b.Build(nil); (err == nil) != test.ok {
+			t.Errorf("CLTVの検証結果が一致しません: height=%d, %v", test.height, err)
+		}
+	}
+	b = newBuilder()
+	if err := b.SetLockTime(time.Unix(1700000000, 0)); err != nil {
+		t.Fatal(err)
+	}
+	b.AddInput(cltvUtxo)
+	if _, err := b.Build(nil); err == nil {
+		t.Errorf("LockTimeの種類が異なるのにCLTVの出力を使用できました")
+	}
+}
+
+func TestTxBuilderRelativeLock(t *testing.T) {
+	csvUtxo := newTestUtxo(1, 100000, 10)
+	csvUtxo.RequiredSequence = RelativeLockBlocks(144)
+
+	// 指定しなければ出力の条件の相対ロックタイムが使われる
+	b := NewTxBuilder(fee.NewRateFromSatPerVByte(1), p2wpkhScript(0xcc), ScriptTypeP2WPKH)
+	b.AddOutput(50000, p2wpkhScript(0xaa))
+	b.AddInput(csvUtxo)
+	built, err := b.Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if built.Tx.TxIn[0].Sequence != 144 {
+		t.Errorf("相対ロックタイムが設定されていません: %#x", built.Tx.TxIn[0].Sequence)
+	}
+
+	seconds, _ := RelativeLockSeconds(86400)
+	tests := []struct {
+		sequence uint32
+		ok       bool
+	}{
+		{RelativeLockBlocks(143), false},
+		{RelativeLockBlocks(200), true},
+		{seconds, false},
+		{protocol.MaxBIP125Sequence, false},
+	}
+	for _, test := range tests {
+		b := NewTxBuilder(fee.NewRateFromSatPerVByte(1), p2wpkhScript(0xcc), ScriptTypeP2WPKH)
+		b.AddOutput(50000, p2wpkhScript(0xaa))
+		b.AddInputWithSequence(csvUtxo, test.sequence)
+		if _, err := b.Build(nil); (err == nil) != test.ok {
+			t.Errorf("CSVの検証結果が一致しません: sequence=%#x, %v", test.sequence, err)
+		}
+	}
+}
@@ -0,0 +1,393 @@
+package wallet
+
+import (
+	"sort"
+	"time"
+
+	"github.com/keiji0/btcwallet/core"
+	"github.com/keiji0/btcwallet/fee"
+	"github.com/keiji0/btcwallet/protocol"
+	"github.com/pkg/errors"
+)
+
+// ErrInsufficientFunds は出力と手数料を支払うための残高が足りない場合のエラー
+var ErrInsufficientFunds = errors.New("残高が足りません")
+
+// dustRelayFee はダストの判定に使う手数料率
+// https://github.com/bitcoin/bitcoin/blob/master/src/policy/policy.h
+const dustRelayFee fee.Rate = 3000
+
+// witnessScaleFactor はウィットネスデータ以外のデータのweightの倍率
+// https://github.com/bitcoin/bips/blob/master/bip-0141.mediawiki
+const witnessScaleFactor = 4
+
+// Utxo はウォレットが使用できる未使用のトランザクション出力
+type Utxo struct {
+	OutPoint   protocol.OutPoint
+	Value      int64
+	PkScript   []byte
+	ScriptType ScriptType
+	// 承認数、0の場合はメモリプールにある未承認の出力
+	Confirmations int32
+	// 出力を使用するのに必要なLockTime、CLTVスクリプトの出力の場合に指定する
+	// 0の場合は制約なし
+	RequiredLockTime uint32
+	// 出力を使用するのに必要なBIP68の相対ロックタイム、CSVスクリプトの出力の場合に指定する
+	// 0の場合は制約なし
+	RequiredSequence uint32
+}
+
+// BuiltTx はTxBuilderで組み立てた未署名のトランザクション
+type BuiltTx struct {
+	Tx *protocol.Tx
+	// Tx.TxInと同じ順番の使用するUTXO
+	Inputs []*Utxo
+	// 支払う手数料(satoshi)
+	Fee int64
+	// 署名後の仮想サイズの見積もり
+	VSize int
+	// お釣りの出力のインデックス、お釣りがない場合は-1
+	ChangeIndex int
+	// お釣りの出力のスクリプト形式
+	ChangeType ScriptType
+}
+
+// FeeRate はトランザクションの実際の手数料率を返します
+func (b *BuiltTx) FeeRate() fee.Rate {
+	return fee.Rate(b.Fee * 1000 / int64(b.VSize))
+}
+
+// TxBuilder は手数料率に応じてUTXOを選択し、未署名のトランザクションを組み立てる型
+type TxBuilder struct {
+	// 手数料率
+	FeeRate fee.Rate
+	// BIP125のRBFを通知するか
+	RBF bool
+
+	changeScript []byte
+	changeType   ScriptType
+	inputs       []*Utxo
+	outputs      []*protocol.TxOut
+	lockTime     uint32
+	// 入力ごとに指定したSequence
+	sequences map[protocol.OutPoint]uint32
+	// 仮想サイズから最低限支払う必要のある手数料を求める、手数料の引き上げ時に使う
+	minFee func(vsize int) int64
+}
+
+// NewTxBuilder はお釣りの送り先を指定してTxBuilderを生成します
+// changeScriptがnilの場合はお釣りを作らず、余った金額は全て手数料になります
+func NewTxBuilder(feeRate fee.Rate, changeScript []byte, changeType ScriptType) *TxBuilder {
+	return &TxBuilder{
+		FeeRate:      feeRate,
+		changeScript: changeScript,
+		changeType:   changeType,
+		sequences:    map[protocol.OutPoint]uint32{},
+	}
+}
+
+// AddInput は必ず使用するUTXOを追加します
+func (b *TxBuilder) AddInput(utxo *Utxo) {
+	b.inputs = append(b.inputs, utxo)
+}
+
+// AddInputWithSequence はSequenceを指定して必ず使用するUTXOを追加します
+// BIP68の相対ロックタイムを設定する場合に使います
+func (b *TxBuilder) AddInputWithSequence(utxo *Utxo, sequence uint32) {
+	b.AddInput(utxo)
+	b.sequences[utxo.OutPoint] = sequence
+}
+
+// SetLockTimeHeight はトランザクションをブロックの高さでロックします
+func (b *TxBuilder) SetLockTimeHeight(height uint32) error {
+	if protocol.LockTimeThreshold <= height {
+		return errors.Errorf("ブロックの高さが大きすぎます: %d", height)
+	}
+	b.lockTime = height
+	return nil
+}
+
+// SetLockTime はトランザクションを時刻でロックします
+// ロックが解除されるのは直近11ブロックのタイムスタンプの中央値がこの時刻を過ぎてからになります
+func (b *TxBuilder) SetLockTime(t time.Time) error {
+	unix := t.Unix()
+	if unix < int64(protocol.LockTimeThreshold) || int64(protocol.MaxTxInSequenceNum) < unix {
+		return errors.Errorf("LockTimeに指定できない時刻です: %v", t)
+	}
+	b.lockTime = uint32(unix)
+	return nil
+}
+
+// AddOutput は送金先の出力を追加します
+func (b *TxBuilder) AddOutput(value int64, pkScript []byte) {
+	b.outputs = append(b.outputs, &protocol.TxOut{
+		Value:    value,
+		PkScript: pkScript,
+	})
+}
+
+// Build はトランザクションを組み立てます
+// AddInputで追加したUTXOで足りない場合はcandidatesから金額の大きい順にUTXOを追加します
+// お釣りがダストになる場合はお釣りの出力を作らずに手数料に含めます
+// 組み立てたトランザクションのLockTimeとSequenceで入力を使用できない場合はエラーになります
+func (b *TxBuilder) Build(candidates []*Utxo) (*BuiltTx, error) {
+	if len(b.outputs) == 0 {
+		return nil, errors.New("出力がありません")
+	}
+	var outValue int64
+	ephemeral := false
+	for _, out := range b.outputs {
+		if isEphemeralAnchor(out) {
+			// ダストの出力はエフェメラルアンカーの1つだけしかリレーされない
+			if ephemeral {
+				return nil, errors.New("エフェメラルアンカーは1トランザクションに1つまでです")
+			}
+			ephemeral = true
+		} else if isDust(out) {
+			return nil, errors.Errorf("出力の金額がダストです: value=%d", out.Value)
+		}
+		outValue += out.Value
+	}
+	// エフェメラルアンカーは子トランザクションが手数料を全て支払う前提なので親の手数料は0にする
+	if ephemeral && (b.FeeRate != 0 || b.minFee != nil) {
+		return nil, errors.New("エフェメラルアンカーを持つトランザクションの手数料は0にする必要があります")
+	}
+
+	inputs := append([]*Utxo{}, b.inputs...)
+	var inValue int64
+	for _, in := range inputs {
+		inValue += in.Value
+	}
+	candidates = b.sortCandidates(candidates)
+
+	change := &protocol.TxOut{PkScript: b.changeScript}
+	for {
+		feeWithChange := b.requiredFee(estimateVSize(inputs, b.outputs, change))
+		if b.changeScript != nil && outValue+feeWithChange <= inValue {
+			change.Value = inValue - outValue - feeWithChange
+			if !isDust(change) {
+				return b.newBuiltTx(inputs, change, feeWithChange)
+			}
+		}
+
+		// お釣りを作らない場合は余った金額が全て手数料になる
+		feeWithoutChange := b.requiredFee(estimateVSize(inputs, b.outputs, nil))
+		if outValue+feeWithoutChange <= inValue {
+			return b.newBuiltTx(inputs, nil, inValue-outValue)
+		}
+
+		if len(candidates) == 0 {
+			return nil, ErrInsufficientFunds
+		}
+		inputs = append(inputs, candidates[0])
+		inValue += candidates[0].Value
+		candidates = candidates[1:]
+	}
+}
+
+// sortCandidates は既に使用しているUTXOを除いて金額の大きい順に並べる
+func (b *TxBuilder) sortCandidates(candidates []*Utxo) []*Utxo {
+	used := map[protocol.OutPoint]bool{}
+	for _, in := range b.inputs {
+		used[in.OutPoint] = true
+	}
+	sorted := []*Utxo{}
+	for _, c := range candidates {
+		if !used[c.OutPoint] {
+			sorted = append(sorted, c)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Value > sorted[j].Value
+	})
+	return sorted
+}
+
+// requiredFee は仮想サイズのトランザクションに必要な手数料を返す
+func (b *TxBuilder) requiredFee(vsize int) int64 {
+	required := b.FeeRate.FeeForVSize(vsize)
+	if b.minFee != nil {
+		if min := b.minFee(vsize); required < min {
+			required = min
+		}
+	}
+	return required
+}
+
+// newBuiltTx は選択したUTXOと出力からBuiltTxを生成する
+func (b *TxBuilder) newBuiltTx(inputs []*Utxo, change *protocol.TxOut, txFee int64) (*BuiltTx, error) {
+	tx := protocol.NewTx()
+	tx.LockTime = b.lockTime
+	for _, out := range b.outputs {
+		if isEphemeralAnchor(out) {
+			tx.Version = protocol.TRUCTxVersion
+		}
+	}
+	for _, in := range inputs {
+		tx.AddTxIn(&protocol.TxIn{
+			PreviousOutPoint: in.OutPoint,
+			Sequence:         b.sequence(in),
+		})
+	}
+	for _, out := range b.outputs {
+		tx.AddTxOut(&protocol.TxOut{Value: out.Value, PkScript: out.PkScript})
+	}
+
+	changeIndex := -1
+	if change != nil {
+		changeIndex = len(tx.TxOut)
+		tx.AddTxOut(change)
+	}
+
+	if err := validateLockTime(tx, inputs); err != nil {
+		return nil, err
+	}
+	vsize := estimateVSize(inputs, b.outputs, change)
+	if tx.Version == protocol.TRUCTxVersion && TRUCMaxVSize < vsize {
+		return nil, errors.Errorf("TRUCトランザクションの仮想サイズが大きすぎます: vsize=%d", vsize)
+	}
+
+	return &BuiltTx{
+		Tx:          tx,
+		Inputs:      inputs,
+		Fee:         txFee,
+		VSize:       vsize,
+		ChangeIndex: changeIndex,
+		ChangeType:  b.changeType,
+	}, nil
+}
+
+// sequence は入力に設定するSequenceを返す
+func (b *TxBuilder) sequence(in *Utxo) uint32 {
+	if sequence, ok := b.sequences[in.OutPoint]; ok {
+		return sequence
+	}
+	switch {
+	case in.RequiredSequence != 0:
+		return in.RequiredSequence
+	case b.RBF:
+		return protocol.MaxBIP125Sequence
+	case b.lockTime != 0:
+		// LockTimeを有効にするために最大値より小さくする
+		return protocol.MaxTxInSequenceNum - 1
+	default:
+		return protocol.MaxTxInSequenceNum
+	}
+}
+
+// estimateVSize は署名後のトランザクションの仮想サイズを見積もる
+func estimateVSize(inputs []*Utxo, outputs []*protocol.TxOut, change *protocol.TxOut) int {
+	if change != nil {
+		outputs = append(append([]*protocol.TxOut{}, outputs...), change)
+	}
+
+	// Version(4) + LockTime(4) + 入出力の数
+	weight := (8 + varUintSize(len(inputs)) + varUintSize(len(outputs))) * witnessScaleFactor
+	hasWitness := false
+	for _, in := range inputs {
+		weight += inputWeight(in.ScriptType)
+		if in.ScriptType != ScriptTypeP2PKH {
+			hasWitness = true
+		}
+	}
+	if hasWitness {
+		// マーカーとフラグ、ウィットネスを持たない入力のアイテム数
+		weight += 2
+		for _, in := range inputs {
+			if in.ScriptType == ScriptTypeP2PKH {
+				weight++
+			}
+		}
+	}
+	for _, out := range outputs {
+		weight += outputSize(out) * witnessScaleFactor
+	}
+	return (weight + witnessScaleFactor - 1) / witnessScaleFactor
+}
+
+// inputWeight は署名後の入力のweightを返す
+func inputWeight(scriptType ScriptType) int {
+	// OutPoint(36) + Sequence(4)
+	const base = 40
+	switch scriptType {
+	case ScriptTypeP2SHP2WPKH:
+		// スクリプト(1+23) + ウィットネス(アイテム数1 + 署名1+72 + 公開鍵1+33)
+		return (base+24)*witnessScaleFactor + 108
+	case ScriptTypeP2WPKH:
+		// スクリプト(1) + ウィットネス(アイテム数1 + 署名1+72 + 公開鍵1+33)
+		return (base+1)*witnessScaleFactor + 108
+	case ScriptTypeP2TR:
+		// スクリプト(1) + ウィットネス(アイテム数1 + シュノア署名1+64)
+		return (base+1)*witnessScaleFactor + 66
+	default:
+		// スクリプト(1 + 署名1+72 + 公開鍵1+33)
+		return (base + 108) * witnessScaleFactor
+	}
+}
+
+// outputSize は出力のバイトサイズを返す
+func outputSize(out *protocol.TxOut) int {
+	return 8 + varUintSize(len(out.PkScript)) + len(out.PkScript)
+}
+
+// varUintSize はVarUintでシリアライズした時のバイトサイズを返す
+func varUintSize(n int) int {
+	switch {
+	case n <= 0xfc:
+		return 1
+	case n <= 0xffff:
+		return 3
+	case n <= 0xffffffff:
+		return 5
+	default:
+		return 9
+	}
+}
+
+// isDust は出力を使用するための手数料が出力の金額を上回るか判定する
+// https://github.com/bitcoin/bitcoin/blob/master/src/policy/policy.cpp
+func isDust(out *protocol.TxOut) bool {
+	if core.IsUnspendable(out.PkScript) {
+		return false
+	}
+	// 出力を使用する入力の大きさ
+	spendSize := 148
+	if isWitnessProgram(out.PkScript) {
+		spendSize = 67
+	}
+	return out.Value < dustRelayFee.FeeForVSize(outputSize(out)+spendSize)
+}
+
+// isWitnessProgram はスクリプトがBIP141のウィットネスプログラムか判定する
+func isWitnessProgram(pkScript []byte) bool {
+	if len(pkScript) < 4 || 42 < len(pkScript) {
+		return false
+	}
+	// バージョン(OP_0かOP_1からOP_16) + プッシュ(2から40バイト)
+	version := pkScript[0]
+	if version != 0x00 && (version < 0x51 || 0x60 < version) {
+		return false
+	}
+	return int(pkScript[1])+2 == len(pkScript)
+}
+
+// scriptTypeOf は出力スクリプトの形式からウォレットが使用できるスクリプト形式を判定する
+// P2SHはウォレットが作るBIP49のP2SH-P2WPKHとして扱います
+func scriptTypeOf(pkScript []byte) (ScriptType, error) {
+	switch {
+	case len(pkScript) == 25 && pkScript[0] == 0x76 && pkScript[1] == 0xa9 && pkScript[2] == 0x14 && pkScript[23] == 0x88 && pkScript[24] == 0xac:
+		// OP_DUP OP_HASH160 <20バイト> OP_EQUALVERIFY OP_CHECKSIG
+		return ScriptTypeP2PKH, nil
+	case len(pkScript) == 23 && pkScript[0] == 0xa9 && pkScript[1] == 0x14 && pkScript[22] == 0x87:
+		// OP_HASH160 <20バイト> OP_EQUAL
+		return ScriptTypeP2SHP2WPKH, nil
+	case len(pkScript) == 22 && pkScript[0] == 0x00 && pkScript[1] == 0x14:
+		// OP_0 <20バイト>
+		return ScriptTypeP2WPKH, nil
+	case len(pkScript) == 34 && pkScript[0] == 0x51 && pkScript[1] == 0x20:
+		// OP_1 <32バイト>
+		return ScriptTypeP2TR, nil
+	default:
+		return 0, errors.Errorf("未対応のスクリプト形式です: %x", pkScript)
+	}
+}
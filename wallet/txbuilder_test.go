@@ -0,0 +1,233 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keiji0/btcwallet/fee"
+	"github.com/keiji0/btcwallet/protocol"
+)
+
+// p2wpkhScript はテスト用のP2WPKHの出力スクリプトを返す
+func p2wpkhScript(tag byte) []byte {
+	return append([]byte{0x00, 0x14}, bytes.Repeat([]byte{tag}, 20)...)
+}
+
+// newTestUtxo はテスト用のP2WPKHのUTXOを生成する
+func newTestUtxo(tag byte, value int64, confirmations int32) *Utxo {
+	return &Utxo{
+		OutPoint:      protocol.OutPoint{Hash: protocol.Hash{tag}},
+		Value:         value,
+		PkScript:      p2wpkhScript(tag),
+		ScriptType:    ScriptTypeP2WPKH,
+		Confirmations: confirmations,
+	}
+}
+
+func TestTxBuilder(t *testing.T) {
+	changeScript := p2wpkhScript(0xcc)
+	b := NewTxBuilder(fee.NewRateFromSatPerVByte(10), changeScript, ScriptTypeP2WPKH)
+	b.AddOutput(150000, p2wpkhScript(0xaa))
+
+	candidates := []*Utxo{
+		newTestUtxo(1, 100000, 1),
+		newTestUtxo(2, 80000, 1),
+		newTestUtxo(3, 10000, 1),
+	}
+	built, err := b.Build(candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(built.Inputs) != 2 {
+		t.Fatalf("入力の数が一致しません: %d", len(built.Inputs))
+	}
+	if built.ChangeIndex != 1 {
+		t.Fatalf("お釣りのインデックスが一致しません: %d", built.ChangeIndex)
+	}
+	// 2入力2出力のP2WPKHは209vB
+	if built.VSize != 209 {
+		t.Errorf("仮想サイズが一致しません: %d", built.VSize)
+	}
+	if built.Fee != 2090 {
+		t.Errorf("手数料が一致しません: %d", built.Fee)
+	}
+	if change := built.Tx.TxOut[built.ChangeIndex]; change.Value != 180000-150000-2090 {
+		t.Errorf("お釣りの金額が一致しません: %d", change.Value)
+	}
+	if built.Tx.SignalsReplacement() {
+		t.Errorf("RBFを指定していないのに置き換え可能になっています")
+	}
+
+	if _, err := b.Build(candidates[2:]); err != ErrInsufficientFunds {
+		t.Errorf("残高が足りないのにトランザクションが組み立てられました: %v", err)
+	}
+}
+
+func TestTxBuilderDustChange(t *testing.T) {
+	b := NewTxBuilder(fee.NewRateFromSatPerVByte(1), p2wpkhScript(0xcc), ScriptTypeP2WPKH)
+	b.AddInput(newTestUtxo(1, 100000, 1))
+	b.AddOutput(99700, p2wpkhScript(0xaa))
+
+	built, err := b.Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if built.ChangeIndex != -1 {
+		t.Errorf("お釣りがダストなのにお釣りの出力が作られました")
+	}
+	if built.Fee != 300 {
+		t.Errorf("余った金額が手数料になっていません: %d", built.Fee)
+	}
+}
+
+func TestBumpFee(t *testing.T) {
+	b := NewTxBuilder(fee.NewRateFromSatPerVByte(2), p2wpkhScript(0xcc), ScriptTypeP2WPKH)
+	b.RBF = true
+	b.AddInput(newTestUtxo(1, 50000, 0))
+	b.AddOutput(45000, p2wpkhScript(0xaa))
+	orig, err := b.Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !orig.Tx.SignalsReplacement() {
+		t.Fatalf("RBFが通知されていません")
+	}
+
+	candidates := []*Utxo{
+		// 未承認のUTXOは追加の入力に使えない
+		newTestUtxo(2, 100000, 0),
+		newTestUtxo(3, 20000, 3),
+	}
+	bumped, err := BumpFee(orig, fee.NewRateFromSatPerVByte(100), candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bumped.Inputs) != 2 || bumped.Inputs[1].OutPoint != candidates[1].OutPoint {
+		t.Fatalf("承認済みのUTXOが追加されていません")
+	}
+	if bumped.Fee < orig.Fee+IncrementalRelayFee.FeeForVSize(bumped.VSize) {
+		t.Errorf("BIP125の手数料の条件を満たしていません: %d", bumped.Fee)
+	}
+	if bumped.Tx.TxOut[0].Value != 45000 {
+		t.Errorf("送金先の金額が変わっています: %d", bumped.Tx.TxOut[0].Value)
+	}
+
+	// わずかに高い手数料率でも元の手数料+IncrementalRelayFee以上を支払う
+	bumped, err = BumpFee(orig, orig.FeeRate()+1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bumped.Fee < orig.Fee+IncrementalRelayFee.FeeForVSize(bumped.VSize) {
+		t.Errorf("BIP125の手数料の条件を満たしていません: %d", bumped.Fee)
+	}
+
+	if _, err := BumpFee(orig, orig.FeeRate(), nil); err == nil {
+		t.Errorf("手数料率が上がっていないのに置き換えできました")
+	}
+
+	b.RBF = false
+	final, err := b.Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := BumpFee(final, fee.NewRateFromSatPerVByte(100), nil); err != ErrNotReplaceable {
+		t.Errorf("RBFを通知していないのに置き換えできました: %v", err)
+	}
+}
+
+// signTx はテスト用に組み立てたトランザクションの入力へダミーの署名を付けたコピーを返す
+func signTx(t *testing.T, built *BuiltTx) *protocol.Tx {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := built.Tx.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	signed := protocol.NewTx()
+	if err := signed.Deserialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for i, in := range built.Inputs {
+		switch in.ScriptType {
+		case ScriptTypeP2PKH:
+			signed.TxIn[i].SignatureScript = bytes.Repeat([]byte{0x01}, 107)
+		case ScriptTypeP2SHP2WPKH:
+			signed.TxIn[i].SignatureScript = append([]byte{0x16}, p2wpkhScript(0x02)...)
+			signed.TxIn[i].Witness = protocol.TxWitness{bytes.Repeat([]byte{0x03}, 72), bytes.Repeat([]byte{0x04}, 33)}
+		default:
+			signed.TxIn[i].Witness = protocol.TxWitness{bytes.Repeat([]byte{0x03}, 72), bytes.Repeat([]byte{0x04}, 33)}
+		}
+	}
+	return signed
+}
+
+func TestCPFP(t *testing.T) {
+	p2pkh := &Utxo{
+		OutPoint:      protocol.OutPoint{Hash: protocol.Hash{2}},
+		Value:         100000,
+		PkScript:      append(append([]byte{0x76, 0xa9, 0x14}, bytes.Repeat([]byte{2}, 20)...), 0x88, 0xac),
+		ScriptType:    ScriptTypeP2PKH,
+		Confirmations: 1,
+	}
+	for _, input := range []*Utxo{newTestUtxo(1, 100000, 1), p2pkh} {
+		b := NewTxBuilder(fee.NewRateFromSatPerVByte(1), p2wpkhScript(0xcc), ScriptTypeP2WPKH)
+		b.AddInput(input)
+		b.AddOutput(50000, p2wpkhScript(0xaa))
+		parent, err := b.Build(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		feeRate := fee.NewRateFromSatPerVByte(20)
+		if _, err := CPFP(parent, parent.Tx, feeRate, p2wpkhScript(0xdd)); input.ScriptType == ScriptTypeP2PKH && err == nil {
+			t.Error("署名していない親トランザクションで子トランザクションを作れました")
+		}
+		signed := signTx(t, parent)
+		child, err := CPFP(parent, signed, feeRate, p2wpkhScript(0xdd))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// P2PKHの入力は署名でtxidが変わるので、署名したトランザクションのお釣りを使用する
+		in := child.Tx.TxIn[0].PreviousOutPoint
+		if in.Hash != signed.TxHash() || in.Index != uint32(parent.ChangeIndex) {
+			t.Errorf("親トランザクションのお釣りを使用していません: %v", in)
+		}
+		if input.ScriptType == ScriptTypeP2PKH && in.Hash == parent.Tx.TxHash() {
+			t.Error("署名する前のtxidを使用しています")
+		}
+		if parent.Fee+child.Fee != feeRate.FeeForVSize(parent.VSize+child.VSize) {
+			t.Errorf("親子の手数料率が目標と一致しません: %d", parent.Fee+child.Fee)
+		}
+		if child.Tx.TxOut[0].Value != parent.Tx.TxOut[parent.ChangeIndex].Value-child.Fee {
+			t.Errorf("子トランザクションの出力の金額が一致しません: %d", child.Tx.TxOut[0].Value)
+		}
+	}
+}
+
+func TestCPFPScriptType(t *testing.T) {
+	// 親のChangeTypeではなくお釣りのスクリプトから入力の形式を判定する
+	p2pkhChange := append(append([]byte{0x76, 0xa9, 0x14}, bytes.Repeat([]byte{0xcc}, 20)...), 0x88, 0xac)
+	b := NewTxBuilder(fee.NewRateFromSatPerVByte(1), p2pkhChange, ScriptTypeP2WPKH)
+	b.AddInput(newTestUtxo(1, 100000, 1))
+	b.AddOutput(50000, p2wpkhScript(0xaa))
+	parent, err := b.Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := signTx(t, parent)
+
+	p2tr := append([]byte{0x51, 0x20}, bytes.Repeat([]byte{0xdd}, 32)...)
+	child, err := CPFP(parent, signed, fee.NewRateFromSatPerVByte(20), p2tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.Inputs[0].ScriptType != ScriptTypeP2PKH {
+		t.Errorf("入力のスクリプト形式が一致しません: %d", child.Inputs[0].ScriptType)
+	}
+	if child.ChangeType != ScriptTypeP2TR {
+		t.Errorf("お釣りのスクリプト形式が一致しません: %d", child.ChangeType)
+	}
+	if _, err := CPFP(parent, signed, fee.NewRateFromSatPerVByte(20), []byte{0x6a}); err == nil {
+		t.Error("未対応のスクリプトに送る子トランザクションを作れました")
+	}
+}